@@ -0,0 +1,13 @@
+# Fail a CI run early when the API cannot be reached with the configured credentials
+data "zillaforge_connectivity" "check" {
+  lifecycle {
+    postcondition {
+      condition     = self.reachable
+      error_message = "Zillaforge API unreachable: ${coalesce(self.error, "unknown error")}"
+    }
+  }
+}
+
+output "api_latency_ms" {
+  value = data.zillaforge_connectivity.check.latency_ms
+}
@@ -42,6 +42,18 @@ output "matched_flavors" {
   value = data.zillaforge_flavors.specific_requirements.flavors
 }
 
+# Smallest flavor matching a name pattern and minimum size
+data "zillaforge_flavors" "smallest" {
+  name_pattern  = "m1.*"
+  min_vcpus     = 2
+  min_memory_mb = 4096
+  sort_by       = "vcpus"
+}
+
+output "smallest_flavor_id" {
+  value = data.zillaforge_flavors.smallest.flavors[0].id
+}
+
 # Use flavor in resource configuration (example integration)
 data "zillaforge_flavors" "compute" {
   vcpus  = 2
@@ -0,0 +1,12 @@
+# Look up an image whose ID is already known, e.g. stored in another stack's outputs
+data "zillaforge_image" "base" {
+  id = var.base_image_id
+}
+
+output "base_image" {
+  value = "${data.zillaforge_image.base.repository_name}:${data.zillaforge_image.base.tag_name}"
+}
+
+output "base_image_os" {
+  value = data.zillaforge_image.base.operating_system
+}
@@ -38,3 +38,14 @@ output "public_key" {
   description = "Public key of the specific keypair"
   value       = length(data.zillaforge_keypairs.specific.keypairs) > 0 ? data.zillaforge_keypairs.specific.keypairs[0].public_key : null
 }
+
+# Check whether a public key is already registered, e.g. to enforce one
+# keypair per key in CI. No match yields an empty list, not an error.
+data "zillaforge_keypairs" "by_fingerprint" {
+  fingerprint = "SHA256:+DiY3wvvV6TuJJhbpZisF/zLDA0zPMSvHdkr4UvCOqU"
+}
+
+output "key_already_registered" {
+  description = "Whether a keypair with this fingerprint exists"
+  value       = length(data.zillaforge_keypairs.by_fingerprint.keypairs) > 0
+}
@@ -0,0 +1,20 @@
+# Look up a network by name and attach a server to it
+data "zillaforge_network" "prod" {
+  name = "prod"
+}
+
+resource "zillaforge_server" "app" {
+  name      = "app"
+  flavor_id = var.flavor_id
+  image_id  = var.image_id
+  password  = var.password
+
+  network_attachment {
+    network_id = data.zillaforge_network.prod.id
+    primary    = true
+  }
+}
+
+output "prod_cidr" {
+  value = data.zillaforge_network.prod.cidr
+}
@@ -0,0 +1,15 @@
+# List the volumes attached to a server, sorted by device
+data "zillaforge_server_volumes" "web" {
+  server_id = zillaforge_server.web.id
+}
+
+output "web_disk_layout" {
+  value = {
+    for v in data.zillaforge_server_volumes.web.volumes : v.device => v.size_gb
+  }
+}
+
+# The volume the server boots from
+output "web_system_volume_id" {
+  value = one([for v in data.zillaforge_server_volumes.web.volumes : v.id if v.boot_index == 0])
+}
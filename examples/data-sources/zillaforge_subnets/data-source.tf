@@ -0,0 +1,24 @@
+# Look up the subnet of a network
+data "zillaforge_networks" "app" {
+  name = "app-private-network"
+}
+
+data "zillaforge_subnets" "app" {
+  network_id = data.zillaforge_networks.app.networks[0].id
+}
+
+output "app_subnet" {
+  value = data.zillaforge_subnets.app.subnets[0]
+}
+
+# Pick a fixed IP inside the subnet (check it falls within allocation_pools)
+resource "zillaforge_server" "db" {
+  name      = "db-01"
+  flavor_id = "flavor-id"
+  image_id  = "image-id"
+
+  network_attachment {
+    network_id = data.zillaforge_networks.app.networks[0].id
+    ip_address = cidrhost(data.zillaforge_subnets.app.subnets[0].cidr, 10)
+  }
+}
@@ -47,3 +47,62 @@ provider "zillaforge" {
 # export ZILLAFORGE_API_KEY="eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
 # export ZILLAFORGE_PROJECT_ID="12345"
 # terraform plan
+
+# Shared config file usage example:
+# Credentials can also be read from an INI-style file (default ~/.zillaforge/config).
+# Explicit provider attributes take precedence over environment variables,
+# which take precedence over values from the config file.
+#
+#   [default]
+#   api_key    = eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
+#   project_id = 12345
+#
+#   [staging]
+#   api_endpoint     = https://staging.api.zillaforge.com
+#   api_key          = eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
+#   project_sys_code = my-project-code
+provider "zillaforge" {
+  alias       = "staging"
+  config_file = "~/.zillaforge/config"
+  profile     = "staging"
+}
+
+# Region example:
+# Select a region by name instead of spelling out its API endpoint.
+# Can be provided via ZILLAFORGE_REGION environment variable.
+provider "zillaforge" {
+  alias            = "tpe"
+  region           = "tpe-1"
+  api_key          = var.zillaforge_api_key
+  project_sys_code = "my-project-code"
+}
+
+# User agent example:
+# Every request carries "User-Agent: terraform-provider-zillaforge/<version>",
+# followed by user_agent_suffix when set. ZillaForge API access logs record
+# this header verbatim, e.g.
+#   terraform-provider-zillaforge/1.2.0 ci-deploy/1.4 (pipeline 812)
+# so quote the suffix when asking ZillaForge support to trace your requests.
+provider "zillaforge" {
+  alias             = "ci"
+  api_key           = var.zillaforge_api_key
+  project_sys_code  = "my-project-code"
+  user_agent_suffix = "ci-deploy/1.4 (pipeline 812)"
+}
+
+# Debugging example:
+# Log every API request and response body (with credentials, passwords and
+# user data redacted). The logs only appear at trace level:
+#   TF_LOG_PROVIDER=TRACE terraform plan
+provider "zillaforge" {
+  alias            = "debug"
+  api_key          = var.zillaforge_api_key
+  project_sys_code = "my-project-code"
+  debug_http       = true
+}
+
+# Operation events example:
+# Log one info-level event per resource create, read, update or delete.
+# With TF_LOG=JSON each event is a line such as
+#   {"@level":"info","@message":"zillaforge operation","resource_type":"zillaforge_server",
+#    "action":"create","id":"<uuid>","duration_ms":41233,"result":"success",...}
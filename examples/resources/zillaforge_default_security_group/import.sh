@@ -0,0 +1,16 @@
+#!/bin/bash
+# Import example script for zillaforge_default_security_group resource
+
+# Import the project's default security group by its ID. Importing is optional:
+# creating the resource adopts the group by its name "default". Import it when
+# the project has several groups named "default", to pick the platform's one.
+terraform import zillaforge_default_security_group.default 12345678-1234-1234-1234-123456789abc
+
+# The ID may be prefixed with its project, as for zillaforge_security_group
+# terraform import zillaforge_default_security_group.default my-project/<SECURITY_GROUP_ID>
+
+# Troubleshooting:
+# - Error "Not the Default Security Group": The ID belongs to a group that is
+#   not named "default"; import it as a zillaforge_security_group instead
+# - Plan shows rule changes after import: Update the ingress_rule and
+#   egress_rule blocks to match the group's current rules, or apply to replace them
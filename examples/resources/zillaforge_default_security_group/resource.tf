@@ -0,0 +1,31 @@
+# Take over the rules of the project's platform-provided "default" security
+# group. The group is adopted, not created: applying replaces its rules with
+# the blocks below, and destroying removes its rules but keeps the group.
+resource "zillaforge_default_security_group" "default" {
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "10.0.0.0/8"
+  }
+
+  ingress_rule {
+    protocol    = "icmp"
+    port_range  = "all"
+    source_cidr = "10.0.0.0/8"
+  }
+
+  egress_rule {
+    protocol         = "any"
+    port_range       = "all"
+    destination_cidr = "0.0.0.0/0"
+  }
+}
+
+# Lock the default group down: no ingress_rule or egress_rule blocks leaves
+# it without rules, denying all traffic on NICs that still use it
+# resource "zillaforge_default_security_group" "locked" {}
+
+output "default_security_group_id" {
+  description = "ID of the project's default security group"
+  value       = zillaforge_default_security_group.default.id
+}
@@ -51,3 +51,12 @@ resource "zillaforge_keypair" "updatable" {
 #   -/+ resource "zillaforge_keypair" "updatable" {
 #         name = "example-key" -> "new-name"  # forces replacement
 #       }
+
+# Example 5: Rotate a system-generated keypair
+# Changing regenerate_trigger destroys and recreates the keypair under the same
+# name with a fresh public_key/private_key. The old private key is invalidated
+# and servers created with this keypair keep the old public key until rebuilt.
+resource "zillaforge_keypair" "rotated" {
+  name               = "rotated-key"
+  regenerate_trigger = "2025-q1" # bump to rotate
+}
@@ -41,13 +41,24 @@ terraform import zillaforge_security_group.imported sg-12345678-1234-1234-1234-1
 # terraform import zillaforge_security_group.imported <SECURITY_GROUP_ID>
 # terraform plan  # Should show "No changes" if config matches
 
+# Example 4: Import with a project prefix, "<project>/<SECURITY_GROUP_ID>"
+# The project is the project_id or project_sys_code of the provider that will
+# manage the group. Use the prefix when several provider aliases point at
+# different projects: the import fails with "Import Project Mismatch" instead of
+# looking the group up in the wrong project. The ID stored in state is the
+# plain UUID either way.
+# terraform import zillaforge_security_group.shared my-project/<SECURITY_GROUP_ID>
+
 # Troubleshooting:
 # - Error "Invalid Import ID Format": Ensure the ID is a valid UUID format
+# - Error "Import Project Mismatch": Set the resource's provider argument to the
+#   provider alias configured for the project named in the import ID
 # - Error "Unable to read security group": Verify the security group exists and you have access
 # - Plan shows changes after import: Update your Terraform config to match the actual security group state
 
 # Notes:
-# - The import ID must be the security group UUID, not the name
+# - The import ID must be the security group UUID, not the name, optionally
+#   prefixed with its project
 # - All rules (ingress and egress) will be imported
 # - After import, Terraform will manage all future changes to the security group
 # - The security group must exist before importing (create via UI/API if needed)
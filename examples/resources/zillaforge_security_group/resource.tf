@@ -35,11 +35,11 @@ resource "zillaforge_security_group" "web_servers" {
     source_cidr = "0.0.0.0/0"
   }
 
-  # Allow SSH from admin network only
+  # Allow SSH from the admin networks only (one API rule per CIDR)
   ingress_rule {
-    protocol    = "tcp"
-    port_range  = "22"
-    source_cidr = "203.0.113.0/24"
+    protocol     = "tcp"
+    port_range   = "22"
+    source_cidrs = ["203.0.113.0/24", "198.51.100.0/24"]
   }
 
   # Allow all outbound traffic (IPv4)
@@ -132,6 +132,21 @@ resource "zillaforge_security_group" "ipv6_enabled" {
   }
 }
 
+# Security group seeded with default rules (allow all egress, allow ICMP
+# ingress) so instances are reachable by ping and can reach the internet.
+# The seeded rules are reported in default_rules; only SSH is declared here.
+resource "zillaforge_security_group" "baseline" {
+  name                 = "baseline-sg"
+  description          = "SSH plus default egress and ICMP"
+  create_default_rules = true
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "10.0.0.0/8"
+  }
+}
+
 # Output security group ID for reference
 output "web_sg_id" {
   description = "Security group ID for web servers"
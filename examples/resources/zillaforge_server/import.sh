@@ -40,6 +40,10 @@ echo "  1. Run 'terraform plan' to verify configuration matches imported state"
 echo "  2. Update your configuration if there are any differences"
 echo "  3. Run 'terraform apply' to confirm no changes are needed"
 echo ""
+echo "Note: network_attachment blocks are imported in network_id order, with the first"
+echo "one marked primary. Every attachment carries its own floating_ip_id and floating_ip."
+echo "Declare the blocks in the same order to avoid a reordering diff."
+echo ""
 echo "Note: The following attributes are not imported from the API (for security):"
 echo "  - user_data: Set to null after import"
 echo "  - password: Set to null after import"
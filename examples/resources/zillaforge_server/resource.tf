@@ -324,3 +324,45 @@ resource "zillaforge_server" "temp_no_wait" {
     primary            = true
   }
 }
+
+// ---------------------------------------------------------------------------
+// Example 6: Reboot in place when application config changes
+// ---------------------------------------------------------------------------
+
+locals {
+  app_config = file("${path.module}/app.conf")
+}
+
+resource "zillaforge_server" "app_reboot" {
+  name      = "app-reboot"
+  flavor_id = data.zillaforge_flavors.available.flavors[0].id
+  image_id  = data.zillaforge_images.ubuntu.images[0].id
+
+  // Any change to app.conf reboots the server; use timestamp() to reboot on every apply
+  reboot_trigger = sha1(local.app_config)
+
+  network_attachment {
+    network_id         = data.zillaforge_networks.default.networks[0].id
+    security_group_ids = [data.zillaforge_security_groups.default.security_groups[0].id]
+    primary            = true
+  }
+}
+
+// ---------------------------------------------------------------------------
+// Example 7: Re-run dependent steps when the server's immutable inputs change
+// ---------------------------------------------------------------------------
+
+// revision only changes with image_id, flavor_id, keypair or user_data, not
+// on refresh, so the registration below runs again only for a new server
+// configuration.
+resource "terraform_data" "app_registration" {
+  input = zillaforge_server.app_reboot.id
+
+  provisioner "local-exec" {
+    command = "./register.sh ${zillaforge_server.app_reboot.ip_addresses[0]}"
+  }
+
+  lifecycle {
+    replace_triggered_by = [zillaforge_server.app_reboot.revision]
+  }
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var _ planmodifier.String = &equivalentValuePlanModifier{}
+
+// equivalentValuePlanModifier keeps the prior state value when the configured
+// value differs from it only in form, as decided by the equivalent function.
+// Terraform accepts a planned value that differs from configuration as long
+// as it equals the prior state, so this suppresses diffs (and replacements)
+// caused by the API normalizing a value.
+type equivalentValuePlanModifier struct {
+	equivalent  func(config, state string) bool
+	description string
+}
+
+// EquivalentValuePlanModifier returns a plan modifier that plans the prior
+// state value when equivalent reports it matches the configured value.
+// It must be listed before RequiresReplace so the suppressed diff does not
+// force replacement. description completes "Ignores differences ...".
+func EquivalentValuePlanModifier(equivalent func(config, state string) bool, description string) planmodifier.String {
+	return &equivalentValuePlanModifier{equivalent: equivalent, description: description}
+}
+
+func (m *equivalentValuePlanModifier) Description(ctx context.Context) string {
+	return "Ignores differences " + m.description
+}
+
+func (m *equivalentValuePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m *equivalentValuePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if m.equivalent(req.ConfigValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func trimmedEqual(config, state string) bool {
+	return strings.TrimSpace(config) == strings.TrimSpace(state)
+}
+
+func TestEquivalentValuePlanModifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   types.String
+		state    types.String
+		expected types.String
+	}{
+		{
+			name:     "equivalent keeps state",
+			config:   types.StringValue("value\n"),
+			state:    types.StringValue("value"),
+			expected: types.StringValue("value"),
+		},
+		{
+			name:     "different keeps plan",
+			config:   types.StringValue("other"),
+			state:    types.StringValue("value"),
+			expected: types.StringValue("other"),
+		},
+		{
+			name:     "create keeps plan",
+			config:   types.StringValue("value\n"),
+			state:    types.StringNull(),
+			expected: types.StringValue("value\n"),
+		},
+		{
+			name:     "unknown config keeps plan",
+			config:   types.StringUnknown(),
+			state:    types.StringValue("value"),
+			expected: types.StringUnknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				ConfigValue: tt.config,
+				PlanValue:   tt.config,
+				StateValue:  tt.state,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			EquivalentValuePlanModifier(trimmedEqual, "in surrounding whitespace").PlanModifyString(context.Background(), req, resp)
+
+			if !resp.PlanValue.Equal(tt.expected) {
+				t.Errorf("expected plan value %s, got %s", tt.expected, resp.PlanValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var _ planmodifier.Int64 = &growOnlyPlanModifier{}
+
+// growOnlyPlanModifier rejects plan-time decreases of a numeric attribute on
+// an existing resource, for sizes the platform can grow but never shrink.
+type growOnlyPlanModifier struct {
+	AttributeName string
+}
+
+// GrowOnlyPlanModifier returns a plan modifier that allows an attribute to
+// increase in place but rejects any decrease at plan time.
+func GrowOnlyPlanModifier(attrName string) planmodifier.Int64 {
+	return &growOnlyPlanModifier{AttributeName: attrName}
+}
+
+func (m *growOnlyPlanModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Rejects decreases to '%s' attribute", m.AttributeName)
+}
+
+func (m *growOnlyPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Rejects decreases to `%s` attribute", m.AttributeName)
+}
+
+func (m *growOnlyPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Create, or nothing known yet to compare against
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if req.PlanValue.ValueInt64() < req.StateValue.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Unsupported Change: %s", m.AttributeName),
+			fmt.Sprintf("'%s' can only be increased (current: %d, planned: %d). Shrinking is not supported; recreate the resource to use a smaller size.",
+				m.AttributeName, req.StateValue.ValueInt64(), req.PlanValue.ValueInt64()),
+		)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGrowOnlyPlanModifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		state       types.Int64
+		plan        types.Int64
+		expectError bool
+	}{
+		{name: "decrease rejected", state: types.Int64Value(40), plan: types.Int64Value(20), expectError: true},
+		{name: "increase allowed", state: types.Int64Value(40), plan: types.Int64Value(80)},
+		{name: "unchanged allowed", state: types.Int64Value(40), plan: types.Int64Value(40)},
+		{name: "create allowed", state: types.Int64Null(), plan: types.Int64Value(20)},
+		{name: "unknown plan allowed", state: types.Int64Value(40), plan: types.Int64Unknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.Int64Request{
+				Path:       path.Root("root_disk_gb"),
+				StateValue: tt.state,
+				PlanValue:  tt.plan,
+			}
+			resp := &planmodifier.Int64Response{}
+
+			GrowOnlyPlanModifier("root_disk_gb").PlanModifyInt64(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error=%t, got: %#v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
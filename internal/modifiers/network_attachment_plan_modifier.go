@@ -72,8 +72,11 @@ func (m IPAddressUnknownOnNetworkChangeModifier) PlanModifyString(ctx context.Co
 	}
 
 	// If network_id changed, mark ip_address as unknown (it will be reassigned by the cloud)
+	// unless a fixed address was configured for the new network
 	if !planNetworkID.Equal(stateNetworkID) {
-		resp.PlanValue = types.StringUnknown()
+		if req.ConfigValue.IsNull() {
+			resp.PlanValue = types.StringUnknown()
+		}
 		return
 	}
 
@@ -88,8 +91,8 @@ func IPAddressUnknownOnNetworkChange() planmodifier.String {
 	return IPAddressUnknownOnNetworkChangeModifier{}
 }
 
-// FloatingIPPreserveStateModifier is a plan modifier for the computed floating_ip attribute
-// that preserves the state value unless floating_ip_id or network_id changes.
+// FloatingIPPreserveStateModifier is a plan modifier for the computed floating_ip and
+// public_ip attributes that preserves the state value unless floating_ip_id or network_id changes.
 // This prevents unnecessary diffs when only runtime-only attributes change.
 type FloatingIPPreserveStateModifier struct{}
 
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NICIDsUnknownOnNetworkChangeModifier keeps nic_ids from state unless the
+// planned network_attachment blocks add or remove a network, or give an
+// existing network a new fixed IP (which detaches and re-adds its NIC).
+// Reordering blocks or changing security groups keeps the NICs.
+type NICIDsUnknownOnNetworkChangeModifier struct{}
+
+func (m NICIDsUnknownOnNetworkChangeModifier) Description(ctx context.Context) string {
+	return "Marks nic_ids as unknown when network interfaces are added, removed or reattached"
+}
+
+func (m NICIDsUnknownOnNetworkChangeModifier) MarkdownDescription(ctx context.Context) string {
+	return "Marks `nic_ids` as unknown when network interfaces are added, removed or reattached"
+}
+
+func (m NICIDsUnknownOnNetworkChangeModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	// Create computes the value; destroy has nothing to plan
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planAttachments, stateAttachments []types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("network_attachment"), &planAttachments)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("network_attachment"), &stateAttachments)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if nicsReplaced(planAttachments, stateAttachments) {
+		tflog.Debug(ctx, "network interfaces change, marking nic_ids as unknown")
+		resp.PlanValue = types.MapUnknown(types.StringType)
+		return
+	}
+
+	if !req.StateValue.IsNull() && !req.StateValue.IsUnknown() {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// nicsReplaced reports whether applying plan creates or deletes any NIC:
+// a network only one side attaches, or a known fixed IP that differs from
+// the current one on the same network.
+func nicsReplaced(plan, state []types.Object) bool {
+	stateIPs := make(map[string]types.String, len(state))
+	for _, att := range state {
+		networkID, _ := att.Attributes()["network_id"].(types.String)
+		ip, _ := att.Attributes()["ip_address"].(types.String)
+		stateIPs[networkID.ValueString()] = ip
+	}
+
+	seen := make(map[string]struct{}, len(plan))
+	for _, att := range plan {
+		networkID, _ := att.Attributes()["network_id"].(types.String)
+		if networkID.IsUnknown() {
+			return true
+		}
+		current, exists := stateIPs[networkID.ValueString()]
+		if !exists {
+			return true
+		}
+		seen[networkID.ValueString()] = struct{}{}
+
+		planned, _ := att.Attributes()["ip_address"].(types.String)
+		if !planned.IsNull() && !planned.IsUnknown() && planned.ValueString() != "" && planned.ValueString() != current.ValueString() {
+			return true
+		}
+	}
+	return len(seen) != len(stateIPs)
+}
+
+func NICIDsUnknownOnNetworkChange() planmodifier.Map {
+	return NICIDsUnknownOnNetworkChangeModifier{}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNICsReplaced(t *testing.T) {
+	t.Parallel()
+
+	attachment := func(networkID string, ip types.String) types.Object {
+		return types.ObjectValueMust(
+			map[string]attr.Type{"network_id": types.StringType, "ip_address": types.StringType},
+			map[string]attr.Value{"network_id": types.StringValue(networkID), "ip_address": ip},
+		)
+	}
+	state := []types.Object{
+		attachment("net-a", types.StringValue("10.0.0.5")),
+		attachment("net-b", types.StringValue("10.1.0.5")),
+	}
+
+	tests := []struct {
+		name     string
+		plan     []types.Object
+		expected bool
+	}{
+		{
+			name:     "unchanged",
+			plan:     state,
+			expected: false,
+		},
+		{
+			name:     "reordered with computed addresses",
+			plan:     []types.Object{attachment("net-b", types.StringUnknown()), attachment("net-a", types.StringNull())},
+			expected: false,
+		},
+		{
+			name:     "network added",
+			plan:     append([]types.Object{attachment("net-c", types.StringUnknown())}, state...),
+			expected: true,
+		},
+		{
+			name:     "network removed",
+			plan:     state[:1],
+			expected: true,
+		},
+		{
+			name:     "network swapped",
+			plan:     []types.Object{state[0], attachment("net-c", types.StringUnknown())},
+			expected: true,
+		},
+		{
+			name:     "fixed IP changed",
+			plan:     []types.Object{attachment("net-a", types.StringValue("10.0.0.9")), state[1]},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := nicsReplaced(tt.plan, state); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultPrimaryNetworkModifier plans the primary flag of network_attachment
+// blocks that leave it unset. When no block sets primary=true, the block
+// with the lowest network_id becomes primary, which is the NIC the provider
+// reports as primary on refresh since the API has no primary flag; all
+// other unset blocks are planned as false. Planning the flag keeps state and
+// configuration in agreement instead of leaving it to be computed.
+type DefaultPrimaryNetworkModifier struct{}
+
+func (m DefaultPrimaryNetworkModifier) Description(ctx context.Context) string {
+	return "Makes the attachment with the lowest network_id primary when no attachment sets primary=true"
+}
+
+func (m DefaultPrimaryNetworkModifier) MarkdownDescription(ctx context.Context) string {
+	return "Makes the attachment with the lowest `network_id` primary when no attachment sets `primary=true`"
+}
+
+func (m DefaultPrimaryNetworkModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	config := req.ConfigValue.Elements()
+	plan := req.PlanValue.Elements()
+	if len(config) != len(plan) {
+		return
+	}
+
+	primary := defaultPrimaryIndex(config)
+	if primary == undecidedPrimary {
+		return
+	}
+
+	planned := make([]attr.Value, len(plan))
+	for i, elem := range plan {
+		planned[i] = elem
+		configObj, ok := config[i].(types.Object)
+		if !ok || !configObj.Attributes()["primary"].IsNull() {
+			continue
+		}
+		planObj, ok := elem.(types.Object)
+		if !ok || planObj.IsNull() || planObj.IsUnknown() {
+			continue
+		}
+
+		attrs := make(map[string]attr.Value, len(planObj.Attributes()))
+		for name, value := range planObj.Attributes() {
+			attrs[name] = value
+		}
+		attrs["primary"] = types.BoolValue(i == primary)
+		obj, diags := types.ObjectValue(planObj.AttributeTypes(ctx), attrs)
+		resp.Diagnostics.Append(diags...)
+		planned[i] = obj
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := types.ListValue(req.PlanValue.ElementType(ctx), planned)
+	resp.Diagnostics.Append(diags...)
+	if !diags.HasError() {
+		resp.PlanValue = list
+	}
+}
+
+const (
+	// undecidedPrimary means the primary attachment cannot be chosen yet
+	// because a primary flag or network_id is unknown.
+	undecidedPrimary = -2
+
+	// explicitPrimary means a block sets primary=true itself.
+	explicitPrimary = -1
+)
+
+// defaultPrimaryIndex returns the index of the configured attachment that
+// becomes primary by default: the one with the lowest network_id among those
+// that leave primary unset. It returns explicitPrimary when a block sets
+// primary=true (or no block leaves it unset), and undecidedPrimary while the
+// choice depends on unknown values.
+func defaultPrimaryIndex(config []attr.Value) int {
+	objs := make([]types.Object, len(config))
+	for i, elem := range config {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			return undecidedPrimary
+		}
+		primary, _ := obj.Attributes()["primary"].(types.Bool)
+		if primary.IsUnknown() {
+			return undecidedPrimary
+		}
+		if primary.ValueBool() {
+			return explicitPrimary
+		}
+		objs[i] = obj
+	}
+
+	candidate := explicitPrimary
+	candidateNetworkID := ""
+	for i, obj := range objs {
+		if !obj.Attributes()["primary"].IsNull() {
+			continue
+		}
+		networkID, _ := obj.Attributes()["network_id"].(types.String)
+		if networkID.IsUnknown() {
+			return undecidedPrimary
+		}
+		if candidate == explicitPrimary || networkID.ValueString() < candidateNetworkID {
+			candidate = i
+			candidateNetworkID = networkID.ValueString()
+		}
+	}
+	return candidate
+}
+
+// DefaultPrimaryNetwork returns a DefaultPrimaryNetworkModifier.
+func DefaultPrimaryNetwork() planmodifier.List {
+	return DefaultPrimaryNetworkModifier{}
+}
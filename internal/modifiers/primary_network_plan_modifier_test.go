@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package modifiers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultPrimaryNetwork(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{"network_id": types.StringType, "primary": types.BoolType}
+	attachments := func(nics ...[2]attr.Value) types.List {
+		elems := make([]attr.Value, len(nics))
+		for i, nic := range nics {
+			elems[i] = types.ObjectValueMust(attrTypes, map[string]attr.Value{"network_id": nic[0], "primary": nic[1]})
+		}
+		return types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, elems)
+	}
+	nic := func(networkID types.String, primary types.Bool) [2]attr.Value {
+		return [2]attr.Value{networkID, primary}
+	}
+	netA, netB, netC := types.StringValue("net-a"), types.StringValue("net-b"), types.StringValue("net-c")
+	unset, yes, no := types.BoolNull(), types.BoolValue(true), types.BoolValue(false)
+
+	tests := []struct {
+		name     string
+		config   types.List
+		expected []bool // nil when the plan is left unchanged
+	}{
+		{
+			name:     "zero primary picks the lowest network_id",
+			config:   attachments(nic(netC, unset), nic(netA, unset), nic(netB, unset)),
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "one primary leaves the others false",
+			config:   attachments(nic(netA, unset), nic(netC, yes), nic(netB, unset)),
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "explicit false is not picked",
+			config:   attachments(nic(netA, no), nic(netC, unset), nic(netB, unset)),
+			expected: []bool{false, false, true},
+		},
+		{
+			name:     "two primaries are left to the validator",
+			config:   attachments(nic(netA, yes), nic(netB, yes)),
+			expected: []bool{true, true},
+		},
+		{
+			name:   "unknown network_id",
+			config: attachments(nic(types.StringUnknown(), unset), nic(netB, unset)),
+		},
+		{
+			name:   "unknown primary",
+			config: attachments(nic(netA, types.BoolUnknown()), nic(netB, unset)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Computed primary flags left unset are unknown in the plan.
+			planElems := make([]attr.Value, 0, len(tt.config.Elements()))
+			for _, elem := range tt.config.Elements() {
+				attrs := elem.(types.Object).Attributes()
+				primary := attrs["primary"]
+				if primary.IsNull() {
+					primary = types.BoolUnknown()
+				}
+				planElems = append(planElems, types.ObjectValueMust(attrTypes, map[string]attr.Value{"network_id": attrs["network_id"], "primary": primary}))
+			}
+			plan := types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, planElems)
+
+			req := planmodifier.ListRequest{ConfigValue: tt.config, PlanValue: plan}
+			resp := &planmodifier.ListResponse{PlanValue: plan}
+			DefaultPrimaryNetwork().PlanModifyList(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			if tt.expected == nil {
+				if !resp.PlanValue.Equal(plan) {
+					t.Errorf("expected the plan to be left unchanged, got %s", resp.PlanValue)
+				}
+				return
+			}
+			var got []bool
+			for _, elem := range resp.PlanValue.Elements() {
+				primary := elem.(types.Object).Attributes()["primary"].(types.Bool)
+				if primary.IsUnknown() {
+					t.Fatalf("expected every primary flag to be planned, got %s", resp.PlanValue)
+				}
+				got = append(got, primary.ValueBool())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected primary flags %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	vps_helper "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+)
+
+// clearFieldsTransport adds the fields marked with vps_helper.WithClearedFields
+// to JSON request bodies as empty strings, letting resources clear optional
+// attributes that the SDK would otherwise omit. Requests without the marker
+// pass through untouched.
+type clearFieldsTransport struct {
+	base http.RoundTripper
+}
+
+func (t *clearFieldsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := vps_helper.ClearedFields(req.Context())
+	if len(fields) == 0 || req.Body == nil || req.Method == http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, field := range fields {
+			if _, ok := payload[field]; !ok {
+				payload[field] = json.RawMessage(`""`)
+			}
+		}
+		if patched, err := json.Marshal(payload); err == nil {
+			body = patched
+		}
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return t.base.RoundTrip(out)
+}
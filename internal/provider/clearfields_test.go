@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	vps_helper "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+)
+
+func TestClearFieldsTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		body     string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "no marker",
+			ctx:      context.Background(),
+			body:     `{"name":"web"}`,
+			expected: map[string]interface{}{"name": "web"},
+		},
+		{
+			name:     "cleared field added",
+			ctx:      vps_helper.WithClearedFields(context.Background(), "description"),
+			body:     `{"name":"web"}`,
+			expected: map[string]interface{}{"name": "web", "description": ""},
+		},
+		{
+			name:     "present field kept",
+			ctx:      vps_helper.WithClearedFields(context.Background(), "description"),
+			body:     `{"description":"set"}`,
+			expected: map[string]interface{}{"description": "set"},
+		},
+		{
+			name:     "empty body object",
+			ctx:      vps_helper.WithClearedFields(context.Background(), "description"),
+			body:     `{}`,
+			expected: map[string]interface{}{"description": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(srv.Close)
+
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodPut, srv.URL+"/servers/s1", bytes.NewReader([]byte(tt.body)))
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			client := &http.Client{Transport: &clearFieldsTransport{base: http.DefaultTransport}}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(received, &got); err != nil {
+				t.Fatalf("server received invalid JSON %q: %v", received, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected body %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
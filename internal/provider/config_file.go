@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfileName is the profile read from the shared config file when
+// neither the `profile` attribute nor ZILLAFORGE_PROFILE is set.
+const defaultProfileName = "default"
+
+// configFileKeys lists the keys accepted inside a profile section. Unknown
+// keys are rejected so that typos (e.g. `apikey`) surface as errors instead
+// of silently falling through to the next credential source.
+var configFileKeys = map[string]bool{
+	"api_endpoint":     true,
+	"api_key":          true,
+	"project_id":       true,
+	"project_sys_code": true,
+}
+
+// configProfile holds the values read from a single profile section of the
+// shared config file. Empty fields mean the key was not present.
+type configProfile struct {
+	APIEndpoint    string
+	APIKey         string
+	ProjectID      string
+	ProjectSysCode string
+}
+
+// defaultConfigFilePath returns ~/.zillaforge/config, or an empty string when
+// the home directory cannot be determined.
+func defaultConfigFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".zillaforge", "config")
+}
+
+// expandHome replaces a leading "~" with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// parseConfigFile parses the INI-style shared config file format:
+//
+//	# comments start with '#' or ';'
+//	[default]
+//	api_key      = eyJhbGciOi...
+//	project_id   = 1234
+//
+//	[staging]
+//	api_endpoint     = https://staging.api.zillaforge.com
+//	api_key          = eyJhbGciOi...
+//	project_sys_code = MYPROJ
+//
+// Every key must belong to a [profile] section, appear at most once per
+// section, and be one of configFileKeys. Values may optionally be wrapped in
+// single or double quotes.
+func parseConfigFile(content string) (map[string]configProfile, error) {
+	profiles := make(map[string]configProfile)
+	seen := make(map[string]map[string]bool)
+	current := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: malformed section header %q", lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNo)
+			}
+			if _, dup := seen[name]; dup {
+				return nil, fmt.Errorf("line %d: duplicate profile [%s]", lineNo, name)
+			}
+			current = name
+			seen[name] = make(map[string]bool)
+			profiles[name] = configProfile{}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected 'key = value', got %q", lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+
+		if current == "" {
+			return nil, fmt.Errorf("line %d: key %q appears before any [profile] section", lineNo, key)
+		}
+		if !configFileKeys[key] {
+			return nil, fmt.Errorf("line %d: unknown key %q in profile [%s]", lineNo, key, current)
+		}
+		if seen[current][key] {
+			return nil, fmt.Errorf("line %d: duplicate key %q in profile [%s]", lineNo, key, current)
+		}
+		seen[current][key] = true
+
+		p := profiles[current]
+		switch key {
+		case "api_endpoint":
+			p.APIEndpoint = value
+		case "api_key":
+			p.APIKey = value
+		case "project_id":
+			p.ProjectID = value
+		case "project_sys_code":
+			p.ProjectSysCode = value
+		}
+		profiles[current] = p
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for name, p := range profiles {
+		if p.ProjectID != "" && p.ProjectSysCode != "" {
+			return nil, fmt.Errorf("profile [%s] sets both project_id and project_sys_code; only one is allowed", name)
+		}
+	}
+
+	return profiles, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// loadConfigProfile reads the named profile from the shared config file.
+//
+// When the path was not set explicitly (required == false) a missing file is
+// not an error and an empty profile is returned. A missing file that the user
+// pointed at explicitly, a parse error, or a missing profile that was
+// explicitly requested are all reported as errors.
+func loadConfigProfile(path string, required bool, profile string, profileExplicit bool) (configProfile, error) {
+	if path == "" {
+		return configProfile{}, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return configProfile{}, nil
+		}
+		return configProfile{}, fmt.Errorf("unable to read config file %q: %w", path, err)
+	}
+
+	profiles, err := parseConfigFile(string(content))
+	if err != nil {
+		return configProfile{}, fmt.Errorf("invalid config file %q: %w", path, err)
+	}
+
+	p, ok := profiles[profile]
+	if !ok {
+		if profileExplicit {
+			return configProfile{}, fmt.Errorf("profile %q not found in config file %q", profile, path)
+		}
+		return configProfile{}, nil
+	}
+
+	return p, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	frameworkProvider "github.com/hashicorp/terraform-plugin-framework/provider"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+		profile     string
+		expected    configProfile
+	}{
+		{
+			name: "default profile",
+			content: `# shared credentials
+[default]
+api_key = a.b.c
+project_id = 1234
+`,
+			profile:  "default",
+			expected: configProfile{APIKey: "a.b.c", ProjectID: "1234"},
+		},
+		{
+			name: "quoted values and named profile",
+			content: `[default]
+api_key = a.b.c
+
+; staging credentials
+[staging]
+api_endpoint = "https://staging.example.com"
+api_key = 'x.y.z'
+project_sys_code = PROJ
+`,
+			profile:  "staging",
+			expected: configProfile{APIEndpoint: "https://staging.example.com", APIKey: "x.y.z", ProjectSysCode: "PROJ"},
+		},
+		{name: "key outside section", content: "api_key = a.b.c\n", expectError: true},
+		{name: "unknown key", content: "[default]\napikey = a.b.c\n", expectError: true},
+		{name: "missing equals", content: "[default]\napi_key\n", expectError: true},
+		{name: "malformed header", content: "[default\n", expectError: true},
+		{name: "duplicate key", content: "[default]\napi_key = a\napi_key = b\n", expectError: true},
+		{name: "duplicate profile", content: "[default]\n[default]\n", expectError: true},
+		{name: "both project identifiers", content: "[default]\nproject_id = 1\nproject_sys_code = X\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			profiles, err := parseConfigFile(tt.content)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := profiles[tt.profile]; got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestZillaforgeProvider_Configure_ConfigFileFallback(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	fileJWT := generateTestJWT(t, "file-secret")
+	path := writeTestConfigFile(t, "[staging]\napi_endpoint = https://staging.example.com\napi_key = "+fileJWT+"\nproject_sys_code = PROJ\n")
+
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", path)
+	t.Setenv("ZILLAFORGE_PROFILE", "staging")
+	t.Setenv("ZILLAFORGE_API_ENDPOINT", "")
+	t.Setenv("ZILLAFORGE_API_KEY", "")
+	t.Setenv("ZILLAFORGE_PROJECT_ID", "")
+	t.Setenv("ZILLAFORGE_PROJECT_SYS_CODE", "")
+
+	oldFactory := newClientWrapper
+	defer func() { newClientWrapper = oldFactory }()
+
+	var gotEndpoint, gotKey, gotProject string
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
+		gotEndpoint, gotKey = apiEndpoint, apiKey
+		return &recordingClient{project: &gotProject}
+	}
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no diagnostics error, got: %v", resp.Diagnostics.Errors())
+	}
+	if gotEndpoint != "https://staging.example.com" || gotKey != fileJWT || gotProject != "PROJ" {
+		t.Fatalf("Expected values from config file, got endpoint=%q key=%q project=%q", gotEndpoint, gotKey, gotProject)
+	}
+}
+
+func TestZillaforgeProvider_Configure_EnvOverridesConfigFile(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	envJWT := generateTestJWT(t, "env-secret")
+	path := writeTestConfigFile(t, "[default]\napi_key = "+generateTestJWT(t, "file-secret")+"\nproject_sys_code = PROJ\n")
+
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", path)
+	t.Setenv("ZILLAFORGE_PROFILE", "")
+	t.Setenv("ZILLAFORGE_API_ENDPOINT", "")
+	t.Setenv("ZILLAFORGE_API_KEY", envJWT)
+	t.Setenv("ZILLAFORGE_PROJECT_ID", "env-project")
+	t.Setenv("ZILLAFORGE_PROJECT_SYS_CODE", "")
+
+	oldFactory := newClientWrapper
+	defer func() { newClientWrapper = oldFactory }()
+
+	var gotKey, gotProject string
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
+		gotKey = apiKey
+		return &recordingClient{project: &gotProject}
+	}
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no diagnostics error, got: %v", resp.Diagnostics.Errors())
+	}
+	// The file's project_sys_code must not conflict with the env project_id.
+	if gotKey != envJWT || gotProject != "env-project" {
+		t.Fatalf("Expected env values to take precedence, got key=%q project=%q", gotKey, gotProject)
+	}
+}
+
+func TestZillaforgeProvider_Configure_MissingProfile(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	path := writeTestConfigFile(t, "[default]\nproject_id = 1\n")
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", path)
+	t.Setenv("ZILLAFORGE_PROFILE", "nope")
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Config File" {
+		t.Fatalf("Expected Invalid Config File error, got: %v", resp.Diagnostics.Errors())
+	}
+}
+
+// recordingClient records the project identifier passed to Project().
+type recordingClient struct {
+	project *string
+}
+
+func (r *recordingClient) Project(ctx context.Context, projectIDOrCode string) (interface{}, error) {
+	*r.project = projectIDOrCode
+	return struct{}{}, nil
+}
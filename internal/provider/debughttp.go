@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedValue replaces credentials in logged headers and bodies.
+const redactedValue = "[REDACTED]"
+
+// maxLoggedBodyBytes bounds each logged body; large lists would otherwise
+// flood the trace output.
+const maxLoggedBodyBytes = 64 << 10
+
+// redactedHeaders and redactedBodyFields name the values debug_http never
+// logs. Body fields are matched case-insensitively at any depth.
+var (
+	redactedHeaders    = []string{"Authorization"}
+	redactedBodyFields = map[string]bool{"password": true, "private_key": true, "user_data": true}
+)
+
+// debugHTTPTransport logs every request and response, with method, URL,
+// status, headers and body, at trace level. It is installed by the provider's
+// debug_http option.
+type debugHTTPTransport struct {
+	base http.RoundTripper
+}
+
+func (t *debugHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	out := req
+	reqFields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		out = req.Clone(ctx)
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		reqFields["body"] = redactBody(body)
+	}
+	tflog.Trace(ctx, "Sending API request", reqFields)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		tflog.Trace(ctx, "API request failed", map[string]interface{}{
+			"method":   req.Method,
+			"url":      req.URL.String(),
+			"duration": time.Since(start).String(),
+			"error":    err.Error(),
+		})
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	tflog.Trace(ctx, "Received API response", map[string]interface{}{
+		"method":   req.Method,
+		"url":      req.URL.String(),
+		"status":   resp.StatusCode,
+		"duration": time.Since(start).String(),
+		"body":     redactBody(body),
+	})
+	return resp, nil
+}
+
+// redactHeaders flattens headers for logging, masking credentials.
+func redactHeaders(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for name, values := range header {
+		flat[name] = strings.Join(values, ", ")
+	}
+	for _, name := range redactedHeaders {
+		if _, ok := flat[http.CanonicalHeaderKey(name)]; ok {
+			flat[http.CanonicalHeaderKey(name)] = redactedValue
+		}
+	}
+	return flat
+}
+
+// redactBody returns body for logging, with redactedBodyFields masked when it
+// is JSON and truncated to maxLoggedBodyBytes. A body that is not JSON is
+// logged as is.
+func redactBody(body []byte) string {
+	logged := string(body)
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if encoded, err := json.Marshal(redactJSON(decoded)); err == nil {
+			logged = string(encoded)
+		}
+	}
+
+	if len(logged) > maxLoggedBodyBytes {
+		logged = logged[:maxLoggedBodyBytes] + fmt.Sprintf("... (truncated, %d bytes)", len(logged))
+	}
+	return logged
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redactedBodyFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactJSON(element)
+		}
+	}
+	return value
+}
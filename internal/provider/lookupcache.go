@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// cacheablePaths matches the read-only flavor and image (VRM repository/tag)
+// endpoints. Nothing else is cached: servers, networks and security groups
+// change during an apply and must always be read fresh.
+var cacheablePaths = []*regexp.Regexp{
+	regexp.MustCompile(`/flavors$`),
+	regexp.MustCompile(`/repositories$`),
+	regexp.MustCompile(`/repository/[^/]+$`),
+	regexp.MustCompile(`/repository/[^/]+/tags$`),
+	regexp.MustCompile(`/tags$`),
+	regexp.MustCompile(`/tag/[^/]+$`),
+}
+
+type lookupCacheEntry struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// lookupCache keeps successful flavor/image GET responses for ttl, keyed by
+// the full request URL (which carries the project and the flavor/image id).
+// It is scoped to one provider instance, so separately configured providers
+// (e.g. aliases for different projects) never share entries.
+type lookupCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]lookupCacheEntry
+	now     func() time.Time
+}
+
+func newLookupCache(ttl time.Duration) *lookupCache {
+	return &lookupCache{
+		ttl:     ttl,
+		entries: make(map[string]lookupCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *lookupCache) get(key string) (lookupCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return lookupCacheEntry{}, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return lookupCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *lookupCache) put(key string, entry lookupCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.expires = c.now().Add(c.ttl)
+	c.entries[key] = entry
+}
+
+func isCacheableLookup(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	for _, re := range cacheablePaths {
+		if re.MatchString(req.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachingTransport serves repeated flavor/image lookups from the cache.
+// Only 2xx responses are stored so that transient failures are retried.
+type cachingTransport struct {
+	base  http.RoundTripper
+	cache *lookupCache
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isCacheableLookup(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	if entry, ok := t.cache.get(key); ok {
+		return &http.Response{
+			Status:        http.StatusText(entry.status),
+			StatusCode:    entry.status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	t.cache.put(key, lookupCacheEntry{
+		status: resp.StatusCode,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCachingTestServer(t *testing.T, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func doRequest(t *testing.T, client *http.Client, method, url string) string {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
+
+func TestCachingTransport_CachesLookupsUntilTTL(t *testing.T) {
+	t.Parallel()
+
+	srv, hits := newCachingTestServer(t, http.StatusOK)
+
+	now := time.Unix(0, 0)
+	cache := newLookupCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	client := &http.Client{Transport: &cachingTransport{base: http.DefaultTransport, cache: cache}}
+
+	tagURL := srv.URL + "/vrm/api/v1/project/p1/tag/tag-1"
+	first := doRequest(t, client, http.MethodGet, tagURL)
+	second := doRequest(t, client, http.MethodGet, tagURL)
+	if first != second {
+		t.Fatalf("expected cached body %q, got %q", first, second)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", got)
+	}
+
+	// A different id is a different key.
+	doRequest(t, client, http.MethodGet, srv.URL+"/vrm/api/v1/project/p1/tag/tag-2")
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Fatalf("expected 2 upstream requests, got %d", got)
+	}
+
+	now = now.Add(time.Minute)
+	doRequest(t, client, http.MethodGet, tagURL)
+	if got := atomic.LoadInt32(hits); got != 3 {
+		t.Fatalf("expected expired entry to be refetched, got %d upstream requests", got)
+	}
+}
+
+func TestCachingTransport_Bypass(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		method string
+		path   string
+	}{
+		{name: "server lookup", status: http.StatusOK, method: http.MethodGet, path: "/vps/api/v1/project/p1/servers/s1"},
+		{name: "non-GET", status: http.StatusOK, method: http.MethodDelete, path: "/vrm/api/v1/project/p1/tag/tag-1"},
+		{name: "error response", status: http.StatusNotFound, method: http.MethodGet, path: "/vps/api/v1/project/p1/flavors"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, hits := newCachingTestServer(t, tt.status)
+			client := &http.Client{Transport: &cachingTransport{base: http.DefaultTransport, cache: newLookupCache(time.Hour)}}
+
+			doRequest(t, client, tt.method, srv.URL+tt.path)
+			doRequest(t, client, tt.method, srv.URL+tt.path)
+			if got := atomic.LoadInt32(hits); got != 2 {
+				t.Fatalf("expected request not to be cached, got %d upstream requests", got)
+			}
+		})
+	}
+}
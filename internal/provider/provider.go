@@ -6,11 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	vps_data "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/data"
+	vps_helper "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	vps_resource "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/resource"
 	vrm_data "github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/data"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -32,10 +35,14 @@ type clientWrapper interface {
 }
 
 // newClientWrapper constructs a clientWrapper from SDK credentials. In
-// production this delegates to cloudsdk.NewClient; tests can replace this
+// production this delegates to cloudsdk.New; tests can replace this
 // variable with a stub to control behavior in unit tests.
-var newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
-	return &sdkClientWrapper{client: cloudsdk.NewClient(apiEndpoint, apiKey)}
+var newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
+	client, err := cloudsdk.New(apiEndpoint, apiKey, opts...)
+	if err != nil {
+		return &errClientWrapper{err: err}
+	}
+	return &sdkClientWrapper{client: client}
 }
 
 type sdkClientWrapper struct {
@@ -46,6 +53,20 @@ func (s *sdkClientWrapper) Project(ctx context.Context, projectIDOrCode string)
 	return s.client.Project(ctx, projectIDOrCode)
 }
 
+// errClientWrapper surfaces an SDK construction error (e.g. a malformed
+// api_endpoint) through the regular Project() error path.
+type errClientWrapper struct {
+	err error
+}
+
+func (e *errClientWrapper) Project(ctx context.Context, projectIDOrCode string) (interface{}, error) {
+	return nil, e.err
+}
+
+// sdkHTTPTimeout matches the per-request timeout cloudsdk.New applies to its
+// own default HTTP client.
+const sdkHTTPTimeout = 30 * time.Second
+
 // Ensure ZillaforgeProvider satisfies various provider interfaces.
 var _ provider.Provider = &ZillaforgeProvider{}
 var _ provider.ProviderWithFunctions = &ZillaforgeProvider{}
@@ -62,9 +83,21 @@ type ZillaforgeProvider struct {
 // ZillaforgeProviderModel describes the provider data model.
 type ZillaforgeProviderModel struct {
 	APIEndpoint    types.String `tfsdk:"api_endpoint"`
+	Region         types.String `tfsdk:"region"`
 	APIKey         types.String `tfsdk:"api_key"`
 	ProjectID      types.String `tfsdk:"project_id"`
 	ProjectSysCode types.String `tfsdk:"project_sys_code"`
+	ConfigFile     types.String `tfsdk:"config_file"`
+	Profile        types.String `tfsdk:"profile"`
+
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	LookupCacheTTL     types.String  `tfsdk:"lookup_cache_ttl"`
+	PrecheckQuota      types.Bool    `tfsdk:"precheck_quota"`
+	PrecheckNameUnique types.Bool    `tfsdk:"precheck_name_unique"`
+	UserAgentSuffix    types.String  `tfsdk:"user_agent_suffix"`
+	DebugHTTP          types.Bool    `tfsdk:"debug_http"`
+
+	EmitOperationEvents types.Bool `tfsdk:"emit_operation_events"`
 }
 
 // T048: JWT token format validation helper (<100ms per NFR-001)
@@ -88,6 +121,10 @@ func (p *ZillaforgeProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "Base URL for the Zillaforge API. Override this to use a different environment (staging, development) or regional endpoint. Can also be set via `ZILLAFORGE_API_ENDPOINT` environment variable.",
 				Optional:            true,
 			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Name of the Zillaforge region to manage, such as `tpe-1`. Selects the region's API endpoint so it does not have to be spelled out; `api_endpoint` set in the provider block or via `ZILLAFORGE_API_ENDPOINT` takes precedence, while an `api_endpoint` in the shared config file is only used when no region is set. Unknown regions are rejected unless `api_endpoint` is also set in the provider block or environment. Can be set via `ZILLAFORGE_REGION` environment variable.",
+				Optional:            true,
+			},
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "API key for authenticating with Zillaforge services. Must be a valid JWT token. This credential is sensitive and will not be displayed in Terraform plan output or logs. Can be provided via the `ZILLAFORGE_API_KEY` environment variable.",
 				Optional:            true,
@@ -101,6 +138,44 @@ func (p *ZillaforgeProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "Alphanumeric system code for the Zillaforge project. Exactly one of `project_id` or `project_sys_code` must be specified. Can be set via `ZILLAFORGE_PROJECT_SYS_CODE` environment variable.",
 				Optional:            true,
 			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a shared config file holding credentials in INI format, one `[profile]` section per set of credentials. Supported keys are `api_endpoint`, `api_key`, `project_id` and `project_sys_code`. Defaults to `~/.zillaforge/config`. Can be set via `ZILLAFORGE_CONFIG_FILE` environment variable. Values from the file are only used when neither the provider block nor the corresponding environment variable sets them.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile to read from the shared config file. Defaults to `default`. Can be set via `ZILLAFORGE_PROFILE` environment variable.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum average number of API requests per second issued by this provider instance. The limit is a single token bucket shared by every resource and data source using the provider (including parallel `zillaforge_images`, `zillaforge_flavors` and `zillaforge_networks` reads), so it caps the provider's total request rate rather than each resource's. Must be positive; fractional values such as `0.5` are allowed. Defaults to unlimited.",
+				Optional:            true,
+			},
+			"lookup_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long successful flavor and image lookups (`zillaforge_flavors`, `zillaforge_image`, `zillaforge_images` and any internal flavor/image reads) are cached in memory, as a Go duration such as `30s` or `5m`. The cache is keyed by request URL and scoped to this provider instance, so configurations with many servers sharing the same flavor or image issue the lookup once per TTL instead of once per resource. Flavors or images created during the TTL may not be visible until it expires. Defaults to disabled; `0s` also disables it.",
+				Optional:            true,
+			},
+			"precheck_name_unique": schema.BoolAttribute{
+				MarkdownDescription: "Check at plan time that no other server in the project already uses the `name` of a `zillaforge_server` being created or renamed, and fail with the conflicting server's ID instead of the API's error at apply time. Costs one server list call per planned create or rename. Defaults to `false`.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header of every API request, after `terraform-provider-zillaforge/<version>`. Use it to tag requests with the tool or pipeline issuing them, e.g. `ci-deploy/1.4`. " +
+					"Limited to 256 printable ASCII characters and spaces.",
+				Optional: true,
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log the method, URL, headers, status and body of every API request and response at trace level. Run Terraform with `TF_LOG=TRACE` (or `TF_LOG_PROVIDER=TRACE`) to see them. The `Authorization` header and any `password`, `private_key` or `user_data` field are redacted, but the logs may still hold other data from your project, so enable this only while debugging. Defaults to `false`.",
+				Optional:            true,
+			},
+			"emit_operation_events": schema.BoolAttribute{
+				MarkdownDescription: "Log one structured event at info level when each create, read, update or delete of a `zillaforge_server`, `zillaforge_security_group`, `zillaforge_keypair` or `zillaforge_floating_ip` finishes. " +
+					"Every event has the message `zillaforge operation` and the fields `resource_type`, `action` (`create`, `read`, `update` or `delete`), `id` (empty when the resource has no ID yet), `duration_ms` and `result` (`success` or `error`), so CI pipelines can collect them from Terraform's logs, e.g. with `TF_LOG_PROVIDER=INFO`. Defaults to `false`.",
+				Optional: true,
+			},
+			"precheck_quota": schema.BoolAttribute{
+				MarkdownDescription: "Check the project quota before creating a `zillaforge_server`, and fail with a diagnostic naming each exceeded quota (instances, vCPUs, RAM, GPUs) instead of the API's generic error. **Note:** the SDK has no quota client yet, so the check reads the VPS `/quotas` endpoint directly; its response format is not part of the API contracts and may change. The check is therefore best-effort: it is skipped when the quota or the flavor cannot be read, and a quota the response does not report is not checked. Costs one extra quota and flavor lookup per server created. Defaults to `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -123,27 +198,72 @@ func (p *ZillaforgeProvider) Configure(ctx context.Context, req provider.Configu
 	// T025: INFO level logging for provider configuration start
 	tflog.Info(ctx, "Configuring Zillaforge provider")
 
+	// Shared config file: explicit path → env var → ~/.zillaforge/config.
+	// Only an explicitly chosen file or profile must exist.
+	configFile := data.ConfigFile.ValueString()
+	if configFile == "" {
+		configFile = os.Getenv("ZILLAFORGE_CONFIG_FILE")
+	}
+	configFileExplicit := configFile != ""
+	if configFileExplicit {
+		configFile = expandHome(configFile)
+	} else {
+		configFile = defaultConfigFilePath()
+	}
+
+	profileName := data.Profile.ValueString()
+	if profileName == "" {
+		profileName = os.Getenv("ZILLAFORGE_PROFILE")
+	}
+	profileExplicit := profileName != ""
+	if !profileExplicit {
+		profileName = defaultProfileName
+	}
+
+	fileProfile, err := loadConfigProfile(configFile, configFileExplicit || profileExplicit, profileName, profileExplicit)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Config File",
+			fmt.Sprintf("Unable to load credentials from the shared config file: %s", err.Error()),
+		)
+		return
+	}
+
 	// T049: Environment variable fallback for all 4 attributes
-	// Get api_endpoint with fallback chain: explicit config → env var → default
+	// Get api_endpoint with fallback chain: explicit config → env var → region → config file → default
 	apiEndpoint := data.APIEndpoint.ValueString()
 	if apiEndpoint == "" {
 		apiEndpoint = os.Getenv("ZILLAFORGE_API_ENDPOINT")
 	}
-	if apiEndpoint == "" {
-		apiEndpoint = "https://api.zillaforge.com"
+
+	region := data.Region.ValueString()
+	if region == "" {
+		region = os.Getenv("ZILLAFORGE_REGION")
+	}
+
+	apiEndpoint, err = resolveAPIEndpoint(apiEndpoint, region, fileProfile.APIEndpoint)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Region",
+			fmt.Sprintf("Unable to determine the API endpoint: %s.", err.Error()),
+		)
+		return
 	}
 
-	// Get api_key with fallback chain: explicit config → env var
+	// Get api_key with fallback chain: explicit config → env var → config file
 	apiKey := data.APIKey.ValueString()
 	if apiKey == "" {
 		apiKey = os.Getenv("ZILLAFORGE_API_KEY")
 	}
+	if apiKey == "" {
+		apiKey = fileProfile.APIKey
+	}
 
 	// T050: Validate api_key presence
 	if apiKey == "" {
 		resp.Diagnostics.AddError(
 			"Missing API Key",
-			"api_key must be set via provider block or ZILLAFORGE_API_KEY environment variable.",
+			"api_key must be set via provider block, ZILLAFORGE_API_KEY environment variable, or the shared config file.",
 		)
 		return
 	}
@@ -169,6 +289,14 @@ func (p *ZillaforgeProvider) Configure(ctx context.Context, req provider.Configu
 		projectSysCode = os.Getenv("ZILLAFORGE_PROJECT_SYS_CODE")
 	}
 
+	// The config file only supplies a project identifier when none was set
+	// explicitly or via environment, so a profile's project_sys_code never
+	// conflicts with a project_id chosen elsewhere.
+	if projectID == "" && projectSysCode == "" {
+		projectID = fileProfile.ProjectID
+		projectSysCode = fileProfile.ProjectSysCode
+	}
+
 	// T052: Validate project identifier mutual exclusivity
 	hasProjectID := projectID != ""
 	hasProjectSysCode := projectSysCode != ""
@@ -177,7 +305,7 @@ func (p *ZillaforgeProvider) Configure(ctx context.Context, req provider.Configu
 	if !hasProjectID && !hasProjectSysCode {
 		resp.Diagnostics.AddError(
 			"Missing Project Identifier",
-			"Either project_id or project_sys_code must be specified. Set one via provider block or environment variables ZILLAFORGE_PROJECT_ID or ZILLAFORGE_PROJECT_SYS_CODE.",
+			"Either project_id or project_sys_code must be specified. Set one via provider block or environment variables ZILLAFORGE_PROJECT_ID or ZILLAFORGE_PROJECT_SYS_CODE, or in the shared config file profile.",
 		)
 		return
 	}
@@ -195,15 +323,88 @@ func (p *ZillaforgeProvider) Configure(ctx context.Context, req provider.Configu
 		projectIDOrCode = projectSysCode
 	}
 
+	var clientOpts []cloudsdk.ClientOption
+	// Log innermost, so the trace shows the bodies actually sent and skips
+	// requests answered from the lookup cache.
+	var transport http.RoundTripper = http.DefaultTransport
+	if data.DebugHTTP.ValueBool() {
+		tflog.Debug(ctx, "Enabling API request/response trace logging")
+		transport = &debugHTTPTransport{base: transport}
+	}
+	transport = &clearFieldsTransport{base: transport}
+
+	if !data.RequestsPerSecond.IsNull() && !data.RequestsPerSecond.IsUnknown() {
+		requestsPerSecond := data.RequestsPerSecond.ValueFloat64()
+		if requestsPerSecond <= 0 {
+			resp.Diagnostics.AddError(
+				"Invalid Requests Per Second",
+				fmt.Sprintf("requests_per_second must be a positive number, got: %v. Omit the attribute to disable rate limiting.", requestsPerSecond),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Enabling shared API rate limiter", map[string]interface{}{
+			"requests_per_second": requestsPerSecond,
+		})
+
+		transport = &rateLimitedTransport{
+			base:    transport,
+			limiter: newRateLimiter(requestsPerSecond),
+		}
+	}
+
+	if !data.LookupCacheTTL.IsNull() && !data.LookupCacheTTL.IsUnknown() {
+		ttl, err := time.ParseDuration(data.LookupCacheTTL.ValueString())
+		if err != nil || ttl < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid Lookup Cache TTL",
+				fmt.Sprintf("lookup_cache_ttl must be a non-negative Go duration such as \"30s\" or \"5m\", got: %q.", data.LookupCacheTTL.ValueString()),
+			)
+			return
+		}
+
+		if ttl > 0 {
+			tflog.Debug(ctx, "Enabling flavor/image lookup cache", map[string]interface{}{
+				"ttl": ttl.String(),
+			})
+
+			// Wrap outside the rate limiter so cache hits do not consume tokens.
+			transport = &cachingTransport{
+				base:  transport,
+				cache: newLookupCache(ttl),
+			}
+		}
+	}
+
+	userAgentSuffix := data.UserAgentSuffix.ValueString()
+	if !validUserAgentSuffix(userAgentSuffix) {
+		resp.Diagnostics.AddError(
+			"Invalid User Agent Suffix",
+			fmt.Sprintf("user_agent_suffix must be at most %d printable ASCII characters or spaces, got: %q.", maxUserAgentSuffixLength, userAgentSuffix),
+		)
+		return
+	}
+	transport = &userAgentTransport{
+		base:      transport,
+		userAgent: providerUserAgent(p.version, userAgentSuffix),
+	}
+
+	httpClient := &http.Client{
+		Timeout:   sdkHTTPTimeout,
+		Transport: transport,
+	}
+	clientOpts = append(clientOpts, cloudsdk.WithHTTPClient(httpClient))
+
 	// T054: Structured logging with provider context for multi-instance support
 	tflog.Debug(ctx, "Initializing Zillaforge SDK client", map[string]interface{}{
 		"api_endpoint":       apiEndpoint,
+		"region":             region,
 		"project_id_or_code": projectIDOrCode,
 		"provider_version":   p.version,
 	})
 
 	// T055: Initialize SDK client with validated config values
-	sdkClient := newClientWrapper(apiEndpoint, apiKey)
+	sdkClient := newClientWrapper(apiEndpoint, apiKey, clientOpts...)
 
 	// Get project-specific client
 	projectClient, err := sdkClient.Project(ctx, projectIDOrCode)
@@ -229,7 +430,22 @@ func (p *ZillaforgeProvider) Configure(ctx context.Context, req provider.Configu
 
 	// T028: Share SDK client via resp.ResourceData and resp.DataSourceData
 	resp.DataSourceData = projectClient
-	resp.ResourceData = projectClient
+
+	resourceData := &vps_helper.ProviderData{}
+	if client, ok := projectClient.(*cloudsdk.ProjectClient); ok {
+		resourceData.Client = client
+		resourceData.Project = projectIDOrCode
+		resourceData.API = &vps_helper.APIClient{
+			BaseURL:    strings.TrimSuffix(apiEndpoint, "/") + "/vps",
+			ProjectID:  client.VPS().ProjectID(),
+			Token:      apiKey,
+			HTTPClient: httpClient,
+		}
+		resourceData.PrecheckQuota = data.PrecheckQuota.ValueBool()
+		resourceData.PrecheckNameUnique = data.PrecheckNameUnique.ValueBool()
+		resourceData.EmitOperationEvents = data.EmitOperationEvents.ValueBool()
+	}
+	resp.ResourceData = resourceData
 }
 
 func (p *ZillaforgeProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -237,6 +453,7 @@ func (p *ZillaforgeProvider) Resources(ctx context.Context) []func() resource.Re
 		vps_resource.NewFloatingIPResource,
 		vps_resource.NewKeypairResource,
 		vps_resource.NewSecurityGroupResource,
+		vps_resource.NewDefaultSecurityGroupResource,
 		vps_resource.NewServerResource,
 	}
 }
@@ -247,11 +464,16 @@ func (p *ZillaforgeProvider) EphemeralResources(ctx context.Context) []func() ep
 
 func (p *ZillaforgeProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		vps_data.NewConnectivityDataSource,
 		vps_data.NewFlavorDataSource,
 		vps_data.NewFloatingIPsDataSource,
 		vps_data.NewNetworkDataSource,
+		vps_data.NewNetworksDataSource,
+		vps_data.NewSubnetsDataSource,
 		vps_data.NewKeypairDataSource,
 		vps_data.NewSecurityGroupsDataSource,
+		vps_data.NewServerVolumesDataSource,
+		vrm_data.NewImageDataSource,
 		vrm_data.NewImagesDataSource,
 	}
 }
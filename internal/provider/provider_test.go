@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	"github.com/golang-jwt/jwt/v5"
 	frameworkProvider "github.com/hashicorp/terraform-plugin-framework/provider"
 	// Provider testing helpers are available in internal/provider/testing.go.
@@ -73,7 +74,7 @@ func TestZillaforgeProvider_Configure_InitializesSDK(t *testing.T) {
 	oldFactory := newClientWrapper
 	defer func() { newClientWrapper = oldFactory }()
 
-	newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
 		return &testClient{projectResult: struct{}{}, projectErr: nil}
 	}
 
@@ -106,7 +107,7 @@ func TestZillaforgeProvider_Configure_InvalidCredentials(t *testing.T) {
 	oldFactory := newClientWrapper
 	defer func() { newClientWrapper = oldFactory }()
 
-	newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
 		return &testClient{projectResult: nil, projectErr: fmt.Errorf("simulated SDK error")}
 	}
 
@@ -221,7 +222,7 @@ func TestZillaforgeProvider_Schema_ValidWithProjectID(t *testing.T) {
 	oldFactory := newClientWrapper
 	defer func() { newClientWrapper = oldFactory }()
 
-	newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
 		return &testClient{projectResult: struct{}{}, projectErr: nil}
 	}
 
@@ -250,7 +251,7 @@ func TestZillaforgeProvider_Schema_ValidWithProjectSysCode(t *testing.T) {
 	oldFactory := newClientWrapper
 	defer func() { newClientWrapper = oldFactory }()
 
-	newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
 		return &testClient{projectResult: struct{}{}, projectErr: nil}
 	}
 
@@ -326,7 +327,7 @@ func TestZillaforgeProvider_MultiInstance_Aliases(t *testing.T) {
 	oldFactory := newClientWrapper
 	defer func() { newClientWrapper = oldFactory }()
 
-	newClientWrapper = func(apiEndpoint, apiKey string) clientWrapper {
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
 		return &testClient{projectResult: apiKey, projectErr: nil}
 	}
 
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_BurstThenWait(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := newRateLimiter(2)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	// The bucket starts full with two tokens.
+	for i := 0; i < 2; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("request %d: expected no delay, got %s", i, d)
+		}
+	}
+
+	// The third request must wait for one token to refill (0.5s at 2 rps).
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Fatalf("expected 500ms delay, got %s", d)
+	}
+
+	// After 1.5s the queued request is paid off and one token is available.
+	now = now.Add(1500 * time.Millisecond)
+	if d := l.reserve(); d != 0 {
+		t.Fatalf("expected no delay after refill, got %s", d)
+	}
+}
+
+func TestRateLimiter_FractionalRate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := newRateLimiter(0.5)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	if d := l.reserve(); d != 0 {
+		t.Fatalf("expected first request to pass, got %s", d)
+	}
+	if d := l.reserve(); d != 2*time.Second {
+		t.Fatalf("expected 2s delay at 0.5 rps, got %s", d)
+	}
+}
+
+func TestRateLimiter_WaitHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(0.001)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("expected first request to pass, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatalf("expected context error")
+	}
+
+	// The cancelled reservation is returned to the bucket.
+	if l.tokens < -0.01 {
+		t.Fatalf("expected cancelled token to be returned, tokens=%v", l.tokens)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultAPIEndpoint is used when neither an endpoint nor a region is set.
+const defaultAPIEndpoint = "https://api.zillaforge.com"
+
+// regionEndpoints maps the `region` provider attribute to the API endpoint
+// serving that region. Add an entry here when a new region goes live.
+var regionEndpoints = map[string]string{
+	"tpe-1": "https://api.zillaforge.com",
+}
+
+// knownRegions returns the supported region names in sorted order.
+func knownRegions() []string {
+	regions := make([]string, 0, len(regionEndpoints))
+	for name := range regionEndpoints {
+		regions = append(regions, name)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// resolveAPIEndpoint picks the endpoint to use from an endpoint set in the
+// provider block or environment, a region name and the shared config file's
+// endpoint, in that order. The file's endpoint only applies when no region is
+// set, so a region chosen in configuration is not overridden by a profile.
+// An unknown region is only accepted alongside an explicit endpoint, e.g. for
+// a region this provider version does not know about yet.
+func resolveAPIEndpoint(apiEndpoint, region, fileEndpoint string) (string, error) {
+	if apiEndpoint != "" {
+		return apiEndpoint, nil
+	}
+	if region == "" {
+		if fileEndpoint != "" {
+			return fileEndpoint, nil
+		}
+		return defaultAPIEndpoint, nil
+	}
+	endpoint, ok := regionEndpoints[region]
+	if !ok {
+		return "", fmt.Errorf("unknown region %q; supported regions are %s. Set api_endpoint to use a region not listed here", region, strings.Join(knownRegions(), ", "))
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	frameworkProvider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResolveAPIEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		apiEndpoint  string
+		region       string
+		fileEndpoint string
+		expected     string
+		expectError  bool
+	}{
+		{
+			name:     "default",
+			expected: defaultAPIEndpoint,
+		},
+		{
+			name:     "known region",
+			region:   "tpe-1",
+			expected: regionEndpoints["tpe-1"],
+		},
+		{
+			name:        "endpoint overrides region",
+			apiEndpoint: "https://staging.example.com",
+			region:      "tpe-1",
+			expected:    "https://staging.example.com",
+		},
+		{
+			name:         "region overrides config file endpoint",
+			region:       "tpe-1",
+			fileEndpoint: "https://staging.example.com",
+			expected:     regionEndpoints["tpe-1"],
+		},
+		{
+			name:         "config file endpoint without region",
+			fileEndpoint: "https://staging.example.com",
+			expected:     "https://staging.example.com",
+		},
+		{
+			name:        "unknown region",
+			region:      "mars-1",
+			expectError: true,
+		},
+		{
+			name:         "unknown region with config file endpoint",
+			region:       "mars-1",
+			fileEndpoint: "https://mars-1.example.com",
+			expectError:  true,
+		},
+		{
+			name:        "unknown region with endpoint",
+			apiEndpoint: "https://mars-1.example.com",
+			region:      "mars-1",
+			expected:    "https://mars-1.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolveAPIEndpoint(tt.apiEndpoint, tt.region, tt.fileEndpoint)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got endpoint %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestZillaforgeProvider_Configure_RegionFromEnv(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", writeTestConfigFile(t, ""))
+	t.Setenv("ZILLAFORGE_PROFILE", "")
+	t.Setenv("ZILLAFORGE_API_ENDPOINT", "")
+	t.Setenv("ZILLAFORGE_REGION", "tpe-1")
+	t.Setenv("ZILLAFORGE_API_KEY", generateTestJWT(t, ""))
+	t.Setenv("ZILLAFORGE_PROJECT_ID", "1234")
+	t.Setenv("ZILLAFORGE_PROJECT_SYS_CODE", "")
+
+	oldFactory := newClientWrapper
+	defer func() { newClientWrapper = oldFactory }()
+
+	var gotEndpoint, gotProject string
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
+		gotEndpoint = apiEndpoint
+		return &recordingClient{project: &gotProject}
+	}
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no diagnostics error, got: %v", resp.Diagnostics.Errors())
+	}
+	if gotEndpoint != regionEndpoints["tpe-1"] {
+		t.Fatalf("Expected endpoint of region tpe-1, got %q", gotEndpoint)
+	}
+}
+
+func TestZillaforgeProvider_Configure_UnknownRegion(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", writeTestConfigFile(t, ""))
+	t.Setenv("ZILLAFORGE_PROFILE", "")
+	t.Setenv("ZILLAFORGE_API_ENDPOINT", "")
+	t.Setenv("ZILLAFORGE_REGION", "mars-1")
+	t.Setenv("ZILLAFORGE_API_KEY", generateTestJWT(t, ""))
+	t.Setenv("ZILLAFORGE_PROJECT_ID", "1234")
+	t.Setenv("ZILLAFORGE_PROJECT_SYS_CODE", "")
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Region" {
+		t.Fatalf("Expected Invalid Region error, got: %v", resp.Diagnostics.Errors())
+	}
+}
+
+func TestZillaforgeProvider_Configure_RegionOverridesConfigFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	prov := New("test")()
+
+	path := writeTestConfigFile(t, "[default]\napi_endpoint = https://staging.example.com\n")
+
+	t.Setenv("ZILLAFORGE_CONFIG_FILE", path)
+	t.Setenv("ZILLAFORGE_PROFILE", "")
+	t.Setenv("ZILLAFORGE_API_ENDPOINT", "")
+	t.Setenv("ZILLAFORGE_REGION", "")
+	t.Setenv("ZILLAFORGE_API_KEY", generateTestJWT(t, ""))
+	t.Setenv("ZILLAFORGE_PROJECT_ID", "1234")
+	t.Setenv("ZILLAFORGE_PROJECT_SYS_CODE", "")
+
+	oldFactory := newClientWrapper
+	defer func() { newClientWrapper = oldFactory }()
+
+	var gotEndpoint, gotProject string
+	newClientWrapper = func(apiEndpoint, apiKey string, opts ...cloudsdk.ClientOption) clientWrapper {
+		gotEndpoint = apiEndpoint
+		return &recordingClient{project: &gotProject}
+	}
+
+	resp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{Config: testProviderConfig(t, map[string]tftypes.Value{
+		"region": tftypes.NewValue(tftypes.String, "tpe-1"),
+	})}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no diagnostics error, got: %v", resp.Diagnostics.Errors())
+	}
+	if gotEndpoint != regionEndpoints["tpe-1"] {
+		t.Fatalf("Expected the region set in configuration to win over the config file endpoint, got %q", gotEndpoint)
+	}
+}
+
+// testProviderConfig returns a provider configuration with attrs set and
+// every other attribute null.
+func testProviderConfig(t *testing.T, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &frameworkProvider.SchemaResponse{}
+	New("test")().Schema(ctx, frameworkProvider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
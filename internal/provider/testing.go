@@ -4,9 +4,11 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
@@ -41,3 +43,29 @@ func TestAccPreCheck(t *testing.T) {
 		t.Skip("Zillaforge API credentials or project not configured; skipping acceptance test")
 	}
 }
+
+// TestAccProjectClient returns an SDK client for the acceptance test project,
+// configured from the same environment variables as the provider. Tests use
+// it to change resources outside Terraform.
+func TestAccProjectClient(t *testing.T) *cloudsdk.ProjectClient {
+	t.Helper()
+
+	apiEndpoint, err := resolveAPIEndpoint(os.Getenv("ZILLAFORGE_API_ENDPOINT"), os.Getenv("ZILLAFORGE_REGION"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve the API endpoint: %v", err)
+	}
+	client, err := cloudsdk.New(apiEndpoint, os.Getenv("ZILLAFORGE_API_KEY"))
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+
+	project := os.Getenv("ZILLAFORGE_PROJECT_ID")
+	if project == "" {
+		project = os.Getenv("ZILLAFORGE_PROJECT_SYS_CODE")
+	}
+	projectClient, err := client.Project(context.Background(), project)
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+	return projectClient
+}
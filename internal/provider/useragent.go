@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+)
+
+// maxUserAgentSuffixLength keeps the header well below common proxy limits.
+const maxUserAgentSuffixLength = 256
+
+// userAgentTransport sets the User-Agent header on every request, including
+// those of the SDK, which otherwise sends Go's default.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(out)
+}
+
+// providerUserAgent returns "terraform-provider-zillaforge/<version>",
+// followed by suffix when one is set.
+func providerUserAgent(version, suffix string) string {
+	userAgent := "terraform-provider-zillaforge/" + version
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// validUserAgentSuffix reports whether suffix is safe to place in a header:
+// printable ASCII and spaces only, so it cannot inject line breaks or other
+// headers, and at most maxUserAgentSuffixLength bytes.
+func validUserAgentSuffix(suffix string) bool {
+	if len(suffix) > maxUserAgentSuffixLength {
+		return false
+	}
+	for i := 0; i < len(suffix); i++ {
+		if c := suffix[i]; c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserAgentTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		version  string
+		suffix   string
+		expected string
+	}{
+		{
+			name:     "version only",
+			version:  "1.2.3",
+			expected: "terraform-provider-zillaforge/1.2.3",
+		},
+		{
+			name:     "with suffix",
+			version:  "dev",
+			suffix:   "ci-deploy/1.4 (team-a)",
+			expected: "terraform-provider-zillaforge/dev ci-deploy/1.4 (team-a)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get("User-Agent")
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &http.Client{Transport: &userAgentTransport{
+				base:      http.DefaultTransport,
+				userAgent: providerUserAgent(tt.version, tt.suffix),
+			}}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("User-Agent", "Go-http-client/1.1")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if received != tt.expected {
+				t.Errorf("expected User-Agent %q, got %q", tt.expected, received)
+			}
+			if req.Header.Get("User-Agent") != "Go-http-client/1.1" {
+				t.Error("expected the caller's request to be left unmodified")
+			}
+		})
+	}
+}
+
+func TestValidUserAgentSuffix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		suffix   string
+		expected bool
+	}{
+		{name: "empty", suffix: "", expected: true},
+		{name: "product tokens", suffix: "ci-deploy/1.4 (linux; amd64)", expected: true},
+		{name: "max length", suffix: strings.Repeat("a", maxUserAgentSuffixLength), expected: true},
+		{name: "too long", suffix: strings.Repeat("a", maxUserAgentSuffixLength+1)},
+		{name: "header injection", suffix: "tool\r\nX-Injected: 1"},
+		{name: "tab", suffix: "tool\t1"},
+		{name: "non-ASCII", suffix: "部署"},
+		{name: "delete", suffix: "tool\x7f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validUserAgentSuffix(tt.suffix); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = &positiveDurationValidator{}
+
+// positiveDurationValidator validates that a string is a Go duration greater
+// than zero.
+type positiveDurationValidator struct{}
+
+// PositiveDuration returns a validator for Go duration strings such as "15m"
+// that rejects zero and negative durations.
+func PositiveDuration() validator.String {
+	return &positiveDurationValidator{}
+}
+
+func (v *positiveDurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive Go duration (e.g., '30s', '15m', '1h30m')"
+}
+
+func (v *positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive Go duration (e.g., `30s`, `15m`, `1h30m`)"
+}
+
+func (v *positiveDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Value %q is not a valid duration. Use a number followed by a unit of \"s\", \"m\" or \"h\" (e.g., \"30s\", \"15m\", \"1h30m\").", value),
+		)
+		return
+	}
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Value %q must be greater than zero.", value),
+		)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPositiveDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "minutes", value: types.StringValue("15m")},
+		{name: "hours", value: types.StringValue("1h")},
+		{name: "compound", value: types.StringValue("1h30m")},
+		{name: "fractional", value: types.StringValue("1.5h")},
+		{name: "seconds", value: types.StringValue("90s")},
+		{name: "not a duration", value: types.StringValue("bogus"), expectError: true},
+		{name: "missing unit", value: types.StringValue("10"), expectError: true},
+		{name: "unsupported unit", value: types.StringValue("1d"), expectError: true},
+		{name: "empty", value: types.StringValue(""), expectError: true},
+		{name: "zero", value: types.StringValue("0s"), expectError: true},
+		{name: "negative", value: types.StringValue("-5m"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("timeouts").AtName("create"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			PositiveDuration().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
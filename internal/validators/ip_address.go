@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = &ipAddressValidator{}
+
+// ipAddressValidator validates IPv4 and IPv6 addresses without a prefix length.
+type ipAddressValidator struct{}
+
+// IPAddress returns a validator for plain IP addresses.
+func IPAddress() validator.String {
+	return &ipAddressValidator{}
+}
+
+func (v *ipAddressValidator) Description(ctx context.Context) string {
+	return "value must be a valid IP address (e.g., '10.0.0.1', '2001:db8::1')"
+}
+
+func (v *ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a valid IP address (e.g., `10.0.0.1`, `2001:db8::1`)"
+}
+
+func (v *ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// Skip validation if value is unknown or null
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("Value '%s' is not a valid IP address. Use an IPv4 address such as '10.0.0.1' or an IPv6 address such as '2001:db8::1', without a prefix length.", value),
+		)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIPAddressValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{name: "IPv4", value: types.StringValue("10.0.0.1")},
+		{name: "IPv6", value: types.StringValue("2001:db8::1")},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "null", value: types.StringNull()},
+		{name: "CIDR", value: types.StringValue("10.0.0.0/24"), expectError: true},
+		{name: "out of range octet", value: types.StringValue("10.0.0.256"), expectError: true},
+		{name: "hostname", value: types.StringValue("dns.example.com"), expectError: true},
+		{name: "empty", value: types.StringValue(""), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			IPAddress().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t for value %s, got: %v", tt.expectError, tt.value, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package validators
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,6 +16,7 @@ import (
 var _ validator.List = &networkAttachmentPrimaryConstraint{}
 
 // networkAttachmentPrimaryConstraint validates at most one primary=true in network_attachment list.
+// Attachments that leave primary unset are planned by modifiers.DefaultPrimaryNetwork.
 type networkAttachmentPrimaryConstraint struct{}
 
 // NetworkAttachmentPrimaryConstraint returns a validator that ensures at most one network attachment has primary=true.
@@ -40,7 +43,7 @@ func (v *networkAttachmentPrimaryConstraint) ValidateList(ctx context.Context, r
 		return
 	}
 
-	primaryCount := 0
+	var primaryNetworks []string
 
 	// Iterate through network attachments
 	for _, elem := range elements {
@@ -62,15 +65,153 @@ func (v *networkAttachmentPrimaryConstraint) ValidateList(ctx context.Context, r
 		}
 
 		if primaryBool.ValueBool() {
-			primaryCount++
+			networkID, _ := attrs["network_id"].(types.String)
+			if networkID.IsUnknown() {
+				primaryNetworks = append(primaryNetworks, "(known after apply)")
+			} else {
+				primaryNetworks = append(primaryNetworks, networkID.ValueString())
+			}
 		}
 	}
 
-	if primaryCount > 1 {
+	if len(primaryNetworks) > 1 {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
 			"Multiple Primary Network Attachments",
-			fmt.Sprintf("Only one network attachment can have primary=true, found %d. Set primary=true on exactly one network attachment or leave it unset.", primaryCount),
+			fmt.Sprintf("Only one network attachment can have primary=true, found %d (networks: %s). "+
+				"Keep primary=true on the attachment that should carry the default route and remove it from the others. "+
+				"If primary is left unset on every attachment, the one with the lowest network_id is made primary.",
+				len(primaryNetworks), strings.Join(primaryNetworks, ", ")),
+		)
+	}
+}
+
+var _ validator.List = &networkAttachmentFixedIPStrategy{}
+
+// networkAttachmentFixedIPStrategy checks fixed_ip_strategy against ip_address.
+type networkAttachmentFixedIPStrategy struct{}
+
+// NetworkAttachmentFixedIPStrategy returns a validator that requires
+// ip_address on network attachments with fixed_ip_strategy = "specific", and
+// rejects it on those with fixed_ip_strategy = "first_free", which picks the
+// address itself.
+func NetworkAttachmentFixedIPStrategy() validator.List {
+	return &networkAttachmentFixedIPStrategy{}
+}
+
+func (v *networkAttachmentFixedIPStrategy) Description(ctx context.Context) string {
+	return "requires ip_address with fixed_ip_strategy=specific and rejects it with fixed_ip_strategy=first_free"
+}
+
+func (v *networkAttachmentFixedIPStrategy) MarkdownDescription(ctx context.Context) string {
+	return "requires `ip_address` with `fixed_ip_strategy=specific` and rejects it with `fixed_ip_strategy=first_free`"
+}
+
+func (v *networkAttachmentFixedIPStrategy) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, elem := range req.ConfigValue.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		attrs := obj.Attributes()
+
+		strategy, ok := attrs["fixed_ip_strategy"].(types.String)
+		if !ok || strategy.IsNull() || strategy.IsUnknown() {
+			continue
+		}
+		ipAddress, ok := attrs["ip_address"].(types.String)
+		if !ok || ipAddress.IsUnknown() {
+			continue
+		}
+
+		switch {
+		case strategy.ValueString() == "specific" && ipAddress.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName("ip_address"),
+				"Missing IP Address",
+				"fixed_ip_strategy is \"specific\" on this network attachment, so ip_address must be set to the address to request.",
+			)
+		case strategy.ValueString() == "first_free" && !ipAddress.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName("ip_address"),
+				"Conflicting IP Address",
+				"fixed_ip_strategy is \"first_free\" on this network attachment, so the provider picks the address and ip_address must not be set. Use fixed_ip_strategy = \"specific\" to request this address.",
+			)
+		}
+	}
+}
+
+var _ validator.List = &networkAttachmentDuplicateIP{}
+
+// networkAttachmentDuplicateIP warns about the same ip_address on several
+// network attachments.
+type networkAttachmentDuplicateIP struct{}
+
+// NetworkAttachmentDuplicateIP returns a validator that warns when more than
+// one network attachment requests the same fixed ip_address. Addresses are
+// compared after parsing, so different spellings of one address match. It is
+// only a warning: networks with overlapping private ranges can legitimately
+// give a server the same address on each of them.
+func NetworkAttachmentDuplicateIP() validator.List {
+	return &networkAttachmentDuplicateIP{}
+}
+
+func (v *networkAttachmentDuplicateIP) Description(ctx context.Context) string {
+	return "warns when several network attachments request the same ip_address"
+}
+
+func (v *networkAttachmentDuplicateIP) MarkdownDescription(ctx context.Context) string {
+	return "warns when several network attachments request the same `ip_address`"
+}
+
+func (v *networkAttachmentDuplicateIP) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// Index of the first attachment requesting each address
+	first := make(map[netip.Addr]int)
+	networkIDs := make(map[int]string)
+
+	for i, elem := range req.ConfigValue.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		attrs := obj.Attributes()
+
+		ipAddress, ok := attrs["ip_address"].(types.String)
+		if !ok || ipAddress.IsNull() || ipAddress.IsUnknown() {
+			continue
+		}
+		addr, err := netip.ParseAddr(ipAddress.ValueString())
+		if err != nil {
+			// Invalid addresses are reported when the network is checked
+			continue
+		}
+		addr = addr.Unmap()
+
+		networkIDs[i] = "(known after apply)"
+		if networkID, ok := attrs["network_id"].(types.String); ok && !networkID.IsUnknown() {
+			networkIDs[i] = networkID.ValueString()
+		}
+
+		j, seen := first[addr]
+		if !seen {
+			first[addr] = i
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path.AtListIndex(i).AtName("ip_address"),
+			"Duplicate Fixed IP Address",
+			fmt.Sprintf("network_attachment %d (network %s) requests ip_address %s, which network_attachment %d (network %s) already requests. "+
+				"This is usually a copy-paste mistake; the same address is only valid when the networks are isolated and their ranges overlap.",
+				i, networkIDs[i], addr, j, networkIDs[j]),
 		)
 	}
 }
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNetworkAttachmentPrimaryConstraint(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"network_id": types.StringType,
+		"primary":    types.BoolType,
+	}
+	attachment := func(networkID string, primary types.Bool) attr.Value {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"network_id": types.StringValue(networkID),
+			"primary":    primary,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		attachments []attr.Value
+		expectError bool
+	}{
+		{
+			name:        "zero primary",
+			attachments: []attr.Value{attachment("net-a", types.BoolNull()), attachment("net-b", types.BoolValue(false))},
+		},
+		{
+			name:        "one primary",
+			attachments: []attr.Value{attachment("net-a", types.BoolNull()), attachment("net-b", types.BoolValue(true))},
+		},
+		{
+			name:        "two primary",
+			attachments: []attr.Value{attachment("net-a", types.BoolValue(true)), attachment("net-b", types.BoolValue(true))},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ListRequest{
+				Path:        path.Root("network_attachment"),
+				ConfigValue: types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, tt.attachments),
+			}
+			resp := &validator.ListResponse{}
+
+			NetworkAttachmentPrimaryConstraint().ValidateList(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+			if tt.expectError {
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "net-a, net-b") {
+					t.Errorf("expected the error to name both networks, got %q", detail)
+				}
+			}
+		})
+	}
+}
+
+func TestNetworkAttachmentFixedIPStrategy(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"fixed_ip_strategy": types.StringType,
+		"ip_address":        types.StringType,
+	}
+
+	tests := []struct {
+		name        string
+		strategy    types.String
+		ipAddress   types.String
+		expectError bool
+	}{
+		{name: "specific with address", strategy: types.StringValue("specific"), ipAddress: types.StringValue("10.0.0.20")},
+		{name: "specific without address", strategy: types.StringValue("specific"), ipAddress: types.StringNull(), expectError: true},
+		{name: "specific with unknown address", strategy: types.StringValue("specific"), ipAddress: types.StringUnknown()},
+		{name: "first_free without address", strategy: types.StringValue("first_free"), ipAddress: types.StringNull()},
+		{name: "first_free with address", strategy: types.StringValue("first_free"), ipAddress: types.StringValue("10.0.0.20"), expectError: true},
+		{name: "auto with address", strategy: types.StringValue("auto"), ipAddress: types.StringValue("10.0.0.20")},
+		{name: "unset without address", strategy: types.StringNull(), ipAddress: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"fixed_ip_strategy": tt.strategy,
+				"ip_address":        tt.ipAddress,
+			})
+			req := validator.ListRequest{
+				Path:        path.Root("network_attachment"),
+				ConfigValue: types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, []attr.Value{obj}),
+			}
+			resp := &validator.ListResponse{}
+
+			NetworkAttachmentFixedIPStrategy().ValidateList(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.expectError {
+				t.Errorf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestNetworkAttachmentDuplicateIP(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"network_id": types.StringType,
+		"ip_address": types.StringType,
+	}
+	attachment := func(networkID string, ipAddress types.String) attr.Value {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"network_id": types.StringValue(networkID),
+			"ip_address": ipAddress,
+		})
+	}
+
+	tests := []struct {
+		name          string
+		attachments   []attr.Value
+		expectWarning int
+	}{
+		{
+			name:        "different addresses",
+			attachments: []attr.Value{attachment("net-a", types.StringValue("10.0.0.5")), attachment("net-b", types.StringValue("10.0.0.6"))},
+		},
+		{
+			name:          "same address on different networks",
+			attachments:   []attr.Value{attachment("net-a", types.StringValue("10.0.0.5")), attachment("net-b", types.StringValue("10.0.0.5"))},
+			expectWarning: 1,
+		},
+		{
+			name:          "same address spelled differently",
+			attachments:   []attr.Value{attachment("net-a", types.StringValue("10.0.0.5")), attachment("net-b", types.StringValue("::ffff:10.0.0.5"))},
+			expectWarning: 1,
+		},
+		{
+			name: "three attachments",
+			attachments: []attr.Value{
+				attachment("net-a", types.StringValue("10.0.0.5")),
+				attachment("net-b", types.StringValue("10.0.0.5")),
+				attachment("net-c", types.StringValue("10.0.0.5")),
+			},
+			expectWarning: 2,
+		},
+		{
+			name:        "addresses assigned by DHCP",
+			attachments: []attr.Value{attachment("net-a", types.StringNull()), attachment("net-b", types.StringNull())},
+		},
+		{
+			name:        "unknown address",
+			attachments: []attr.Value{attachment("net-a", types.StringValue("10.0.0.5")), attachment("net-b", types.StringUnknown())},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ListRequest{
+				Path:        path.Root("network_attachment"),
+				ConfigValue: types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, tt.attachments),
+			}
+			resp := &validator.ListResponse{}
+
+			NetworkAttachmentDuplicateIP().ValidateList(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected only warnings, got %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tt.expectWarning {
+				t.Errorf("expected %d warnings, got %v", tt.expectWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 var _ validator.String = &protocolValidator{}
 
 // protocolValidator validates protocol strings for security group rules.
-// Allowed values: tcp, udp, icmp, any (case-insensitive, normalized to lowercase in state).
+// Allowed values: tcp, udp, icmp, icmpv6, any (case-insensitive, normalized to lowercase in state).
 type protocolValidator struct{}
 
 // Protocol returns a validator for protocol strings.
@@ -23,11 +23,11 @@ func Protocol() validator.String {
 }
 
 func (v *protocolValidator) Description(ctx context.Context) string {
-	return "value must be one of: tcp, udp, icmp, any (case-insensitive)"
+	return "value must be one of: tcp, udp, icmp, icmpv6, any (case-insensitive)"
 }
 
 func (v *protocolValidator) MarkdownDescription(ctx context.Context) string {
-	return "value must be one of: `tcp`, `udp`, `icmp`, `any` (case-insensitive)"
+	return "value must be one of: `tcp`, `udp`, `icmp`, `icmpv6`, `any` (case-insensitive)"
 }
 
 func (v *protocolValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
@@ -40,17 +40,18 @@ func (v *protocolValidator) ValidateString(ctx context.Context, req validator.St
 
 	// Valid protocols
 	validProtocols := map[string]bool{
-		"tcp":  true,
-		"udp":  true,
-		"icmp": true,
-		"any":  true,
+		"tcp":    true,
+		"udp":    true,
+		"icmp":   true,
+		"icmpv6": true,
+		"any":    true,
 	}
 
 	if !validProtocols[value] {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
 			"Invalid Protocol",
-			fmt.Sprintf("Protocol '%s' is not valid. Must be one of: tcp, udp, icmp, any (case-insensitive).", req.ConfigValue.ValueString()),
+			fmt.Sprintf("Protocol '%s' is not valid. Must be one of: tcp, udp, icmp, icmpv6, any (case-insensitive).", req.ConfigValue.ValueString()),
 		)
 		return
 	}
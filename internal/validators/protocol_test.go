@@ -36,6 +36,11 @@ func TestProtocolValidator(t *testing.T) {
 			value:       "icmp",
 			expectError: false,
 		},
+		{
+			name:        "icmpv6 lowercase",
+			value:       "icmpv6",
+			expectError: false,
+		},
 		{
 			name:        "any lowercase",
 			value:       "any",
@@ -80,6 +85,11 @@ func TestProtocolValidator(t *testing.T) {
 			value:       "Icmp",
 			expectError: false,
 		},
+		{
+			name:        "ICMPv6 mixed case",
+			value:       "ICMPv6",
+			expectError: false,
+		},
 		{
 			name:        "Any mixed case",
 			value:       "Any",
@@ -118,13 +128,13 @@ func TestProtocolValidator(t *testing.T) {
 			expectError: true,
 		},
 		{
-			name:        "invalid text",
-			value:       "not-a-protocol",
+			name:        "ipv6-icmp (network service spelling)",
+			value:       "ipv6-icmp",
 			expectError: true,
 		},
 		{
-			name:        "icmpv6",
-			value:       "icmpv6",
+			name:        "invalid text",
+			value:       "not-a-protocol",
 			expectError: true,
 		},
 		{
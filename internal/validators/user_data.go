@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// MaxUserDataBytes is the largest boot script the VPS API accepts, measured
+// after the provider base64-encodes user_data.
+const MaxUserDataBytes = 64 * 1024
+
+var _ validator.String = &userDataSizeValidator{}
+
+// userDataSizeValidator rejects user_data whose base64-encoded form, as sent
+// to the API, exceeds maxBytes.
+type userDataSizeValidator struct {
+	maxBytes int
+}
+
+// UserDataSize returns a validator that checks the encoded size of user_data
+// against maxBytes.
+func UserDataSize(maxBytes int) validator.String {
+	return &userDataSizeValidator{maxBytes: maxBytes}
+}
+
+func (v *userDataSizeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at most %d bytes once base64-encoded", v.maxBytes)
+}
+
+func (v *userDataSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *userDataSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// Skip validation if value is unknown or null
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	raw := len(req.ConfigValue.ValueString())
+	encoded := base64.StdEncoding.EncodedLen(raw)
+	if encoded > v.maxBytes {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"User Data Too Large",
+			fmt.Sprintf("user_data is %d bytes, which is %d bytes once base64-encoded for the API; the limit is %d bytes. "+
+				"Shrink the script, for example by compressing it with cloud-init's gzip support or by downloading larger files at boot.",
+				raw, encoded, v.maxBytes),
+		)
+	}
+}
+
+var _ validator.String = &userDataFileValidator{}
+
+// userDataFileValidator checks that user_data_file names a readable regular
+// file whose base64-encoded contents fit in maxBytes.
+type userDataFileValidator struct {
+	maxBytes int
+}
+
+// UserDataFile returns a validator that checks, at plan time, that the file
+// named by user_data_file can be read and is within maxBytes once encoded.
+func UserDataFile(maxBytes int) validator.String {
+	return &userDataFileValidator{maxBytes: maxBytes}
+}
+
+func (v *userDataFileValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must name a readable file of at most %d bytes once base64-encoded", v.maxBytes)
+}
+
+func (v *userDataFileValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *userDataFileValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// Skip validation if value is unknown or null
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+	f, err := os.Open(name)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"User Data File Not Readable",
+			fmt.Sprintf("Unable to read user_data_file %q: %s", name, err),
+		)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err == nil && !info.Mode().IsRegular() {
+		err = fmt.Errorf("not a regular file")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"User Data File Not Readable",
+			fmt.Sprintf("Unable to read user_data_file %q: %s", name, err),
+		)
+		return
+	}
+
+	raw := int(info.Size())
+	encoded := base64.StdEncoding.EncodedLen(raw)
+	if encoded > v.maxBytes {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"User Data Too Large",
+			fmt.Sprintf("user_data_file %q is %d bytes, which is %d bytes once base64-encoded for the API; the limit is %d bytes. "+
+				"Shrink the script, for example by compressing it with cloud-init's gzip support or by downloading larger files at boot.",
+				name, raw, encoded, v.maxBytes),
+		)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUserDataSizeValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{
+			name:  "null",
+			value: types.StringNull(),
+		},
+		{
+			name:  "unknown",
+			value: types.StringUnknown(),
+		},
+		{
+			name:  "small script",
+			value: types.StringValue("#cloud-config\npackages:\n  - nginx\n"),
+		},
+		{
+			// 49152 bytes encode to exactly 65536.
+			name:  "at limit once encoded",
+			value: types.StringValue(strings.Repeat("a", 49152)),
+		},
+		{
+			name:        "over limit once encoded",
+			value:       types.StringValue(strings.Repeat("a", 49153)),
+			expectError: true,
+		},
+		{
+			name:        "oversized",
+			value:       types.StringValue(strings.Repeat("a", 70000)),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("user_data"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			UserDataSize(MaxUserDataBytes).ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+			if tt.expectError {
+				detail := resp.Diagnostics.Errors()[0].Detail()
+				if !strings.Contains(detail, "65536") {
+					t.Errorf("expected the limit in the error, got %q", detail)
+				}
+			}
+		})
+	}
+}
+
+func TestUserDataFileValidator(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", file, err)
+		}
+		return file
+	}
+	small := write("cloud-init.yaml", "#cloud-config\npackages:\n  - nginx\n")
+	atLimit := write("at-limit.yaml", strings.Repeat("a", 49152))
+	overLimit := write("over-limit.yaml", strings.Repeat("a", 49153))
+
+	tests := []struct {
+		name          string
+		value         types.String
+		expectSummary string
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "small file", value: types.StringValue(small)},
+		{name: "at limit once encoded", value: types.StringValue(atLimit)},
+		{name: "over limit once encoded", value: types.StringValue(overLimit), expectSummary: "User Data Too Large"},
+		{name: "missing file", value: types.StringValue(filepath.Join(dir, "missing.yaml")), expectSummary: "User Data File Not Readable"},
+		{name: "directory", value: types.StringValue(dir), expectSummary: "User Data File Not Readable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("user_data_file"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			UserDataFile(MaxUserDataBytes).ValidateString(context.Background(), req, resp)
+
+			if tt.expectSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected %q error", tt.expectSummary)
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.expectSummary {
+				t.Errorf("expected %q, got %q", tt.expectSummary, summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConnectivityDataSource{}
+
+// NewConnectivityDataSource creates a new instance of the connectivity data source.
+func NewConnectivityDataSource() datasource.DataSource {
+	return &ConnectivityDataSource{}
+}
+
+// ConnectivityDataSource checks that the provider can reach the API with its
+// credentials.
+type ConnectivityDataSource struct {
+	client *cloudsdk.ProjectClient
+}
+
+func (d *ConnectivityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connectivity"
+}
+
+func (d *ConnectivityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that the provider can reach the ZillaForge API with its credentials, without creating anything. " +
+			"It makes one lightweight authenticated call in the configured project (listing keypairs) and is meant for CI pipelines that should fail fast, " +
+			"for example with a `postcondition` on `reachable`, before running a large plan. " +
+			"A failed call is reported in `error` and as a warning rather than failing the read. " +
+			"Credentials the provider rejects already fail provider configuration with an `SDK Initialization Failed` error. The API key is never exposed.",
+
+		Attributes: map[string]schema.Attribute{
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated call succeeded.",
+				Computed:            true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "How long the call took, in milliseconds, whether or not it succeeded.",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project the provider is configured for, resolved from `project_sys_code` when that was used.",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Why the call failed, or null when `reachable` is `true`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConnectivityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudsdk.ProjectClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ConnectivityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := model.ConnectivityDataSourceModel{
+		Reachable: types.BoolValue(false),
+		LatencyMS: types.Int64Value(0),
+		ProjectID: types.StringNull(),
+		Error:     types.StringNull(),
+	}
+
+	if d.client == nil {
+		data.Error = types.StringValue("the provider is not configured")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	vpsClient := d.client.VPS()
+	data.ProjectID = types.StringValue(vpsClient.ProjectID())
+
+	latency, err := helper.CheckConnectivity(ctx, vpsClient.Keypairs())
+	data.LatencyMS = types.Int64Value(latency.Milliseconds())
+	if err != nil {
+		tflog.Warn(ctx, "Zillaforge API connectivity check failed", map[string]interface{}{
+			"project_id": data.ProjectID.ValueString(),
+			"error":      err.Error(),
+		})
+		data.Error = types.StringValue(err.Error())
+		resp.Diagnostics.AddWarning(
+			"Zillaforge API Unreachable",
+			fmt.Sprintf("An authenticated call to the API in project %s failed after %dms: %s", data.ProjectID.ValueString(), data.LatencyMS.ValueInt64(), err.Error()),
+		)
+	} else {
+		data.Reachable = types.BoolValue(true)
+	}
+
+	tflog.Debug(ctx, "Checked Zillaforge API connectivity", map[string]interface{}{
+		"reachable":  data.Reachable.ValueBool(),
+		"latency_ms": data.LatencyMS.ValueInt64(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
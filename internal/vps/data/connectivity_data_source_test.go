@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConnectivityDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zillaforge_connectivity" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zillaforge_connectivity.test", "reachable", "true"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_connectivity.test", "latency_ms"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_connectivity.test", "project_id"),
+					resource.TestCheckNoResourceAttr("data.zillaforge_connectivity.test", "error"),
+				),
+			},
+		},
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -34,12 +35,16 @@ func (d *FlavorDataSource) Metadata(ctx context.Context, req datasource.Metadata
 
 func (d *FlavorDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Query available compute flavors in Zillaforge VPS service.",
+		MarkdownDescription: "Query available compute flavors in Zillaforge VPS service. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Filter flavors by exact name match (case-sensitive)",
 				Optional:            true,
 			},
+			"name_pattern": schema.StringAttribute{
+				MarkdownDescription: "Filter flavors by name pattern using glob-style wildcards (`*` matches any characters, `?` matches single character), e.g. `m1.*`. Mutually exclusive with `name`.",
+				Optional:            true,
+			},
 			"vcpus": schema.Int64Attribute{
 				MarkdownDescription: "Filter flavors with minimum number of vCPUs",
 				Optional:            true,
@@ -54,8 +59,29 @@ func (d *FlavorDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					int64validator.AtLeast(1),
 				},
 			},
+			"min_vcpus": schema.Int64Attribute{
+				MarkdownDescription: "Filter flavors with at least this many vCPUs. Combined with `vcpus` when both are set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_memory_mb": schema.Int64Attribute{
+				MarkdownDescription: "Filter flavors with at least this much memory, in MiB as reported by the API.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"sort_by": schema.StringAttribute{
+				MarkdownDescription: "Sort the matching flavors in ascending order by `name`, `vcpus`, `memory` or `disk`, with ties ordered by name, so that `flavors[0]` is e.g. the smallest matching flavor. When omitted, flavors are returned in API order.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(helper.FlavorSortByName, helper.FlavorSortByVCPUs, helper.FlavorSortByMemory, helper.FlavorSortByDisk),
+				},
+			},
 			"flavors": schema.ListNestedAttribute{
-				MarkdownDescription: "List of matching flavor objects.",
+				MarkdownDescription: "List of matching flavor objects. All flavors are returned when no filter is set.",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -106,6 +132,15 @@ func (d *FlavorDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	// Validate mutual exclusivity of name and name_pattern
+	if data.Name.ValueString() != "" && data.NamePattern.ValueString() != "" {
+		resp.Diagnostics.AddError(
+			"Invalid Filter Combination",
+			"Cannot specify both 'name' and 'name_pattern' filters. Please use only one name filter at a time.",
+		)
+		return
+	}
+
 	// If client not configured, return empty list (but not error) to avoid failing plan
 	if d.client == nil {
 		// Save empty list to state
@@ -115,7 +150,9 @@ func (d *FlavorDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Use typed SDK calls from cloud-sdk
-	fls, err := helper.ListFlavorsWithSDK(ctx, d.client, data)
+	fls, err := helper.WithRetry(ctx, func(ctx context.Context) ([]model.FlavorModel, error) {
+		return helper.ListFlavorsWithSDK(ctx, d.client, data)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Flavors list error", fmt.Sprintf("Failed to list flavors using SDK: %s", err))
 		data.Flavors = []model.FlavorModel{}
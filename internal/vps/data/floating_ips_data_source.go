@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	floatingipmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
@@ -35,7 +36,7 @@ func (d *FloatingIPsDataSource) Metadata(ctx context.Context, req datasource.Met
 
 func (d *FloatingIPsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Queries existing floating IPs (public IP addresses) from ZillaForge VPS. Use this data source to retrieve floating IP details by ID, name, IP address, or status. Returns a list of matching floating IPs, which can then be referenced in other resources. Supports client-side filtering with AND logic when multiple filters are specified.",
+		MarkdownDescription: "Queries existing floating IPs (public IP addresses) from ZillaForge VPS. Use this data source to retrieve floating IP details by ID, name, IP address, or status. Returns a list of matching floating IPs, which can then be referenced in other resources. Supports client-side filtering with AND logic when multiple filters are specified. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -114,7 +115,9 @@ func (d *FloatingIPsDataSource) Read(ctx context.Context, req datasource.ReadReq
 	// List all floating IPs from API
 	tflog.Debug(ctx, "Listing all floating IPs from API")
 
-	allFloatingIPs, err := vpsClient.FloatingIPs().List(ctx, nil)
+	allFloatingIPs, err := helper.WithRetry(ctx, func(ctx context.Context) ([]*floatingipmodels.FloatingIP, error) {
+		return vpsClient.FloatingIPs().List(ctx, nil)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to List Floating IPs",
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
@@ -35,17 +36,22 @@ func (d *KeypairDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 func (d *KeypairDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Query available SSH keypairs in ZillaForge VPS service. Supports individual lookup by ID or name, and listing all keypairs when no filters are specified.",
+		MarkdownDescription: "Query available SSH keypairs in ZillaForge VPS service. Supports individual lookup by ID, name or public key fingerprint, and listing all keypairs when no filters are specified. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Filter by specific keypair ID. Mutually exclusive with `name` filter. Returns single keypair if found, error if not found.",
+				MarkdownDescription: "Filter by specific keypair ID. Mutually exclusive with `name` and `fingerprint` filters. Returns single keypair if found, error if not found.",
 				Optional:            true,
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Filter by exact keypair name (case-sensitive). Mutually exclusive with `id` filter. Returns all keypairs matching the name.",
 				Optional:            true,
 			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "Filter by public key fingerprint, in the form reported by the API or as printed by `ssh-keygen -l` (`SHA256:...`, or `MD5:aa:bb:...` with `-E md5`). Mutually exclusive with `id` filter; combined with `name`, both must match. " +
+					"Returns an empty list rather than an error when no keypair matches, so `length(...keypairs) > 0` can detect an already-registered key.",
+				Optional: true,
+			},
 			"keypairs": schema.ListNestedAttribute{
 				MarkdownDescription: "List of matching keypair objects. Empty list if no matches found (for name filter) or error (for id filter).",
 				Computed:            true,
@@ -105,6 +111,7 @@ func (d *KeypairDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// T035: Validate mutual exclusivity of id and name filters
 	hasID := !data.ID.IsNull() && data.ID.ValueString() != ""
 	hasName := !data.Name.IsNull() && data.Name.ValueString() != ""
+	hasFingerprint := !data.Fingerprint.IsNull() && data.Fingerprint.ValueString() != ""
 
 	if hasID && hasName {
 		resp.Diagnostics.AddError(
@@ -113,6 +120,13 @@ func (d *KeypairDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		)
 		return
 	}
+	if hasID && hasFingerprint {
+		resp.Diagnostics.AddError(
+			"Invalid Filter Combination",
+			"Cannot specify both 'id' and 'fingerprint' filters. Please use only one filter at a time.",
+		)
+		return
+	}
 
 	// If client not configured, return empty list (but not error) to avoid failing plan
 	if d.client == nil {
@@ -125,7 +139,9 @@ func (d *KeypairDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	// T036: ID filter mode - use Get() for single keypair lookup
 	if hasID {
-		keypair, err := vpsClient.Keypairs().Get(ctx, data.ID.ValueString())
+		keypair, err := helper.WithRetry(ctx, func(ctx context.Context) (*keypairsmodels.Keypair, error) {
+			return vpsClient.Keypairs().Get(ctx, data.ID.ValueString())
+		})
 		if err != nil {
 			// T039: Error handling for not-found by ID scenario
 			resp.Diagnostics.AddError(
@@ -142,8 +158,10 @@ func (d *KeypairDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// T037: Name filter or list-all mode - use List()
-	keypairs, err := helper.ListKeypairsWithSDK(ctx, d.client, data)
+	// T037: Name/fingerprint filter or list-all mode - use List()
+	keypairs, err := helper.WithRetry(ctx, func(ctx context.Context) ([]model.KeypairModel, error) {
+		return helper.ListKeypairsWithSDK(ctx, d.client, data)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Keypairs List Error",
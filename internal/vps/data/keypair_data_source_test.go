@@ -226,3 +226,40 @@ data "zillaforge_keypairs" "test" {
   name = "non-existent-keypair-name-12345"
 }
 `
+
+// Acceptance test - Look up a keypair by fingerprint, and get an empty list
+// for a fingerprint that matches no keypair.
+func TestAccKeypairDataSource_FilterByFingerprint(t *testing.T) {
+	name := fmt.Sprintf("test-query-by-fingerprint-%d", time.Now().UnixNano())
+	config := fmt.Sprintf(`
+resource "zillaforge_keypair" "setup" {
+  name       = "%s"
+  public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBvYqSq6wTxEtyyTQ5ZKUAXsPB1bX6O4r1fy8rSaNpbF fingerprint@example.com"
+}
+
+data "zillaforge_keypairs" "test" {
+  fingerprint = zillaforge_keypair.setup.fingerprint
+}
+
+data "zillaforge_keypairs" "none" {
+  fingerprint = "SHA256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zillaforge_keypairs.test", "keypairs.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_keypairs.test", "keypairs.0.id", "zillaforge_keypair.setup", "id"),
+					resource.TestCheckResourceAttr("data.zillaforge_keypairs.test", "keypairs.0.name", name),
+					resource.TestCheckResourceAttrSet("data.zillaforge_keypairs.test", "keypairs.0.public_key"),
+					resource.TestCheckResourceAttr("data.zillaforge_keypairs.none", "keypairs.#", "0"),
+				),
+			},
+		},
+	})
+}
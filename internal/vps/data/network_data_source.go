@@ -6,75 +6,189 @@ package data
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	networksmodels "github.com/Zillaforge/cloud-sdk/models/vps/networks"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/networks"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/validators"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &NetworkDataSource{}
 
-func NewNetworkDataSource() datasource.DataSource { return &NetworkDataSource{} }
+// NewNetworkDataSource creates a new instance of the network data source.
+func NewNetworkDataSource() datasource.DataSource {
+	return &NetworkDataSource{}
+}
 
+// NetworkDataSource defines the single-network lookup data source implementation.
 type NetworkDataSource struct {
 	client *cloudsdk.ProjectClient
 }
 
 func (d *NetworkDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_networks"
+	resp.TypeName = req.ProviderTypeName + "_network"
 }
 
 func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Query available networks in Zillaforge VPS service.",
+		MarkdownDescription: "Looks up a single VPS network by ID or exact name, together with its subnet. " +
+			"Use it to feed `network_attachment.network_id` of `zillaforge_server`. " +
+			"Unlike `zillaforge_networks`, a missing network or a name shared by several networks is reported as an error. " +
+			"API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
+
 		Attributes: map[string]schema.Attribute{
-			"name":   schema.StringAttribute{MarkdownDescription: "Exact name match", Optional: true},
-			"status": schema.StringAttribute{MarkdownDescription: "Exact status match", Optional: true},
-			"networks": schema.ListNestedAttribute{MarkdownDescription: "List of matching networks", Computed: true, NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
-				"id":          schema.StringAttribute{MarkdownDescription: "Network id", Computed: true},
-				"name":        schema.StringAttribute{MarkdownDescription: "Network name", Computed: true},
-				"cidr":        schema.StringAttribute{MarkdownDescription: "CIDR block", Computed: true},
-				"status":      schema.StringAttribute{MarkdownDescription: "Network status", Computed: true},
-				"description": schema.StringAttribute{MarkdownDescription: "Optional description", Computed: true},
-			}}},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the network to look up. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					validators.UUIDValidator(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Exact name of the network to look up. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the network.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Network status, e.g. `ACTIVE`.",
+				Computed:            true,
+			},
+			"cidr": schema.StringAttribute{
+				MarkdownDescription: "CIDR block of the network's subnet.",
+				Computed:            true,
+			},
+			"gateway_ip": schema.StringAttribute{
+				MarkdownDescription: "Gateway address of the subnet.",
+				Computed:            true,
+			},
+			"enable_dhcp": schema.BoolAttribute{
+				MarkdownDescription: "Whether the subnet hands out addresses over DHCP. Always `true` on this platform.",
+				Computed:            true,
+			},
+			"subnet_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the network's subnet. Each network has exactly one.",
+				Computed:            true,
+			},
 		},
 	}
 }
 
 func (d *NetworkDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
 	if req.ProviderData == nil {
 		return
 	}
-	projectClient, ok := req.ProviderData.(*cloudsdk.ProjectClient)
-	if ok {
-		d.client = projectClient
+
+	client, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudsdk.ProjectClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
 	}
+
+	d.client = client
 }
 
 func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data model.NetworkDataSourceModel
+	var data model.NetworkLookupModel
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if d.client == nil {
-		// return empty list
-		data.Networks = []model.NetworkModel{}
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		return
-	}
+	var network *networksmodels.Network
+	if !data.ID.IsNull() {
+		id := data.ID.ValueString()
+		tflog.Debug(ctx, "Reading network by ID", map[string]interface{}{
+			"id": id,
+		})
 
-	nets, err := helper.ListNetworksWithSDK(ctx, d.client, data)
-	if err != nil {
-		resp.Diagnostics.AddError("Networks list error", fmt.Sprintf("Failed to list networks using SDK: %s", err))
-		data.Networks = []model.NetworkModel{}
+		networkRes, err := helper.WithRetry(ctx, func(ctx context.Context) (*networks.NetworkResource, error) {
+			return d.client.VPS().Networks().Get(ctx, id)
+		})
+		if err != nil {
+			if helper.IsNotFound(err) {
+				resp.Diagnostics.AddError(
+					"Network Not Found",
+					fmt.Sprintf("No network with ID %q exists in this project. Use the `zillaforge_networks` data source to list available networks.", id),
+				)
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Failed to Retrieve Network",
+				fmt.Sprintf("Unable to get network %s: %s", id, err.Error()),
+			)
+			return
+		}
+		network = networkRes.Network
 	} else {
-		data.Networks = nets
+		name := data.Name.ValueString()
+		tflog.Debug(ctx, "Reading network by name", map[string]interface{}{
+			"name": name,
+		})
+
+		matches, err := helper.WithRetry(ctx, func(ctx context.Context) ([]*networksmodels.Network, error) {
+			return helper.FindNetworksByName(ctx, d.client, name)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Retrieve Network",
+				fmt.Sprintf("Unable to list networks named %q: %s", name, err.Error()),
+			)
+			return
+		}
+
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddError(
+				"Network Not Found",
+				fmt.Sprintf("No network named %q exists in this project. Use the `zillaforge_networks` data source to list available networks.", name),
+			)
+			return
+		case 1:
+			network = matches[0]
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, match := range matches {
+				ids = append(ids, match.ID)
+			}
+			resp.Diagnostics.AddError(
+				"Multiple Networks Found",
+				fmt.Sprintf("%d networks are named %q (IDs: %s). Look the network up by `id` instead.", len(matches), name, strings.Join(ids, ", ")),
+			)
+			return
+		}
 	}
+
+	data = helper.NetworkLookupFromNetwork(network)
+
+	tflog.Debug(ctx, "Successfully retrieved network", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	tflog.Trace(ctx, "Read zillaforge_networks data source")
 }
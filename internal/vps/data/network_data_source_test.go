@@ -11,166 +11,91 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-// T035: Test basic networks query without filters.
-func TestAccNetworkDataSource_basic(t *testing.T) {
+// Acceptance test - Look up a network by the ID returned from zillaforge_networks.
+func TestAccNetworkLookupDataSource_ByID(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { provider.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccNetworkDataSourceConfig_all,
+				Config: testAccNetworkLookupDataSourceConfig_byID,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify networks list exists
-					resource.TestCheckResourceAttrSet("data.zillaforge_networks.test", "networks.#"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_network.test", "id", "data.zillaforge_networks.all", "networks.0.id"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_network.test", "name", "data.zillaforge_networks.all", "networks.0.name"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_network.test", "cidr", "data.zillaforge_networks.all", "networks.0.cidr"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_network.test", "gateway_ip"),
+					resource.TestCheckResourceAttr("data.zillaforge_network.test", "enable_dhcp", "true"),
 				),
 			},
 		},
 	})
 }
 
-const testAccNetworkDataSourceConfig_all = `
-data "zillaforge_networks" "test" {}
-`
-
-// T036: Test name filter with exact match.
-func TestAccNetworkDataSource_nameFilter(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { provider.TestAccPreCheck(t) },
-		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccNetworkDataSourceConfig_name,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify networks list exists
-					resource.TestCheckResourceAttrSet("data.zillaforge_networks.test", "networks.#"),
-					// Verify filter was applied
-					resource.TestCheckResourceAttr("data.zillaforge_networks.test", "name", "private-network"),
-				),
-			},
-		},
-	})
-}
-
-const testAccNetworkDataSourceConfig_name = `
-data "zillaforge_networks" "test" {
-  name = "private-network"
-}
-`
-
-// T037: Test status filter.
-func TestAccNetworkDataSource_statusFilter(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { provider.TestAccPreCheck(t) },
-		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccNetworkDataSourceConfig_status,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify networks list exists
-					resource.TestCheckResourceAttrSet("data.zillaforge_networks.test", "networks.#"),
-					// Verify filter was applied
-					resource.TestCheckResourceAttr("data.zillaforge_networks.test", "status", "ACTIVE"),
-				),
-			},
-		},
-	})
-}
+const testAccNetworkLookupDataSourceConfig_byID = `
+data "zillaforge_networks" "all" {}
 
-const testAccNetworkDataSourceConfig_status = `
-data "zillaforge_networks" "test" {
-  status = "ACTIVE"
+data "zillaforge_network" "test" {
+  id = data.zillaforge_networks.all.networks[0].id
 }
 `
 
-// T038: Test multiple filters with AND logic.
-func TestAccNetworkDataSource_multipleFilters(t *testing.T) {
+// Acceptance test - Look up a network by exact name.
+func TestAccNetworkLookupDataSource_ByName(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { provider.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccNetworkDataSourceConfig_multiple,
+				Config: testAccNetworkLookupDataSourceConfig_byName,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify networks list exists
-					resource.TestCheckResourceAttrSet("data.zillaforge_networks.test", "networks.#"),
-					// Verify all filters were applied
-					resource.TestCheckResourceAttr("data.zillaforge_networks.test", "name", "dmz"),
-					resource.TestCheckResourceAttr("data.zillaforge_networks.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_network.test", "id", "data.zillaforge_networks.all", "networks.0.id"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_network.test", "subnet_id"),
 				),
 			},
 		},
 	})
 }
 
-const testAccNetworkDataSourceConfig_multiple = `
-data "zillaforge_networks" "test" {
-  name   = "dmz"
-  status = "ACTIVE"
-}
-`
+const testAccNetworkLookupDataSourceConfig_byName = `
+data "zillaforge_networks" "all" {}
 
-// T039: Test empty results when no matches found.
-func TestAccNetworkDataSource_emptyResults(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { provider.TestAccPreCheck(t) },
-		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccNetworkDataSourceConfig_empty,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify networks list exists (empty list, not null)
-					resource.TestCheckResourceAttrSet("data.zillaforge_networks.test", "networks.#"),
-					// Verify filter was applied
-					resource.TestCheckResourceAttr("data.zillaforge_networks.test", "name", "non-existent-network-xyz"),
-				),
-			},
-		},
-	})
-}
-
-const testAccNetworkDataSourceConfig_empty = `
-# Use an unrealistic filter to ensure no matches
-data "zillaforge_networks" "test" {
-  name = "non-existent-network-xyz"
+data "zillaforge_network" "test" {
+  name = data.zillaforge_networks.all.networks[0].name
 }
 `
 
-// T040: Test API authentication error.
-func TestAccNetworkDataSource_apiAuthError(t *testing.T) {
+// Acceptance test - Unknown name returns an error instead of an empty result.
+func TestAccNetworkLookupDataSource_NotFound(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { provider.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: `
-provider "zillaforge" {
-	api_key = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e30.signature"
+data "zillaforge_network" "test" {
+  name = "non-existent-network-xyz"
 }
-
-data "zillaforge_networks" "test" {}
 `,
-				ExpectError: regexp.MustCompile(`(?i)unauthori|401|403|authentication|invalid credentials|verify token|illegal token|sdk initialization failed|\b400\b`),
+				ExpectError: regexp.MustCompile(`Network Not Found`),
 			},
 		},
 	})
 }
 
-// T041: Test API error handling.
-func TestAccNetworkDataSource_apiErrorHandling(t *testing.T) {
+// Acceptance test - id and name are mutually exclusive.
+func TestAccNetworkLookupDataSource_IDAndName(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { provider.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: `
-provider "zillaforge" {
-	api_endpoint = "http://127.0.0.1:1"
-	api_key = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e30.signature"
+data "zillaforge_network" "test" {
+  id   = "00000000-0000-0000-0000-000000000000"
+  name = "prod"
 }
-
-data "zillaforge_networks" "test" {}
 `,
-				ExpectError: regexp.MustCompile(`(?i)connection refused|connect:|timeout|EOF|no such host`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
 			},
 		},
 	})
@@ -9,6 +9,7 @@ import (
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/securitygroups"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	resourcemodel "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
@@ -37,7 +38,7 @@ func (d *SecurityGroupsDataSource) Metadata(ctx context.Context, req datasource.
 
 func (d *SecurityGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Queries existing security groups from ZillaForge VPS. Use this data source to retrieve security group details by ID or name, or to list all security groups in your project. Security groups returned include all ingress/egress rules and can be referenced in other resources.",
+		MarkdownDescription: "Queries existing security groups from ZillaForge VPS. Use this data source to retrieve security group details by ID or name, or to list all security groups in your project. Security groups returned include all ingress/egress rules and can be referenced in other resources. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -62,7 +63,7 @@ func (d *SecurityGroupsDataSource) Schema(ctx context.Context, req datasource.Sc
 							Computed:            true,
 						},
 						"description": schema.StringAttribute{
-							MarkdownDescription: "Optional description providing context about the security group's purpose. Empty string if not set.",
+							MarkdownDescription: "Optional description providing context about the security group's purpose. Null if not set, matching the `zillaforge_security_group` resource.",
 							Computed:            true,
 						},
 						"ingress_rule": schema.ListNestedAttribute{
@@ -71,7 +72,7 @@ func (d *SecurityGroupsDataSource) Schema(ctx context.Context, req datasource.Sc
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"protocol": schema.StringAttribute{
-										MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `any`.",
+										MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `icmpv6`, `any`.",
 										Computed:            true,
 									},
 									"port_range": schema.StringAttribute{
@@ -95,7 +96,7 @@ func (d *SecurityGroupsDataSource) Schema(ctx context.Context, req datasource.Sc
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"protocol": schema.StringAttribute{
-										MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `any`.",
+										MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `icmpv6`, `any`.",
 										Computed:            true,
 									},
 									"port_range": schema.StringAttribute{
@@ -158,7 +159,9 @@ func (d *SecurityGroupsDataSource) Read(ctx context.Context, req datasource.Read
 			"id": config.ID.ValueString(),
 		})
 
-		securityGroupResource, err := vpsClient.SecurityGroups().Get(ctx, config.ID.ValueString())
+		securityGroupResource, err := helper.WithRetry(ctx, func(ctx context.Context) (*securitygroups.SecurityGroupResource, error) {
+			return vpsClient.SecurityGroups().Get(ctx, config.ID.ValueString())
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Failed to Query Security Group by ID",
@@ -182,8 +185,10 @@ func (d *SecurityGroupsDataSource) Read(ctx context.Context, req datasource.Read
 		})
 
 		// Request detailed information including rules
-		allGroups, err := vpsClient.SecurityGroups().List(ctx, &sgmodels.ListSecurityGroupsOptions{
-			Detail: true,
+		allGroups, err := helper.WithRetry(ctx, func(ctx context.Context) ([]*securitygroups.SecurityGroupResource, error) {
+			return vpsClient.SecurityGroups().List(ctx, &sgmodels.ListSecurityGroupsOptions{
+				Detail: true,
+			})
 		})
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -214,8 +219,10 @@ func (d *SecurityGroupsDataSource) Read(ctx context.Context, req datasource.Read
 		tflog.Debug(ctx, "Listing all security groups")
 
 		// Request detailed information including rules
-		allGroups, err := vpsClient.SecurityGroups().List(ctx, &sgmodels.ListSecurityGroupsOptions{
-			Detail: true,
+		allGroups, err := helper.WithRetry(ctx, func(ctx context.Context) ([]*securitygroups.SecurityGroupResource, error) {
+			return vpsClient.SecurityGroups().List(ctx, &sgmodels.ListSecurityGroupsOptions{
+				Detail: true,
+			})
 		})
 		if err != nil {
 			resp.Diagnostics.AddError(
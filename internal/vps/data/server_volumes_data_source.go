@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerVolumesDataSource{}
+
+// NewServerVolumesDataSource creates a new instance of the server volumes data source.
+func NewServerVolumesDataSource() datasource.DataSource {
+	return &ServerVolumesDataSource{}
+}
+
+// ServerVolumesDataSource lists the volumes attached to a server.
+type ServerVolumesDataSource struct {
+	client *cloudsdk.ProjectClient
+}
+
+func (d *ServerVolumesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_volumes"
+}
+
+func (d *ServerVolumesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the volumes attached to a ZillaForge VPS server, including its system volume. Use this data source to audit a server's disk layout or to drive resources that depend on its devices. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
+
+		Attributes: map[string]schema.Attribute{
+			"server_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the server whose volumes are listed (UUID format). Reading fails if the server does not exist.",
+				Required:            true,
+			},
+			"volumes": schema.ListNestedAttribute{
+				MarkdownDescription: "Volumes attached to the server. Results are sorted by `device` for deterministic ordering.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique identifier for the volume (UUID format).",
+							Computed:            true,
+						},
+						"device": schema.StringAttribute{
+							MarkdownDescription: "Device path the volume is attached at inside the server (e.g., `/dev/vdb`).",
+							Computed:            true,
+						},
+						"size_gb": schema.Int64Attribute{
+							MarkdownDescription: "Size of the volume in GB. Null if the API did not report the volume details.",
+							Computed:            true,
+						},
+						"boot_index": schema.Int64Attribute{
+							MarkdownDescription: "`0` for the system volume the server boots from, null for other volumes. The API does not report a boot order beyond the system volume.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServerVolumesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudsdk.ProjectClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ServerVolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data model.ServerVolumesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverID := data.ServerID.ValueString()
+	serversClient := d.client.VPS().Servers()
+
+	serverRes, err := helper.WithRetry(ctx, func(ctx context.Context) (*serversdk.ServerResource, error) {
+		return serversClient.Get(ctx, serverID)
+	})
+	if err != nil {
+		if helper.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_id"),
+				"Server Not Found",
+				fmt.Sprintf("No server with ID '%s' exists in this project. Check that server_id refers to an existing server.", serverID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to Read Server",
+			fmt.Sprintf("Unable to read server %s: %s", serverID, err),
+		)
+		return
+	}
+
+	volumes, err := helper.WithRetry(ctx, func(ctx context.Context) ([]*servermodels.ServerVolume, error) {
+		return serverRes.Volumes().List(ctx)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to List Server Volumes",
+			fmt.Sprintf("Unable to list volumes of server %s: %s", serverID, err),
+		)
+		return
+	}
+
+	data.Volumes = helper.MapServerVolumes(volumes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	tflog.Info(ctx, "Successfully queried server volumes", map[string]interface{}{
+		"server_id":    serverID,
+		"result_count": len(data.Volumes),
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServerVolumesDataSource_Basic(t *testing.T) {
+	name := fmt.Sprintf("test-server-volumes-%d", time.Now().UnixNano()%100000)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerVolumesDataSourceConfig_basic, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.zillaforge_server_volumes.test", "server_id",
+						"zillaforge_server.test", "id",
+					),
+					// The system volume is always attached
+					resource.TestCheckResourceAttrSet("data.zillaforge_server_volumes.test", "volumes.0.id"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_server_volumes.test", "volumes.0.device"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.zillaforge_server_volumes.test", "volumes.*", map[string]string{
+						"boot_index": "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerVolumesDataSourceConfig_basic = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+  }
+}
+
+data "zillaforge_server_volumes" "test" {
+  server_id = zillaforge_server.test.id
+}
+`
+
+func TestAccServerVolumesDataSource_ServerNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "zillaforge_server_volumes" "missing" {
+  server_id = "00000000-0000-0000-0000-000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile("Server Not Found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/validators"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &SubnetsDataSource{}
+
+func NewSubnetsDataSource() datasource.DataSource { return &SubnetsDataSource{} }
+
+type SubnetsDataSource struct {
+	client *cloudsdk.ProjectClient
+}
+
+func (d *SubnetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subnets"
+}
+
+func (d *SubnetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query the subnets of a Zillaforge VPS network. Use it to pick a valid fixed `ip_address` for a `zillaforge_server` `network_attachment`. API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
+		Attributes: map[string]schema.Attribute{
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the network whose subnets to return.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.NetworkIDValidator(),
+				},
+			},
+			"subnets": schema.ListNestedAttribute{MarkdownDescription: "Subnets of the network", Computed: true, NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+				"id":         schema.StringAttribute{MarkdownDescription: "Subnet id", Computed: true},
+				"cidr":       schema.StringAttribute{MarkdownDescription: "CIDR block", Computed: true},
+				"gateway_ip": schema.StringAttribute{MarkdownDescription: "Gateway address. Empty when the subnet has no gateway.", Computed: true},
+				"allocation_pools": schema.ListNestedAttribute{
+					MarkdownDescription: "Address ranges available for fixed and automatically assigned IPs: every host address in `cidr` except the gateway.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{MarkdownDescription: "First address of the range", Computed: true},
+						"end":   schema.StringAttribute{MarkdownDescription: "Last address of the range", Computed: true},
+					}},
+				},
+				"dns_nameservers": schema.ListAttribute{MarkdownDescription: "DNS name servers handed out to instances", Computed: true, ElementType: types.StringType},
+			}}},
+		},
+	}
+}
+
+func (d *SubnetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	projectClient, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	if ok {
+		d.client = projectClient
+	}
+}
+
+func (d *SubnetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data model.SubnetDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		// return empty list
+		data.Subnets = []model.SubnetModel{}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	subnets, err := helper.WithRetry(ctx, func(ctx context.Context) ([]model.SubnetModel, error) {
+		return helper.GetSubnetsWithSDK(ctx, d.client, data.NetworkID.ValueString())
+	})
+	if err != nil {
+		if helper.IsNotFound(err) {
+			resp.Diagnostics.AddError("Network Not Found", fmt.Sprintf("Network %s does not exist or is not accessible in this project.", data.NetworkID.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Subnets read error", fmt.Sprintf("Failed to read subnets using SDK: %s", err))
+		return
+	}
+	data.Subnets = subnets
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	tflog.Trace(ctx, "Read zillaforge_subnets data source")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Test subnets lookup for an existing network.
+func TestAccSubnetsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubnetsDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zillaforge_subnets.test", "subnets.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_subnets.test", "subnets.0.cidr", "data.zillaforge_networks.test", "networks.0.cidr"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_subnets.test", "subnets.0.allocation_pools.0.start"),
+					resource.TestCheckResourceAttrSet("data.zillaforge_subnets.test", "subnets.0.allocation_pools.0.end"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSubnetsDataSourceConfig_basic = `
+data "zillaforge_networks" "test" {}
+
+data "zillaforge_subnets" "test" {
+  network_id = data.zillaforge_networks.test.networks[0].id
+}
+`
+
+// Test an unknown network_id surfaces a clear error.
+func TestAccSubnetsDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSubnetsDataSourceConfig_notFound,
+				ExpectError: regexp.MustCompile(`Network Not Found`),
+			},
+		},
+	})
+}
+
+const testAccSubnetsDataSourceConfig_notFound = `
+data "zillaforge_subnets" "test" {
+  network_id = "00000000-0000-0000-0000-000000000000"
+}
+`
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIClient calls VPS endpoints the SDK does not cover yet (quotas, Windows
+// passwords) with the provider's HTTP client and credentials.
+type APIClient struct {
+	// BaseURL is the VPS service URL, i.e. the API endpoint plus "/vps".
+	BaseURL    string
+	ProjectID  string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// getJSON decodes the response of a GET on path, relative to the project,
+// into out.
+func (c *APIClient) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.doJSON(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// doJSON sends body (if any) as JSON with the given method to path, relative
+// to the project, and decodes the response into out.
+func (c *APIClient) doJSON(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	reqURL := strings.TrimSuffix(c.BaseURL, "/") + "/api/v1/project/" + c.ProjectID + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned HTTP %d", method, path, httpResp.StatusCode)
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(out); err != nil {
+		return fmt.Errorf("unable to decode %s response: %w", path, err)
+	}
+	return nil
+}
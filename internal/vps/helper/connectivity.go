@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"time"
+
+	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+)
+
+// CheckConnectivity makes one lightweight authenticated, project-scoped call,
+// listing the project's keypairs, and returns how long it took. The call is
+// not retried, so a broken connection is reported at once.
+func CheckConnectivity(ctx context.Context, keypairsClient interface {
+	List(ctx context.Context, opts *keypairsmodels.ListKeypairsOptions) ([]*keypairsmodels.Keypair, error)
+}) (time.Duration, error) {
+	start := time.Now()
+	_, err := keypairsClient.List(ctx, nil)
+	return time.Since(start), err
+}
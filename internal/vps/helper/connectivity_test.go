@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+)
+
+// fakeKeypairLister answers List after delay with err.
+type fakeKeypairLister struct {
+	delay time.Duration
+	err   error
+}
+
+func (f fakeKeypairLister) List(ctx context.Context, opts *keypairsmodels.ListKeypairsOptions) ([]*keypairsmodels.Keypair, error) {
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*keypairsmodels.Keypair{}, nil
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		client      fakeKeypairLister
+		expectError bool
+	}{
+		{name: "reachable", client: fakeKeypairLister{delay: 20 * time.Millisecond}},
+		{name: "unauthorized", client: fakeKeypairLister{delay: 20 * time.Millisecond, err: errors.New("401 unauthorized")}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			latency, err := CheckConnectivity(context.Background(), tt.client)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if latency < tt.client.delay {
+				t.Errorf("expected a latency of at least %s, got %s", tt.client.delay, latency)
+			}
+		})
+	}
+}
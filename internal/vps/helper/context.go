@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import "context"
+
+type clearedFieldsKey struct{}
+
+// WithClearedFields marks JSON body fields that requests made with the
+// returned context must send as empty strings. The SDK request models tag
+// optional strings with omitempty, so without this an attribute cleared in
+// configuration is dropped from the request and never cleared in the API.
+// The provider's HTTP transport reads the marker and adds the fields back.
+func WithClearedFields(ctx context.Context, fields ...string) context.Context {
+	return context.WithValue(ctx, clearedFieldsKey{}, append(ClearedFields(ctx), fields...))
+}
+
+// ClearedFields returns the fields marked by WithClearedFields.
+func ClearedFields(ctx context.Context) []string {
+	fields, _ := ctx.Value(clearedFieldsKey{}).([]string)
+	return fields
+}
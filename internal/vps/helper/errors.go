@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// IsNotFound reports whether err means the requested object does not exist.
+// SDK errors are matched on their HTTP status; errors wrapped by other layers
+// fall back to matching the "404"/"not found" text the API returns.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sdkErr *cloudsdk.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode == http.StatusNotFound
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "404") || strings.Contains(strings.ToLower(msg), "not found")
+}
+
+// IsConflict reports whether err means the API rejected a request as
+// conflicting with an existing object, such as a duplicate security group
+// rule. Errors without an HTTP status fall back to matching "409" or
+// "already exists".
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sdkErr *cloudsdk.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode == http.StatusConflict
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "409") || strings.Contains(strings.ToLower(msg), "already exists")
+}
+
+// IsInUse reports whether err means the API refused to delete an object that
+// is still referenced, such as a security group attached to a server: a 409
+// Conflict, or the network service's "<object> in use" message, which is not
+// always sent with that status.
+func IsInUse(err error) bool {
+	if err == nil {
+		return false
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "in use") {
+		return true
+	}
+
+	var sdkErr *cloudsdk.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode == http.StatusConflict
+	}
+	return strings.Contains(err.Error(), "409")
+}
+
+// IsForbidden reports whether err means the API key is not allowed to perform
+// the request, as with keys scoped to a subset of operations. Errors without
+// an HTTP status fall back to matching "403", "forbidden" or "permission
+// denied".
+func IsForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sdkErr *cloudsdk.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode == http.StatusForbidden
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "403") || strings.Contains(msg, "forbidden") || strings.Contains(msg, "permission denied")
+}
+
+// IsOutcomeUnknown reports whether err leaves it unknown if a write was
+// applied: the request timed out or lost its connection, or a gateway gave up
+// waiting for the API (502, 504). The API may have completed it anyway.
+func IsOutcomeUnknown(err error) bool {
+	var sdkErr *cloudsdk.SDKError
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+
+	switch sdkErr.StatusCode {
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	case 0:
+		category, _ := sdkErr.Meta["category"].(string)
+		return category == "timeout" || category == "network"
+	}
+	return false
+}
+
+// APIErrorDiagnostic returns the error diagnostic for a failed API call,
+// detailed as "Unable to <action>: <err>". A forbidden request is instead
+// reported as the permission the API key lacks, with the raw error kept for
+// reference.
+func APIErrorDiagnostic(summary, action string, err error) diag.Diagnostic {
+	if IsForbidden(err) {
+		return diag.NewErrorDiagnostic(
+			summary,
+			fmt.Sprintf("Permission denied: your API key lacks permission to %s on this project. "+
+				"Use an API key whose scope includes this operation, or ask a project administrator to grant it.\n\n"+
+				"API error: %s", action, err),
+		)
+	}
+	return diag.NewErrorDiagnostic(summary, fmt.Sprintf("Unable to %s: %s", action, err))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+)
+
+func TestIsConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "wrapped sdk 409", err: fmt.Errorf("failed to create rule: %w", cloudsdk.NewSDKError(409, 0, "rule exists", nil, nil)), expected: true},
+		{name: "sdk 400 mentioning already exists", err: cloudsdk.NewSDKError(400, 0, "name already exists in another project", nil, nil), expected: false},
+		{name: "plain already exists text", err: errors.New("Security group rule already exists"), expected: true},
+		{name: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsConflict(tt.err); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "sdk 404", err: cloudsdk.NewSDKError(404, 0, "missing", nil, nil), expected: true},
+		{name: "wrapped sdk 404", err: fmt.Errorf("failed to delete: %w", cloudsdk.NewSDKError(404, 0, "missing", nil, nil)), expected: true},
+		{name: "sdk 409 mentioning not found", err: cloudsdk.NewSDKError(409, 0, "port not found on host", nil, nil), expected: false},
+		{name: "plain not found text", err: errors.New("keypair Not Found"), expected: true},
+		{name: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsNotFound(tt.err); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsForbidden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "wrapped sdk 403", err: fmt.Errorf("failed to create server: %w", cloudsdk.NewSDKError(403, 0, "forbidden", nil, nil)), expected: true},
+		{name: "sdk 401", err: cloudsdk.NewSDKError(401, 0, "token expired", nil, nil), expected: false},
+		{name: "sdk 400 mentioning permission denied", err: cloudsdk.NewSDKError(400, 0, "permission denied for file", nil, nil), expected: false},
+		{name: "plain forbidden text", err: errors.New("HTTP 403 Forbidden"), expected: true},
+		{name: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsForbidden(tt.err); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsInUse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "wrapped sdk 409", err: fmt.Errorf("failed to delete keypair: %w", cloudsdk.NewSDKError(409, 0, "conflict", nil, nil)), expected: true},
+		{name: "sdk 400 mentioning in use", err: cloudsdk.NewSDKError(400, 0, "(neutron)Security Group 0a1b in use.", nil, nil), expected: true},
+		{name: "sdk 404", err: cloudsdk.NewSDKError(404, 0, "missing", nil, nil), expected: false},
+		{name: "plain 409 text", err: errors.New("HTTP 409 Conflict"), expected: true},
+		{name: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsInUse(tt.err); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsOutcomeUnknown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "wrapped timeout", err: fmt.Errorf("failed to create server: %w", cloudsdk.NewTimeoutError(errors.New("deadline exceeded"))), expected: true},
+		{name: "network error", err: cloudsdk.NewNetworkError("connection reset by peer", nil), expected: true},
+		{name: "gateway timeout", err: cloudsdk.NewSDKError(504, 0, "gateway timeout", nil, nil), expected: true},
+		{name: "service unavailable", err: cloudsdk.NewSDKError(503, 0, "maintenance", nil, nil), expected: false},
+		{name: "canceled", err: cloudsdk.NewCanceledError(errors.New("context canceled")), expected: false},
+		{name: "plain error", err: errors.New("request timeout"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsOutcomeUnknown(tt.err); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedDetail string
+	}{
+		{
+			name:           "forbidden",
+			err:            fmt.Errorf("failed to delete keypair: %w", cloudsdk.NewSDKError(403, 0, "forbidden", nil, nil)),
+			expectedDetail: "Permission denied: your API key lacks permission to delete keypair on this project.",
+		},
+		{
+			name:           "other error",
+			err:            errors.New("connection refused"),
+			expectedDetail: "Unable to delete keypair: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := APIErrorDiagnostic("Delete Error", "delete keypair", tt.err)
+			if d.Summary() != "Delete Error" {
+				t.Errorf("unexpected summary: %s", d.Summary())
+			}
+			if !strings.HasPrefix(d.Detail(), tt.expectedDetail) {
+				t.Errorf("expected detail starting with %q, got %q", tt.expectedDetail, d.Detail())
+			}
+			if !strings.Contains(d.Detail(), tt.err.Error()) {
+				t.Errorf("expected the API error in the detail, got %q", d.Detail())
+			}
+		})
+	}
+}
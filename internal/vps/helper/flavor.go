@@ -6,6 +6,8 @@ package helper
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	flavorsmodels "github.com/Zillaforge/cloud-sdk/models/vps/flavors"
@@ -29,18 +31,45 @@ func ListFlavorsWithSDK(ctx context.Context, projectClient *cloudsdk.ProjectClie
 		return nil, fmt.Errorf("sdk Flavor List() error: %w", err)
 	}
 
-	results := []model.FlavorModel{}
+	return FilterFlavors(flavorList, filters)
+}
+
+// Values accepted by the sort_by argument of the flavors data source.
+const (
+	FlavorSortByName   = "name"
+	FlavorSortByVCPUs  = "vcpus"
+	FlavorSortByMemory = "memory"
+	FlavorSortByDisk   = "disk"
+)
+
+// FilterFlavors applies the data source filters to flavorList and sorts the
+// result by sort_by, ascending, with ties broken by name. Without sort_by the
+// API order is kept. name_pattern is a glob matched with filepath.Match.
+func FilterFlavors(flavorList []*flavorsmodels.Flavor, filters model.FlavorDataSourceModel) ([]model.FlavorModel, error) {
+	matched := []*flavorsmodels.Flavor{}
 	for _, f := range flavorList {
 		// Exact name match
 		if !filters.Name.IsNull() && f.Name != filters.Name.ValueString() {
 			continue
 		}
+		if pattern := filters.NamePattern.ValueString(); pattern != "" {
+			ok, err := filepath.Match(pattern, f.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid name_pattern %q: %w", pattern, err)
+			}
+			if !ok {
+				continue
+			}
+		}
 		// min vcpus
 		if !filters.VCPUs.IsNull() {
 			if int64(f.VCPU) < filters.VCPUs.ValueInt64() {
 				continue
 			}
 		}
+		if !filters.MinVCPUs.IsNull() && int64(f.VCPU) < filters.MinVCPUs.ValueInt64() {
+			continue
+		}
 		// min memory - SDK returns GiB
 		if !filters.Memory.IsNull() {
 			memoryGB := int64(f.Memory)
@@ -48,16 +77,60 @@ func ListFlavorsWithSDK(ctx context.Context, projectClient *cloudsdk.ProjectClie
 				continue
 			}
 		}
+		if !filters.MinMemoryMB.IsNull() && int64(f.Memory) < filters.MinMemoryMB.ValueInt64() {
+			continue
+		}
+		matched = append(matched, f)
+	}
+
+	if sortBy := filters.SortBy.ValueString(); sortBy != "" {
+		key := func(f *flavorsmodels.Flavor) int {
+			switch sortBy {
+			case FlavorSortByVCPUs:
+				return f.VCPU
+			case FlavorSortByMemory:
+				return f.Memory
+			case FlavorSortByDisk:
+				return f.Disk
+			}
+			return 0
+		}
+		sort.SliceStable(matched, func(i, j int) bool {
+			if ki, kj := key(matched[i]), key(matched[j]); ki != kj {
+				return ki < kj
+			}
+			return matched[i].Name < matched[j].Name
+		})
+	}
 
-		fm := model.FlavorModel{
+	results := make([]model.FlavorModel, 0, len(matched))
+	for _, f := range matched {
+		results = append(results, model.FlavorModel{
 			ID:          types.StringValue(f.ID),
 			Name:        types.StringValue(f.Name),
 			VCPUs:       types.Int64Value(int64(f.VCPU)),
 			Memory:      types.Int64Value(int64(f.Memory)),
 			Disk:        types.Int64Value(int64(f.Disk)),
 			Description: types.StringValue(f.Description),
-		}
-		results = append(results, fm)
+		})
 	}
 	return results, nil
 }
+
+// GetFlavorByID returns the flavor with the given ID. The flavors API has no
+// single-item endpoint, so this lists all flavors and matches on ID.
+func GetFlavorByID(ctx context.Context, projectClient *cloudsdk.ProjectClient, id string) (*flavorsmodels.Flavor, error) {
+	if projectClient == nil {
+		return nil, fmt.Errorf("no project client available")
+	}
+	flavorList, err := projectClient.VPS().Flavors().List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sdk Flavor List() error: %w", err)
+	}
+	for _, f := range flavorList {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("flavor %s not found", id)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"reflect"
+	"testing"
+
+	flavorsmodels "github.com/Zillaforge/cloud-sdk/models/vps/flavors"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFilterFlavors(t *testing.T) {
+	t.Parallel()
+
+	flavors := []*flavorsmodels.Flavor{
+		{ID: "f-3", Name: "m1.large", VCPU: 4, Memory: 8192, Disk: 80},
+		{ID: "f-1", Name: "m1.small", VCPU: 1, Memory: 2048, Disk: 20},
+		{ID: "f-4", Name: "c1.large", VCPU: 8, Memory: 8192, Disk: 40},
+		{ID: "f-2", Name: "m1.medium", VCPU: 2, Memory: 4096, Disk: 40},
+	}
+
+	filters := func(modify func(*model.FlavorDataSourceModel)) model.FlavorDataSourceModel {
+		f := model.FlavorDataSourceModel{
+			Name:        types.StringNull(),
+			NamePattern: types.StringNull(),
+			VCPUs:       types.Int64Null(),
+			Memory:      types.Int64Null(),
+			MinVCPUs:    types.Int64Null(),
+			MinMemoryMB: types.Int64Null(),
+			SortBy:      types.StringNull(),
+		}
+		if modify != nil {
+			modify(&f)
+		}
+		return f
+	}
+
+	tests := []struct {
+		name        string
+		filters     model.FlavorDataSourceModel
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "no filters",
+			filters:  filters(nil),
+			expected: []string{"f-3", "f-1", "f-4", "f-2"},
+		},
+		{
+			name:     "name pattern",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.NamePattern = types.StringValue("m1.*") }),
+			expected: []string{"f-3", "f-1", "f-2"},
+		},
+		{
+			name:     "min vcpus",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.MinVCPUs = types.Int64Value(2) }),
+			expected: []string{"f-3", "f-4", "f-2"},
+		},
+		{
+			name:     "min memory",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.MinMemoryMB = types.Int64Value(4096) }),
+			expected: []string{"f-3", "f-4", "f-2"},
+		},
+		{
+			name: "smallest matching flavor first",
+			filters: filters(func(f *model.FlavorDataSourceModel) {
+				f.NamePattern = types.StringValue("m1.*")
+				f.MinVCPUs = types.Int64Value(2)
+				f.SortBy = types.StringValue(FlavorSortByVCPUs)
+			}),
+			expected: []string{"f-2", "f-3"},
+		},
+		{
+			name:     "sort ties by name",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.SortBy = types.StringValue(FlavorSortByMemory) }),
+			expected: []string{"f-1", "f-2", "f-4", "f-3"},
+		},
+		{
+			name:     "sort by name",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.SortBy = types.StringValue(FlavorSortByName) }),
+			expected: []string{"f-4", "f-3", "f-2", "f-1"},
+		},
+		{
+			name:     "no match",
+			filters:  filters(func(f *model.FlavorDataSourceModel) { f.NamePattern = types.StringValue("g1.*") }),
+			expected: []string{},
+		},
+		{
+			name:        "invalid pattern",
+			filters:     filters(func(f *model.FlavorDataSourceModel) { f.NamePattern = types.StringValue("m1.[") }),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := FilterFlavors(flavors, tt.filters)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ids := []string{}
+			for _, r := range results {
+				ids = append(ids, r.ID.ValueString())
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
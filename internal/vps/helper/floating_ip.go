@@ -5,11 +5,17 @@ package helper
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	floatingipmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // stringPointerOrNull returns nil for empty strings (converts to types.StringNull).
@@ -72,6 +78,63 @@ func BuildUpdateRequest(data *model.FloatingIPResourceModel) *floatingipmodels.F
 	return req
 }
 
+// AssociatedServerName returns the name of the server a floating IP is
+// associated with, or null when it is unassociated. The name reported with
+// the floating IP is used when present; otherwise the server is looked up.
+// A failed lookup is logged and yields null rather than failing the read.
+func AssociatedServerName(ctx context.Context, serversClient interface {
+	Get(ctx context.Context, serverID string) (*serversdk.ServerResource, error)
+}, fip *floatingipmodels.FloatingIP) types.String {
+	if fip.DeviceID == "" {
+		return types.StringNull()
+	}
+	if fip.DeviceName != "" {
+		return types.StringValue(fip.DeviceName)
+	}
+
+	serverRes, err := serversClient.Get(ctx, fip.DeviceID)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to resolve floating IP association target", map[string]interface{}{
+			"floating_ip_id": fip.ID,
+			"device_id":      fip.DeviceID,
+			"error":          err.Error(),
+		})
+		return types.StringNull()
+	}
+	return types.StringValue(serverRes.Server.Name)
+}
+
+// CheckFloatingIPAvailable returns an error when fip is associated with a
+// device other than serverID, which is empty for a server not created yet.
+// The API rejects such an association, so it is caught before the request.
+func CheckFloatingIPAvailable(fip *floatingipmodels.FloatingIP, serverID string) error {
+	if fip == nil || fip.DeviceID == "" || fip.DeviceID == serverID {
+		return nil
+	}
+
+	device := fip.DeviceID
+	if fip.DeviceName != "" {
+		device = fmt.Sprintf("%s (%s)", fip.DeviceName, fip.DeviceID)
+	}
+	return fmt.Errorf("floating IP %s (%s) is already associated with %s. Remove it from that server's network_attachment and apply, or disassociate it, before associating it here", fip.ID, fip.Address, device)
+}
+
+// BandwidthNotAppliedWarning warns that a configured bandwidth_mbps has no
+// effect. The floating IP API does not support bandwidth caps yet, so the
+// value is kept in state for a stable plan but never sent.
+func BandwidthNotAppliedWarning(bandwidth types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if bandwidth.IsNull() || bandwidth.IsUnknown() {
+		return diags
+	}
+	diags.AddAttributeWarning(
+		path.Root("bandwidth_mbps"),
+		"Floating IP Bandwidth Not Supported",
+		fmt.Sprintf("The floating IP API does not support bandwidth limits, so bandwidth_mbps = %d was not applied. The value is recorded in state only.", bandwidth.ValueInt64()),
+	)
+	return diags
+}
+
 // FilterFloatingIPs applies client-side filtering to floating IP list.
 func FilterFloatingIPs(fips []*floatingipmodels.FloatingIP, filters *model.FloatingIPDataSourceModel) []*floatingipmodels.FloatingIP {
 	var filtered []*floatingipmodels.FloatingIP
@@ -122,3 +185,145 @@ func matchesFilters(fip *floatingipmodels.FloatingIP, filters *model.FloatingIPD
 
 	return true
 }
+
+// RefreshNICFloatingIPs confirms each floating IP that the NIC listing
+// reported on a network attachment by reading it from the floating IP API,
+// which reflects a disassociation made outside Terraform before the NIC
+// listing does. A floating IP that is gone or bound to another device is
+// cleared so the next plan shows the drift. A failed read keeps the NIC
+// listing's value.
+func RefreshNICFloatingIPs(ctx context.Context, floatingIPClient interface {
+	Get(context.Context, string) (*floatingipmodels.FloatingIP, error)
+}, serverID string, state *model.ServerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if state.NetworkAttachment.IsNull() || state.NetworkAttachment.IsUnknown() {
+		return diags
+	}
+
+	var attachments []model.NetworkAttachmentModel
+	diags.Append(state.NetworkAttachment.ElementsAs(ctx, &attachments, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	changed := false
+	values := make([]attr.Value, 0, len(attachments))
+	for _, att := range attachments {
+		if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
+			fip, err := floatingIPClient.Get(ctx, att.FloatingIPID.ValueString())
+			switch {
+			case err != nil && !IsNotFound(err):
+				tflog.Warn(ctx, "Unable to confirm floating IP association", map[string]interface{}{
+					"floating_ip_id": att.FloatingIPID.ValueString(),
+					"error":          err.Error(),
+				})
+			case err != nil || fip.DeviceID != serverID:
+				tflog.Info(ctx, "Floating IP no longer associated with server", map[string]interface{}{
+					"network_id":     att.NetworkID.ValueString(),
+					"floating_ip_id": att.FloatingIPID.ValueString(),
+				})
+				att.FloatingIPID = types.StringNull()
+				att.FloatingIP = types.StringNull()
+				changed = true
+			}
+		}
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+	if diags.HasError() || !changed {
+		return diags
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, values)
+	diags.Append(d...)
+	if !d.HasError() {
+		state.NetworkAttachment = list
+	}
+	return diags
+}
+
+// FillNICFloatingIPs sets floating_ip_id and floating_ip on network
+// attachments whose NIC holds a floating IP that the NIC listing did not
+// report. The project's floating IPs are only listed when one of the server's
+// public addresses is missing from the attachments, and are matched to NICs
+// through their port ID. A failed listing is reported as a warning.
+func FillNICFloatingIPs(ctx context.Context, floatingIPClient interface {
+	List(ctx context.Context, opts *floatingipmodels.ListFloatingIPsOptions) ([]*floatingipmodels.FloatingIP, error)
+}, serverID string, publicIPs []string, state *model.ServerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(publicIPs) == 0 || state.NetworkAttachment.IsNull() || state.NetworkAttachment.IsUnknown() {
+		return diags
+	}
+
+	var attachments []model.NetworkAttachmentModel
+	diags.Append(state.NetworkAttachment.ElementsAs(ctx, &attachments, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	reported := make(map[string]bool, len(attachments))
+	for _, att := range attachments {
+		reported[att.FloatingIP.ValueString()] = true
+	}
+	missing := false
+	for _, ip := range publicIPs {
+		if !reported[ip] {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return diags
+	}
+
+	fips, err := floatingIPClient.List(ctx, &floatingipmodels.ListFloatingIPsOptions{DeviceID: serverID})
+	if err != nil {
+		diags.AddWarning(
+			"Floating IPs Not Fully Resolved",
+			fmt.Sprintf("Server %s reports public addresses that its NICs do not, and listing floating IPs failed: %s. "+
+				"Some network_attachment floating_ip_id values may be empty until the next refresh.", serverID, err.Error()),
+		)
+		return diags
+	}
+
+	byPort := make(map[string]*floatingipmodels.FloatingIP, len(fips))
+	for _, fip := range fips {
+		if fip.PortID != "" && fip.DeviceID == serverID {
+			byPort[fip.PortID] = fip
+		}
+	}
+
+	nicIDs := make(map[string]string, len(attachments))
+	diags.Append(state.NICIDs.ElementsAs(ctx, &nicIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	values := make([]attr.Value, 0, len(attachments))
+	for _, att := range attachments {
+		if att.FloatingIPID.IsNull() {
+			if fip, ok := byPort[nicIDs[att.NetworkID.ValueString()]]; ok {
+				tflog.Debug(ctx, "Floating IP resolved from port", map[string]interface{}{
+					"network_id":     att.NetworkID.ValueString(),
+					"floating_ip_id": fip.ID,
+				})
+				att.FloatingIPID = types.StringValue(fip.ID)
+				att.FloatingIP = types.StringValue(fip.Address)
+			}
+		}
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, values)
+	diags.Append(d...)
+	if !d.HasError() {
+		state.NetworkAttachment = list
+	}
+	return diags
+}
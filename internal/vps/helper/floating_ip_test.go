@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	floatingipmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type fakeServerGetter map[string]string
+
+func (f fakeServerGetter) Get(ctx context.Context, serverID string) (*serversdk.ServerResource, error) {
+	name, ok := f[serverID]
+	if !ok {
+		return nil, errors.New("404 not found")
+	}
+	return &serversdk.ServerResource{Server: &servermodels.Server{ID: serverID, Name: name}}, nil
+}
+
+func TestAssociatedServerName(t *testing.T) {
+	t.Parallel()
+
+	servers := fakeServerGetter{"srv-1": "web"}
+
+	tests := []struct {
+		name     string
+		fip      floatingipmodels.FloatingIP
+		expected types.String
+	}{
+		{
+			name:     "unassociated",
+			fip:      floatingipmodels.FloatingIP{ID: "fip-1"},
+			expected: types.StringNull(),
+		},
+		{
+			name:     "device name reported",
+			fip:      floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-2", DeviceName: "db"},
+			expected: types.StringValue("db"),
+		},
+		{
+			name:     "resolved from server",
+			fip:      floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-1"},
+			expected: types.StringValue("web"),
+		},
+		{
+			name:     "server lookup fails",
+			fip:      floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-gone"},
+			expected: types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := AssociatedServerName(context.Background(), servers, &tt.fip); !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+type fakeFloatingIPLister struct {
+	fips  []*floatingipmodels.FloatingIP
+	err   error
+	calls int
+}
+
+func (f *fakeFloatingIPLister) List(ctx context.Context, opts *floatingipmodels.ListFloatingIPsOptions) ([]*floatingipmodels.FloatingIP, error) {
+	f.calls++
+	return f.fips, f.err
+}
+
+func TestFillNICFloatingIPs(t *testing.T) {
+	t.Parallel()
+
+	attachment := func(networkID, fipID, address string) attr.Value {
+		fipIDValue, addressValue := types.StringNull(), types.StringNull()
+		if fipID != "" {
+			fipIDValue, addressValue = types.StringValue(fipID), types.StringValue(address)
+		}
+		obj, _ := networkAttachmentObject(resourcemodels.NetworkAttachmentModel{
+			NetworkID:          types.StringValue(networkID),
+			IPAddress:          types.StringNull(),
+			Primary:            types.BoolValue(networkID == "net-a"),
+			SecurityGroupIDs:   types.ListNull(types.StringType),
+			SecurityGroupNames: types.ListNull(types.StringType),
+			FloatingIPID:       fipIDValue,
+			FloatingIP:         addressValue,
+		})
+		return obj
+	}
+	nicIDs := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"net-a": types.StringValue("nic-a"),
+		"net-b": types.StringValue("nic-b"),
+	})
+	portFIP := &floatingipmodels.FloatingIP{ID: "fip-b", Address: "203.0.113.20", PortID: "nic-b", DeviceID: "srv-1"}
+
+	tests := []struct {
+		name          string
+		attachments   []attr.Value
+		publicIPs     []string
+		lister        *fakeFloatingIPLister
+		expected      []attr.Value
+		expectCalls   int
+		expectWarning bool
+	}{
+		{
+			name:        "all reported",
+			attachments: []attr.Value{attachment("net-a", "fip-a", "203.0.113.10"), attachment("net-b", "", "")},
+			publicIPs:   []string{"203.0.113.10"},
+			lister:      &fakeFloatingIPLister{},
+			expected:    []attr.Value{attachment("net-a", "fip-a", "203.0.113.10"), attachment("net-b", "", "")},
+		},
+		{
+			name:        "second NIC filled from port",
+			attachments: []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+			publicIPs:   []string{"203.0.113.20"},
+			lister:      &fakeFloatingIPLister{fips: []*floatingipmodels.FloatingIP{portFIP}},
+			expected:    []attr.Value{attachment("net-a", "", ""), attachment("net-b", "fip-b", "203.0.113.20")},
+			expectCalls: 1,
+		},
+		{
+			name:          "listing fails",
+			attachments:   []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+			publicIPs:     []string{"203.0.113.20"},
+			lister:        &fakeFloatingIPLister{err: errors.New("503 service unavailable")},
+			expected:      []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+			expectCalls:   1,
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			listType := types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}
+			state := resourcemodels.ServerResourceModel{
+				NetworkAttachment: types.ListValueMust(listType, tt.attachments),
+				NICIDs:            nicIDs,
+			}
+
+			diags := FillNICFloatingIPs(context.Background(), tt.lister, "srv-1", tt.publicIPs, &state)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.expectWarning {
+				t.Errorf("expected warning %t, got %v", tt.expectWarning, diags)
+			}
+			if tt.lister.calls != tt.expectCalls {
+				t.Errorf("expected %d list calls, got %d", tt.expectCalls, tt.lister.calls)
+			}
+			if expected := types.ListValueMust(listType, tt.expected); !state.NetworkAttachment.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, state.NetworkAttachment)
+			}
+		})
+	}
+}
+
+type fakeFloatingIPReader map[string]string
+
+// Get returns the floating IP bound to the device in f. A device of "error"
+// fails the read; a missing ID is not found.
+func (f fakeFloatingIPReader) Get(ctx context.Context, id string) (*floatingipmodels.FloatingIP, error) {
+	deviceID, ok := f[id]
+	switch {
+	case !ok:
+		return nil, errors.New("404 not found")
+	case deviceID == "error":
+		return nil, errors.New("503 service unavailable")
+	}
+	return &floatingipmodels.FloatingIP{ID: id, DeviceID: deviceID}, nil
+}
+
+func TestRefreshNICFloatingIPs(t *testing.T) {
+	t.Parallel()
+
+	attachment := func(networkID, fipID, address string) attr.Value {
+		fipIDValue, addressValue := types.StringNull(), types.StringNull()
+		if fipID != "" {
+			fipIDValue, addressValue = types.StringValue(fipID), types.StringValue(address)
+		}
+		obj, _ := networkAttachmentObject(resourcemodels.NetworkAttachmentModel{
+			NetworkID:          types.StringValue(networkID),
+			IPAddress:          types.StringNull(),
+			Primary:            types.BoolValue(networkID == "net-a"),
+			SecurityGroupIDs:   types.ListNull(types.StringType),
+			SecurityGroupNames: types.ListNull(types.StringType),
+			FloatingIPID:       fipIDValue,
+			FloatingIP:         addressValue,
+		})
+		return obj
+	}
+
+	tests := []struct {
+		name     string
+		fips     fakeFloatingIPReader
+		expected []attr.Value
+	}{
+		{
+			name:     "still associated",
+			fips:     fakeFloatingIPReader{"fip-a": "srv-1"},
+			expected: []attr.Value{attachment("net-a", "fip-a", "203.0.113.10"), attachment("net-b", "", "")},
+		},
+		{
+			name:     "disassociated out of band",
+			fips:     fakeFloatingIPReader{"fip-a": ""},
+			expected: []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+		},
+		{
+			name:     "moved to another server",
+			fips:     fakeFloatingIPReader{"fip-a": "srv-2"},
+			expected: []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+		},
+		{
+			name:     "deleted",
+			fips:     fakeFloatingIPReader{},
+			expected: []attr.Value{attachment("net-a", "", ""), attachment("net-b", "", "")},
+		},
+		{
+			name:     "read fails",
+			fips:     fakeFloatingIPReader{"fip-a": "error"},
+			expected: []attr.Value{attachment("net-a", "fip-a", "203.0.113.10"), attachment("net-b", "", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			listType := types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}
+			state := resourcemodels.ServerResourceModel{
+				NetworkAttachment: types.ListValueMust(listType, []attr.Value{attachment("net-a", "fip-a", "203.0.113.10"), attachment("net-b", "", "")}),
+			}
+
+			if diags := RefreshNICFloatingIPs(context.Background(), tt.fips, "srv-1", &state); diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if expected := types.ListValueMust(listType, tt.expected); !state.NetworkAttachment.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, state.NetworkAttachment)
+			}
+		})
+	}
+}
+
+func TestCheckFloatingIPAvailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		fip         *floatingipmodels.FloatingIP
+		serverID    string
+		expectError bool
+	}{
+		{name: "unassociated", fip: &floatingipmodels.FloatingIP{ID: "fip-1"}, serverID: "srv-1"},
+		{name: "associated with the server", fip: &floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-1"}, serverID: "srv-1"},
+		{name: "associated with another server", fip: &floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-2"}, serverID: "srv-1", expectError: true},
+		{name: "server not created yet", fip: &floatingipmodels.FloatingIP{ID: "fip-1", DeviceID: "srv-2"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckFloatingIPAvailable(tt.fip, tt.serverID)
+			if got := err != nil; got != tt.expectError {
+				t.Errorf("expected error %t, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
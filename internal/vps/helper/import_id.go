@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// compositeIDSeparator joins the parts of a composite import ID, e.g.
+// "server_id/network_id/sg_id".
+const compositeIDSeparator = "/"
+
+// ParseCompositeID splits a composite import ID into exactly n non-empty
+// parts. Resources whose ID combines several parent IDs use it in
+// ImportState so that a malformed ID fails with the expected format rather
+// than with a not-found error from the API.
+func ParseCompositeID(id string, n int) ([]string, error) {
+	parts := strings.Split(id, compositeIDSeparator)
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected an ID with %d parts separated by %q, got %d part(s) in %q", n, compositeIDSeparator, len(parts), id)
+	}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("part %d of ID %q is empty", i+1, id)
+		}
+	}
+	return parts, nil
+}
+
+// SplitProjectImportID splits an import ID that may be prefixed with the
+// project it belongs to, "<project>/<id>". The project is empty when the ID
+// has no prefix.
+func SplitProjectImportID(id string) (project, resourceID string, err error) {
+	if !strings.Contains(id, compositeIDSeparator) {
+		return "", id, nil
+	}
+	parts, err := ParseCompositeID(id, 2)
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCompositeID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		id          string
+		parts       int
+		expected    []string
+		expectError bool
+	}{
+		{name: "correct", id: "srv-1/net-1/sg-1", parts: 3, expected: []string{"srv-1", "net-1", "sg-1"}},
+		{name: "too few parts", id: "srv-1/net-1", parts: 3, expectError: true},
+		{name: "too many parts", id: "srv-1/net-1/sg-1/extra", parts: 3, expectError: true},
+		{name: "empty part", id: "srv-1//sg-1", parts: 3, expectError: true},
+		{name: "plain id", id: "srv-1", parts: 2, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parts, err := ParseCompositeID(tt.id, tt.parts)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", parts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(parts, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, parts)
+			}
+		})
+	}
+}
+
+func TestSplitProjectImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		id              string
+		expectedProject string
+		expectedID      string
+		expectError     bool
+	}{
+		{name: "plain id", id: "sg-1", expectedID: "sg-1"},
+		{name: "project prefix", id: "proj-a/sg-1", expectedProject: "proj-a", expectedID: "sg-1"},
+		{name: "empty project", id: "/sg-1", expectError: true},
+		{name: "too many parts", id: "proj-a/sg-1/extra", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, id, err := SplitProjectImportID(tt.id)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %q and %q", project, id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project != tt.expectedProject || id != tt.expectedID {
+				t.Errorf("expected %q and %q, got %q and %q", tt.expectedProject, tt.expectedID, project, id)
+			}
+		})
+	}
+}
@@ -4,16 +4,49 @@
 package helper
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
 )
 
+// NormalizePublicKey returns an OpenSSH public key in canonical form: key
+// type, base64 key body and optional comment separated by single spaces, with
+// surrounding whitespace and newlines removed. Keys that do not parse are only
+// trimmed, leaving the API to reject them.
+func NormalizePublicKey(key string) string {
+	trimmed := strings.TrimSpace(key)
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(trimmed))
+	if err != nil {
+		return trimmed
+	}
+
+	canonical := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub)))
+	if comment != "" {
+		canonical += " " + comment
+	}
+	return canonical
+}
+
+// PublicKeysEquivalent reports whether a and b hold the same key material,
+// ignoring comments and whitespace. Unparseable keys are compared after
+// trimming.
+func PublicKeysEquivalent(a, b string) bool {
+	pubA, _, _, _, errA := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(a)))
+	pubB, _, _, _, errB := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(b)))
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return bytes.Equal(pubA.Marshal(), pubB.Marshal())
+}
+
 // T038: KeypairToModel() conversion helper.
 func KeypairToModel(kp keypairsmodels.Keypair) model.KeypairModel {
 	model := model.KeypairModel{
@@ -58,9 +91,59 @@ func ListKeypairsWithSDK(ctx context.Context, projectClient *cloudsdk.ProjectCli
 		if !filters.Name.IsNull() && kp.Name != filters.Name.ValueString() {
 			continue
 		}
+		if filters.Fingerprint.ValueString() != "" && !KeypairMatchesFingerprint(*kp, filters.Fingerprint.ValueString()) {
+			continue
+		}
 
 		results = append(results, KeypairToModel(*kp))
 	}
 
 	return results, nil
 }
+
+// KeypairMatchesFingerprint reports whether fingerprint identifies the
+// keypair's public key. It accepts the fingerprint reported by the API as well
+// as the SHA256 ("SHA256:...") and MD5 ("aa:bb:...", optionally prefixed with
+// "MD5:") forms printed by ssh-keygen, so a key matches whichever form the
+// platform stores.
+func KeypairMatchesFingerprint(kp keypairsmodels.Keypair, fingerprint string) bool {
+	fingerprint = strings.TrimSpace(fingerprint)
+	if fingerprint == "" {
+		return false
+	}
+	if strings.HasPrefix(strings.ToUpper(fingerprint), "MD5:") {
+		fingerprint = fingerprint[len("MD5:"):]
+	}
+
+	candidates := []string{kp.Fingerprint}
+	if pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(kp.PublicKey))); err == nil {
+		candidates = append(candidates, ssh.FingerprintSHA256(pub), ssh.FingerprintLegacyMD5(pub))
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if strings.HasPrefix(candidate, "SHA256:") {
+			// Base64 is case-sensitive.
+			if candidate == fingerprint {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(strings.TrimPrefix(candidate, "MD5:"), fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicKeyStateValue returns the public key to store after create or update.
+// Terraform requires the configured value back when it is equivalent to what
+// the API stored; the API's canonical form is picked up on the next refresh.
+func PublicKeyStateValue(planned types.String, apiKey string) types.String {
+	if !planned.IsNull() && !planned.IsUnknown() && PublicKeysEquivalent(planned.ValueString(), apiKey) {
+		return planned
+	}
+	return types.StringValue(apiKey)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+)
+
+const testPublicKeyBody = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+func TestNormalizePublicKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{
+			name:     "canonical",
+			key:      testPublicKeyBody + " test@example.com",
+			expected: testPublicKeyBody + " test@example.com",
+		},
+		{
+			name:     "trailing newline",
+			key:      testPublicKeyBody + " test@example.com\n",
+			expected: testPublicKeyBody + " test@example.com",
+		},
+		{
+			name:     "extra whitespace",
+			key:      "  ssh-ed25519   AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl \t test@example.com \r\n",
+			expected: testPublicKeyBody + " test@example.com",
+		},
+		{
+			name:     "no comment",
+			key:      testPublicKeyBody + "\n",
+			expected: testPublicKeyBody,
+		},
+		{
+			name:     "invalid key is only trimmed",
+			key:      " this-is-not-a-valid-ssh-key \n",
+			expected: "this-is-not-a-valid-ssh-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NormalizePublicKey(tt.key); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPublicKeysEquivalent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "comment and newline ignored",
+			a:        testPublicKeyBody + " test@example.com\n",
+			b:        testPublicKeyBody,
+			expected: true,
+		},
+		{
+			name:     "different key",
+			a:        testPublicKeyBody,
+			b:        "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBvYqSq6wTxEtyyTQ5ZKUAXsPB1bX6O4r1fy8rSaNpbF",
+			expected: false,
+		},
+		{
+			name:     "invalid keys compared trimmed",
+			a:        "not-a-key\n",
+			b:        "not-a-key",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := PublicKeysEquivalent(tt.a, tt.b); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestKeypairMatchesFingerprint(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sha256Fingerprint = "SHA256:+DiY3wvvV6TuJJhbpZisF/zLDA0zPMSvHdkr4UvCOqU"
+		md5Fingerprint    = "65:96:2d:fc:e8:d5:a9:11:64:0c:0f:ea:00:6e:5b:bd"
+	)
+	kp := keypairsmodels.Keypair{PublicKey: testPublicKeyBody + " test@example.com", Fingerprint: md5Fingerprint}
+
+	tests := []struct {
+		name        string
+		keypair     keypairsmodels.Keypair
+		fingerprint string
+		expected    bool
+	}{
+		{name: "API fingerprint", keypair: kp, fingerprint: md5Fingerprint, expected: true},
+		{name: "MD5 upper case with prefix", keypair: kp, fingerprint: "MD5:" + strings.ToUpper(md5Fingerprint), expected: true},
+		{name: "SHA256 computed from key", keypair: kp, fingerprint: sha256Fingerprint, expected: true},
+		{name: "SHA256 is case-sensitive", keypair: kp, fingerprint: strings.ToLower(sha256Fingerprint)},
+		{name: "other key", keypair: kp, fingerprint: "SHA256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"},
+		{name: "unparseable key uses API fingerprint", keypair: keypairsmodels.Keypair{PublicKey: "not-a-key", Fingerprint: "SHA256:abc"}, fingerprint: " SHA256:abc ", expected: true},
+		{name: "empty", keypair: kp, fingerprint: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := KeypairMatchesFingerprint(tt.keypair, tt.fingerprint); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
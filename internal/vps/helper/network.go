@@ -6,10 +6,12 @@ package helper
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"sort"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	networksmodels "github.com/Zillaforge/cloud-sdk/models/vps/networks"
+	networksdk "github.com/Zillaforge/cloud-sdk/modules/vps/networks"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -53,9 +55,259 @@ func ListNetworksWithSDK(ctx context.Context, projectClient *cloudsdk.ProjectCli
 	return results, nil
 }
 
+// FindNetworksByName returns every network whose name is exactly name,
+// sorted by ID. The API filter matches substrings, so it is re-checked here.
+func FindNetworksByName(ctx context.Context, projectClient *cloudsdk.ProjectClient, name string) ([]*networksmodels.Network, error) {
+	if projectClient == nil {
+		return nil, fmt.Errorf("no project client available")
+	}
+	networkList, err := projectClient.VPS().Networks().List(ctx, &networksmodels.ListNetworksOptions{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("sdk Network List() error: %w", err)
+	}
+
+	matches := []*networksmodels.Network{}
+	for _, nr := range networkList {
+		if nr.Network.Name == name {
+			matches = append(matches, nr.Network)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].ID < matches[j].ID
+	})
+	return matches, nil
+}
+
+// NetworkLookupFromNetwork maps a network to the zillaforge_network data
+// source model. Every VPS subnet serves DHCP; the API has no toggle for it.
+func NetworkLookupFromNetwork(network *networksmodels.Network) model.NetworkLookupModel {
+	return model.NetworkLookupModel{
+		ID:          types.StringValue(network.ID),
+		Name:        types.StringValue(network.Name),
+		Description: types.StringValue(network.Description),
+		Status:      types.StringValue(network.Status),
+		CIDR:        types.StringValue(network.CIDR),
+		GatewayIP:   types.StringValue(network.Gateway),
+		EnableDHCP:  types.BoolValue(true),
+		SubnetID:    types.StringValue(network.SubnetID),
+	}
+}
+
 // sortNetworksDeterministic sorts networks by id asc (deterministic).
 func sortNetworksDeterministic(results []model.NetworkModel) {
 	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].ID.ValueString() < results[j].ID.ValueString()
 	})
 }
+
+// IPRange is an inclusive range of addresses within a subnet.
+type IPRange struct {
+	Start netip.Addr
+	End   netip.Addr
+}
+
+// Contains reports whether addr falls within the range.
+func (r IPRange) Contains(addr netip.Addr) bool {
+	return r.Start.Compare(addr) <= 0 && addr.Compare(r.End) <= 0
+}
+
+// AllocationPools returns the address ranges the platform hands out for
+// fixed and DHCP-assigned IPs in a subnet. The API does not report pools
+// explicitly, so they are derived the same way the network service
+// allocates them: every host address in the CIDR except the gateway (and,
+// for IPv4, the network and broadcast addresses).
+func AllocationPools(cidr, gateway string) ([]IPRange, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	first := prefix.Addr()
+	last := lastAddr(prefix)
+	if first.Is4() && prefix.Bits() < 31 {
+		first = first.Next()
+		last = last.Prev()
+	} else if first.Is6() && prefix.Bits() < 128 {
+		// The first IPv6 address is the subnet-router anycast address
+		first = first.Next()
+	}
+	if last.Less(first) {
+		return []IPRange{}, nil
+	}
+
+	gw, err := netip.ParseAddr(gateway)
+	if gateway == "" || err != nil || !(IPRange{Start: first, End: last}).Contains(gw) {
+		return []IPRange{{Start: first, End: last}}, nil
+	}
+
+	pools := []IPRange{}
+	if first.Less(gw) {
+		pools = append(pools, IPRange{Start: first, End: gw.Prev()})
+	}
+	if gw.Less(last) {
+		pools = append(pools, IPRange{Start: gw.Next(), End: last})
+	}
+	return pools, nil
+}
+
+// lastAddr returns the highest address in prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// FixedIPCandidates returns addresses at the given offsets from the start of
+// a subnet's allocation pool, skipping any that fall outside the pools (for
+// example the gateway). They are used as fallbacks when the platform rejects
+// automatic allocation on a NIC add.
+func FixedIPCandidates(cidr, gateway string, offsets []int) []string {
+	pools, err := AllocationPools(cidr, gateway)
+	if err != nil || len(pools) == 0 {
+		return nil
+	}
+
+	candidates := []string{}
+	for _, off := range offsets {
+		addr := pools[0].Start
+		for i := 0; i < off && addr.IsValid(); i++ {
+			addr = addr.Next()
+		}
+		for _, pool := range pools {
+			if addr.IsValid() && pool.Contains(addr) {
+				candidates = append(candidates, addr.String())
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// FirstFreeIP returns the lowest address of a subnet's allocation pools that
+// is not in use.
+func FirstFreeIP(cidr, gateway string, used []string) (string, error) {
+	pools, err := AllocationPools(cidr, gateway)
+	if err != nil {
+		return "", err
+	}
+
+	taken := make(map[netip.Addr]struct{}, len(used))
+	for _, ip := range used {
+		if addr, err := netip.ParseAddr(ip); err == nil {
+			taken[addr] = struct{}{}
+		}
+	}
+
+	for _, pool := range pools {
+		for addr := pool.Start; addr.IsValid() && pool.Contains(addr); addr = addr.Next() {
+			if _, ok := taken[addr]; !ok {
+				return addr.String(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no free address left in %s", cidr)
+}
+
+// FirstFreeNetworkIP returns the first free address of a network, counting
+// the addresses of every port on it as used. Another allocation can still
+// take the address before it is requested.
+func FirstFreeNetworkIP(ctx context.Context, networksClient *networksdk.Client, networkID string) (string, error) {
+	netRes, err := networksClient.Get(ctx, networkID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read network %s: %w", networkID, err)
+	}
+
+	ports, err := netRes.Ports().List(ctx)
+	if err != nil {
+		return "", err
+	}
+	used := []string{}
+	for _, port := range ports {
+		used = append(used, port.Addresses...)
+	}
+	return FirstFreeIP(netRes.Network.CIDR, netRes.Network.Gateway, used)
+}
+
+// CheckFixedIPInSubnet returns an error describing why ip cannot be
+// requested as a fixed address in the subnet with the given CIDR and
+// gateway: it is not an IP, lies outside the CIDR, or is one of the reserved
+// addresses outside the allocation pools.
+func CheckFixedIPInSubnet(ip, cidr, gateway string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid IP address", ip)
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	if !prefix.Masked().Contains(addr) {
+		return fmt.Errorf("%s is outside the network CIDR %s", ip, cidr)
+	}
+	if addr.String() == gateway {
+		return fmt.Errorf("%s is the gateway address of %s", ip, cidr)
+	}
+
+	pools, err := AllocationPools(cidr, gateway)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if pool.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is a reserved address of %s", ip, cidr)
+}
+
+// GetSubnetsWithSDK returns the subnets of a network. Each network carries
+// exactly one subnet on this platform, described by the network itself.
+func GetSubnetsWithSDK(ctx context.Context, projectClient *cloudsdk.ProjectClient, networkID string) ([]model.SubnetModel, error) {
+	if projectClient == nil {
+		return nil, fmt.Errorf("no project client available")
+	}
+	networkRes, err := projectClient.VPS().Networks().Get(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("sdk Network Get() error: %w", err)
+	}
+
+	subnet, err := SubnetFromNetwork(networkRes.Network)
+	if err != nil {
+		return nil, err
+	}
+	return []model.SubnetModel{subnet}, nil
+}
+
+// SubnetFromNetwork maps the subnet details embedded in a network to the
+// data source model.
+func SubnetFromNetwork(network *networksmodels.Network) (model.SubnetModel, error) {
+	pools, err := AllocationPools(network.CIDR, network.Gateway)
+	if err != nil {
+		return model.SubnetModel{}, fmt.Errorf("network %s: %w", network.ID, err)
+	}
+
+	poolModels := make([]model.AllocationPoolModel, 0, len(pools))
+	for _, pool := range pools {
+		poolModels = append(poolModels, model.AllocationPoolModel{
+			Start: types.StringValue(pool.Start.String()),
+			End:   types.StringValue(pool.End.String()),
+		})
+	}
+
+	nameservers := make([]types.String, 0, len(network.Nameservers))
+	for _, ns := range network.Nameservers {
+		nameservers = append(nameservers, types.StringValue(ns))
+	}
+
+	return model.SubnetModel{
+		ID:              types.StringValue(network.SubnetID),
+		CIDR:            types.StringValue(network.CIDR),
+		GatewayIP:       types.StringValue(network.Gateway),
+		AllocationPools: poolModels,
+		DNSNameservers:  nameservers,
+	}, nil
+}
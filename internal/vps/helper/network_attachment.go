@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NetworkAttachmentAttrTypes is the object type of a network_attachment block.
+var NetworkAttachmentAttrTypes = map[string]attr.Type{
+	"network_id":         types.StringType,
+	"ip_address":         types.StringType,
+	"primary":            types.BoolType,
+	"security_group_ids": types.ListType{ElemType: types.StringType},
+	"floating_ip_id":     types.StringType,
+	"floating_ip":        types.StringType,
+	"public_ip":          types.StringType,
+
+	"security_group_names": types.ListType{ElemType: types.StringType},
+	"dns_nameservers":      types.ListType{ElemType: types.StringType},
+	"host_routes":          types.ListType{ElemType: types.ObjectType{AttrTypes: HostRouteAttrTypes}},
+
+	"fixed_ip_strategy": types.StringType,
+}
+
+// HostRouteAttrTypes is the object type of a network_attachment host route.
+var HostRouteAttrTypes = map[string]attr.Type{
+	"destination": types.StringType,
+	"nexthop":     types.StringType,
+}
+
+// Values of network_attachment.fixed_ip_strategy. A null strategy is auto.
+const (
+	// FixedIPStrategyAuto lets the platform pick the address, or uses
+	// ip_address when set. If the platform rejects the allocation while a
+	// NIC is added on update, a few addresses at fixed offsets into the
+	// allocation pool are tried instead.
+	FixedIPStrategyAuto = "auto"
+
+	// FixedIPStrategyFirstFree requests the lowest address of the
+	// allocation pool that no port on the network uses.
+	FixedIPStrategyFirstFree = "first_free"
+
+	// FixedIPStrategySpecific requests ip_address and never falls back to
+	// another address.
+	FixedIPStrategySpecific = "specific"
+)
+
+// AttachmentOrderSource tells OrderNetworkAttachments where the preferred
+// ordering comes from, which decides how much of it is authoritative.
+type AttachmentOrderSource int
+
+const (
+	// OrderFromPlan is used after create and update. Terraform requires the
+	// new state to match the plan, so the planned primary flag,
+	// floating_ip_id and security group list are kept as configured.
+	OrderFromPlan AttachmentOrderSource = iota
+
+	// OrderFromState is used on refresh. Only the prior ordering and primary
+	// flag are kept; every other value comes from the API so out-of-band
+	// changes show as drift.
+	OrderFromState
+)
+
+// OrderNetworkAttachments returns the server's network attachments in the
+// order of planOrder, so list positions stay stable across plan, apply and
+// refresh even though the API returns NICs sorted by network. apiNics are
+// the attachments as mapped by MapServerToState.
+//
+// Attachments are matched by network_id. The IP address and floating IP
+// address come from the API. NICs missing from planOrder are appended sorted
+// by network_id, with their security groups sorted.
+func OrderNetworkAttachments(ctx context.Context, planOrder, apiNics []resourcemodels.NetworkAttachmentModel, source AttachmentOrderSource) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	nicMap := make(map[string]resourcemodels.NetworkAttachmentModel, len(apiNics))
+	for _, nic := range apiNics {
+		nicMap[nic.NetworkID.ValueString()] = nic
+	}
+
+	ordered := make([]attr.Value, 0, len(apiNics))
+	for _, p := range planOrder {
+		nid := p.NetworkID.ValueString()
+		nic, found := nicMap[nid]
+		if !found {
+			tflog.Warn(ctx, "NIC not found for network attachment", map[string]interface{}{"network_id": nid})
+			if source == OrderFromState {
+				// Detached out-of-band; drop it so the next plan re-adds it.
+				continue
+			}
+		}
+		delete(nicMap, nid)
+
+		apiSGs, d := SecurityGroupIDsFromList(ctx, nic.SecurityGroupIDs)
+		diags.Append(d...)
+		priorSGs, d := SecurityGroupIDsFromList(ctx, p.SecurityGroupIDs)
+		diags.Append(d...)
+
+		var sgIDs []string
+		if source == OrderFromPlan {
+			sgIDs = planSecurityGroupIDs(priorSGs, apiSGs)
+			if p.SecurityGroupIDs.IsNull() && len(sgIDs) > 0 {
+				// The platform attached security groups although none were requested.
+				// State must match the plan here; the next refresh reports them as drift.
+				diags.AddWarning(
+					"Security Groups Assigned By Platform",
+					fmt.Sprintf("Network attachment %s has no security_group_ids in configuration, but the platform attached %d security group(s). "+
+						"Set security_group_ids explicitly to manage them; the next plan will show them as drift.", nid, len(sgIDs)),
+				)
+				sgIDs = nil
+			}
+		} else {
+			sgIDs = reconcileSecurityGroupIDs(priorSGs, apiSGs)
+		}
+		sgList, d := SecurityGroupIDsListValue(stringAttrValues(sgIDs), p.SecurityGroupIDs)
+		diags.Append(d...)
+
+		sgNames := p.SecurityGroupNames
+		if sgNames.ElementType(ctx) == nil {
+			// Zero value: the attachment was built in code, not decoded from config.
+			sgNames = types.ListNull(types.StringType)
+		}
+
+		att := resourcemodels.NetworkAttachmentModel{
+			NetworkID:        types.StringValue(nid),
+			IPAddress:        nic.IPAddress,
+			Primary:          types.BoolValue(nic.Primary.ValueBool()),
+			SecurityGroupIDs: sgList,
+			FloatingIPID:     nic.FloatingIPID,
+			FloatingIP:       nic.FloatingIP,
+
+			// The NIC API does not report these, so they always carry over.
+			SecurityGroupNames: sgNames,
+			DNSNameservers:     p.DNSNameservers,
+			HostRoutes:         p.HostRoutes,
+			FixedIPStrategy:    p.FixedIPStrategy,
+		}
+		if source == OrderFromPlan {
+			att.Primary = types.BoolValue(p.Primary.ValueBool())
+			att.FloatingIPID = p.FloatingIPID
+		} else if !p.Primary.IsNull() && !p.Primary.IsUnknown() {
+			// The API has no primary flag and MapServerToState only guesses
+			// one, so the prior flag is kept rather than reported as drift.
+			att.Primary = p.Primary
+		}
+
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		ordered = append(ordered, obj)
+	}
+
+	// Append any NICs not present in planOrder (sorted by NetworkID)
+	remaining := make([]resourcemodels.NetworkAttachmentModel, 0, len(nicMap))
+	for _, nic := range nicMap {
+		remaining = append(remaining, nic)
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].NetworkID.ValueString() < remaining[j].NetworkID.ValueString()
+	})
+	for _, nic := range remaining {
+		apiSGs, d := SecurityGroupIDsFromList(ctx, nic.SecurityGroupIDs)
+		diags.Append(d...)
+		sort.Strings(apiSGs)
+		sgList, d := SecurityGroupIDsListValue(stringAttrValues(apiSGs), types.ListNull(types.StringType))
+		diags.Append(d...)
+
+		att := resourcemodels.NetworkAttachmentModel{
+			NetworkID:        nic.NetworkID,
+			IPAddress:        nic.IPAddress,
+			Primary:          types.BoolValue(nic.Primary.ValueBool()),
+			SecurityGroupIDs: sgList,
+			FloatingIPID:     nic.FloatingIPID,
+			FloatingIP:       nic.FloatingIP,
+
+			SecurityGroupNames: types.ListNull(types.StringType),
+			FixedIPStrategy:    types.StringNull(),
+		}
+		if source == OrderFromPlan {
+			att.Primary = types.BoolValue(false)
+		}
+
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		ordered = append(ordered, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, ordered)
+	diags.Append(d...)
+	return list, diags
+}
+
+// WithNICSettings returns attachments with dns_nameservers, host_routes and
+// the other settings the NIC API does not expose taken from the planned
+// attachment on the same network, since the planned values are the only
+// source.
+func WithNICSettings(ctx context.Context, attachments types.List, planned []resourcemodels.NetworkAttachmentModel) (types.List, diag.Diagnostics) {
+	var current []resourcemodels.NetworkAttachmentModel
+	diags := attachments.ElementsAs(ctx, &current, false)
+	if diags.HasError() {
+		return attachments, diags
+	}
+
+	plannedByNetwork := make(map[string]resourcemodels.NetworkAttachmentModel, len(planned))
+	for _, p := range planned {
+		plannedByNetwork[p.NetworkID.ValueString()] = p
+	}
+
+	values := make([]attr.Value, 0, len(current))
+	for _, att := range current {
+		if p, ok := plannedByNetwork[att.NetworkID.ValueString()]; ok {
+			att.DNSNameservers = p.DNSNameservers
+			att.HostRoutes = p.HostRoutes
+			att.FixedIPStrategy = p.FixedIPStrategy
+		}
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// NICSettingsNotAppliedWarning warns about each planned attachment whose
+// dns_nameservers or host_routes is set and differs from prior. The NIC API has no fields for them yet, so they are
+// recorded in state but never sent.
+func NICSettingsNotAppliedWarning(planned, prior []resourcemodels.NetworkAttachmentModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	priorByNetwork := make(map[string]resourcemodels.NetworkAttachmentModel, len(prior))
+	for _, p := range prior {
+		priorByNetwork[p.NetworkID.ValueString()] = p
+	}
+
+	for i, att := range planned {
+		old, existed := priorByNetwork[att.NetworkID.ValueString()]
+		var settings []string
+		if !att.DNSNameservers.IsNull() && !att.DNSNameservers.IsUnknown() && (!existed || !att.DNSNameservers.Equal(old.DNSNameservers)) {
+			settings = append(settings, "dns_nameservers")
+		}
+		if !att.HostRoutes.IsNull() && !att.HostRoutes.IsUnknown() && (!existed || !att.HostRoutes.Equal(old.HostRoutes)) {
+			settings = append(settings, "host_routes")
+		}
+		if len(settings) == 0 {
+			continue
+		}
+
+		diags.AddAttributeWarning(
+			path.Root("network_attachment").AtListIndex(i),
+			"Network Interface Settings Not Supported",
+			fmt.Sprintf("The network interface API does not support %s yet, so it was not applied to the interface on network %s. The value is recorded in state only.",
+				strings.Join(settings, " or "), att.NetworkID.ValueString()),
+		)
+	}
+	return diags
+}
+
+// LockoutWarning warns when no attachment lists a security group in
+// security_group_ids or security_group_names and neither keypair nor password
+// is set: such a server usually has no ingress rule and no way to log in.
+// Unknown values count as set, so the warning only fires when the
+// configuration is certain.
+func LockoutWarning(attachments []resourcemodels.NetworkAttachmentModel, keypair, password types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !keypair.IsNull() || !password.IsNull() {
+		return diags
+	}
+	for _, att := range attachments {
+		for _, list := range []types.List{att.SecurityGroupIDs, att.SecurityGroupNames} {
+			if list.IsUnknown() || len(list.Elements()) > 0 {
+				return diags
+			}
+		}
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("network_attachment"),
+		"Server May Be Unreachable",
+		"No network_attachment lists a security group and neither keypair nor password is set, so the server may accept no inbound traffic and offer no way to log in. "+
+			"Attach a security group with an ingress rule (e.g. SSH from your address) and set keypair, or ignore this warning if access is provided another way, such as user_data.",
+	)
+	return diags
+}
+
+// SecurityGroupNamesFromAttachments returns the security_group_names of all
+// attachments, without duplicates, in the order they first appear.
+func SecurityGroupNamesFromAttachments(ctx context.Context, attachments []resourcemodels.NetworkAttachmentModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	seen := make(map[string]struct{})
+	var names []string
+	for _, att := range attachments {
+		attNames, d := SecurityGroupIDsFromList(ctx, att.SecurityGroupNames)
+		diags.Append(d...)
+		for _, name := range attNames {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+	return names, diags
+}
+
+// WithNamedSecurityGroups returns attachments with the IDs that
+// security_group_names resolve to (via nameIDs) appended to
+// security_group_ids, skipping IDs already listed. The result is what the
+// NIC API must be sent; it is never written to state.
+func WithNamedSecurityGroups(ctx context.Context, attachments types.List, nameIDs map[string]string) (types.List, diag.Diagnostics) {
+	if len(nameIDs) == 0 || attachments.IsNull() || attachments.IsUnknown() {
+		return attachments, nil
+	}
+
+	var current []resourcemodels.NetworkAttachmentModel
+	diags := attachments.ElementsAs(ctx, &current, false)
+	if diags.HasError() {
+		return attachments, diags
+	}
+
+	values := make([]attr.Value, 0, len(current))
+	for _, att := range current {
+		names, d := SecurityGroupIDsFromList(ctx, att.SecurityGroupNames)
+		diags.Append(d...)
+		if len(names) > 0 {
+			ids, d := SecurityGroupIDsFromList(ctx, att.SecurityGroupIDs)
+			diags.Append(d...)
+			for _, name := range names {
+				if id, ok := nameIDs[name]; ok && !containsString(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+			att.SecurityGroupIDs, d = types.ListValue(types.StringType, stringAttrValues(ids))
+			diags.Append(d...)
+		}
+
+		obj, d := networkAttachmentObject(att)
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// WithoutNamedSecurityGroups prepares planOrder and apiNics for
+// OrderNetworkAttachments when attachments use security_group_names. The
+// groups attached through a name are removed from the API's security group
+// IDs, so security_group_ids only holds the IDs that were configured as IDs.
+//
+// On refresh, a name whose group is no longer attached is also dropped from
+// security_group_names, so the next plan re-attaches it. Names that did not
+// resolve are kept as they are.
+func WithoutNamedSecurityGroups(ctx context.Context, planOrder, apiNics []resourcemodels.NetworkAttachmentModel, nameIDs map[string]string, source AttachmentOrderSource) ([]resourcemodels.NetworkAttachmentModel, []resourcemodels.NetworkAttachmentModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	nicIndex := make(map[string]int, len(apiNics))
+	nics := append([]resourcemodels.NetworkAttachmentModel(nil), apiNics...)
+	for i, nic := range nics {
+		nicIndex[nic.NetworkID.ValueString()] = i
+	}
+
+	order := append([]resourcemodels.NetworkAttachmentModel(nil), planOrder...)
+	for i, p := range order {
+		names, d := SecurityGroupIDsFromList(ctx, p.SecurityGroupNames)
+		diags.Append(d...)
+		idx, found := nicIndex[p.NetworkID.ValueString()]
+		if len(names) == 0 || !found {
+			continue
+		}
+
+		configured, d := SecurityGroupIDsFromList(ctx, p.SecurityGroupIDs)
+		diags.Append(d...)
+		attached, d := SecurityGroupIDsFromList(ctx, nics[idx].SecurityGroupIDs)
+		diags.Append(d...)
+
+		named := make(map[string]struct{}, len(names))
+		kept := make([]string, 0, len(names))
+		for _, name := range names {
+			id, resolved := nameIDs[name]
+			if !resolved {
+				kept = append(kept, name)
+				continue
+			}
+			if containsString(attached, id) {
+				kept = append(kept, name)
+			}
+			if !containsString(configured, id) {
+				named[id] = struct{}{}
+			}
+		}
+
+		remaining := make([]string, 0, len(attached))
+		for _, id := range attached {
+			if _, ok := named[id]; !ok {
+				remaining = append(remaining, id)
+			}
+		}
+		nics[idx].SecurityGroupIDs, d = types.ListValue(types.StringType, stringAttrValues(remaining))
+		diags.Append(d...)
+
+		if source == OrderFromState && len(kept) != len(names) {
+			order[i].SecurityGroupNames, d = types.ListValue(types.StringType, stringAttrValues(kept))
+			diags.Append(d...)
+		}
+	}
+
+	return order, nics, diags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// planSecurityGroupIDs keeps the planned security groups verbatim, falling
+// back to the API's groups (sorted) when none were planned.
+func planSecurityGroupIDs(planned, api []string) []string {
+	if len(planned) > 0 {
+		return planned
+	}
+	sorted := append([]string(nil), api...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// reconcileSecurityGroupIDs returns the API's security groups in the prior
+// order, followed by any groups the prior value did not have (sorted).
+func reconcileSecurityGroupIDs(prior, api []string) []string {
+	apiSet := make(map[string]struct{}, len(api))
+	for _, sg := range api {
+		apiSet[sg] = struct{}{}
+	}
+
+	result := make([]string, 0, len(api))
+	for _, sg := range prior {
+		if _, ok := apiSet[sg]; ok {
+			result = append(result, sg)
+			delete(apiSet, sg)
+		}
+	}
+
+	extra := make([]string, 0, len(apiSet))
+	for sg := range apiSet {
+		extra = append(extra, sg)
+	}
+	sort.Strings(extra)
+	return append(result, extra...)
+}
+
+func networkAttachmentObject(att resourcemodels.NetworkAttachmentModel) (types.Object, diag.Diagnostics) {
+	// Attachments built in code leave these lists as untyped zero values.
+	if att.DNSNameservers.IsNull() {
+		att.DNSNameservers = types.ListNull(types.StringType)
+	}
+	if att.HostRoutes.IsNull() {
+		att.HostRoutes = types.ListNull(types.ObjectType{AttrTypes: HostRouteAttrTypes})
+	}
+
+	return types.ObjectValue(NetworkAttachmentAttrTypes, map[string]attr.Value{
+		"network_id":         att.NetworkID,
+		"ip_address":         att.IPAddress,
+		"primary":            att.Primary,
+		"security_group_ids": att.SecurityGroupIDs,
+		"floating_ip_id":     att.FloatingIPID,
+		"floating_ip":        att.FloatingIP,
+		"public_ip":          att.FloatingIP, // always mirrors floating_ip
+
+		"security_group_names": att.SecurityGroupNames,
+		"dns_nameservers":      att.DNSNameservers,
+		"host_routes":          att.HostRoutes,
+
+		"fixed_ip_strategy": att.FixedIPStrategy,
+	})
+}
+
+func stringAttrValues(values []string) []attr.Value {
+	result := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		result = append(result, types.StringValue(v))
+	}
+	return result
+}
@@ -0,0 +1,597 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringList(t *testing.T, values ...string) types.List {
+	t.Helper()
+	if values == nil {
+		return types.ListNull(types.StringType)
+	}
+	list, diags := types.ListValue(types.StringType, stringAttrValues(values))
+	if diags.HasError() {
+		t.Fatalf("failed to build list: %v", diags)
+	}
+	return list
+}
+
+// apiNIC builds an attachment the way MapServerToState reports it.
+func apiNIC(t *testing.T, networkID, ip string, sgIDs ...string) resourcemodels.NetworkAttachmentModel {
+	t.Helper()
+	if sgIDs == nil {
+		sgIDs = []string{}
+	}
+	return resourcemodels.NetworkAttachmentModel{
+		NetworkID:        types.StringValue(networkID),
+		IPAddress:        types.StringValue(ip),
+		Primary:          types.BoolValue(false),
+		SecurityGroupIDs: stringList(t, sgIDs...),
+		FloatingIPID:     types.StringNull(),
+		FloatingIP:       types.StringNull(),
+	}
+}
+
+func planNIC(t *testing.T, networkID string, primary bool, sgIDs ...string) resourcemodels.NetworkAttachmentModel {
+	t.Helper()
+	return resourcemodels.NetworkAttachmentModel{
+		NetworkID:        types.StringValue(networkID),
+		IPAddress:        types.StringUnknown(),
+		Primary:          types.BoolValue(primary),
+		SecurityGroupIDs: stringList(t, sgIDs...),
+		FloatingIPID:     types.StringNull(),
+		FloatingIP:       types.StringUnknown(),
+	}
+}
+
+func orderedAttachments(t *testing.T, list types.List) []resourcemodels.NetworkAttachmentModel {
+	t.Helper()
+	var result []resourcemodels.NetworkAttachmentModel
+	if diags := list.ElementsAs(context.Background(), &result, false); diags.HasError() {
+		t.Fatalf("failed to read ordered attachments: %v", diags)
+	}
+	return result
+}
+
+func attachmentSGs(t *testing.T, att resourcemodels.NetworkAttachmentModel) []string {
+	t.Helper()
+	sgIDs, diags := SecurityGroupIDsFromList(context.Background(), att.SecurityGroupIDs)
+	if diags.HasError() {
+		t.Fatalf("failed to read security groups: %v", diags)
+	}
+	return sgIDs
+}
+
+func TestOrderNetworkAttachments_PlanOrderPreserved(t *testing.T) {
+	t.Parallel()
+
+	plan := []resourcemodels.NetworkAttachmentModel{
+		planNIC(t, "net-c", false),
+		planNIC(t, "net-a", true),
+		planNIC(t, "net-b", false),
+	}
+	api := []resourcemodels.NetworkAttachmentModel{
+		apiNIC(t, "net-a", "10.0.0.5"),
+		apiNIC(t, "net-b", "10.1.0.5"),
+		apiNIC(t, "net-c", "10.2.0.5"),
+	}
+
+	list, diags := OrderNetworkAttachments(context.Background(), plan, api, OrderFromPlan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got := orderedAttachments(t, list)
+	var networks []string
+	for _, att := range got {
+		networks = append(networks, att.NetworkID.ValueString())
+	}
+	if expected := []string{"net-c", "net-a", "net-b"}; !reflect.DeepEqual(networks, expected) {
+		t.Fatalf("expected order %v, got %v", expected, networks)
+	}
+	if !got[1].Primary.ValueBool() || got[0].Primary.ValueBool() {
+		t.Errorf("expected the planned primary flag to be kept, got %v", got)
+	}
+}
+
+func TestOrderNetworkAttachments_ExtraNICsAppendedSorted(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []AttachmentOrderSource{OrderFromPlan, OrderFromState} {
+		plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-m", true)}
+		api := []resourcemodels.NetworkAttachmentModel{
+			apiNIC(t, "net-z", "10.9.0.5", "sg-2", "sg-1"),
+			apiNIC(t, "net-m", "10.5.0.5"),
+			apiNIC(t, "net-b", "10.1.0.5"),
+		}
+
+		list, diags := OrderNetworkAttachments(context.Background(), plan, api, source)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		got := orderedAttachments(t, list)
+		var networks []string
+		for _, att := range got {
+			networks = append(networks, att.NetworkID.ValueString())
+		}
+		if expected := []string{"net-m", "net-b", "net-z"}; !reflect.DeepEqual(networks, expected) {
+			t.Fatalf("source %d: expected order %v, got %v", source, expected, networks)
+		}
+		if sgIDs := attachmentSGs(t, got[2]); !reflect.DeepEqual(sgIDs, []string{"sg-1", "sg-2"}) {
+			t.Errorf("source %d: expected appended NIC security groups sorted, got %v", source, sgIDs)
+		}
+	}
+}
+
+func TestOrderNetworkAttachments_SecurityGroupOrderPreserved(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		source   AttachmentOrderSource
+		prior    []string
+		api      []string
+		expected []string
+	}{
+		{
+			name:     "plan order kept verbatim",
+			source:   OrderFromPlan,
+			prior:    []string{"sg-b", "sg-a"},
+			api:      []string{"sg-a", "sg-b"},
+			expected: []string{"sg-b", "sg-a"},
+		},
+		{
+			name:     "empty plan falls back to sorted API",
+			source:   OrderFromPlan,
+			prior:    []string{},
+			api:      []string{"sg-b", "sg-a"},
+			expected: []string{"sg-a", "sg-b"},
+		},
+		{
+			name:     "state order kept on refresh",
+			source:   OrderFromState,
+			prior:    []string{"sg-c", "sg-a"},
+			api:      []string{"sg-a", "sg-c"},
+			expected: []string{"sg-c", "sg-a"},
+		},
+		{
+			name:     "refresh drops removed and appends added groups sorted",
+			source:   OrderFromState,
+			prior:    []string{"sg-c", "sg-gone", "sg-a"},
+			api:      []string{"sg-z", "sg-a", "sg-c", "sg-b"},
+			expected: []string{"sg-c", "sg-a", "sg-b", "sg-z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true, tt.prior...)}
+			api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5", tt.api...)}
+
+			list, diags := OrderNetworkAttachments(context.Background(), plan, api, tt.source)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			got := orderedAttachments(t, list)
+			if sgIDs := attachmentSGs(t, got[0]); !reflect.DeepEqual(sgIDs, tt.expected) {
+				t.Errorf("expected security groups %v, got %v", tt.expected, sgIDs)
+			}
+		})
+	}
+}
+
+func TestOrderNetworkAttachments_IPFromNIC(t *testing.T) {
+	t.Parallel()
+
+	plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true)}
+	plan[0].IPAddress = types.StringValue("10.0.0.99")
+	api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5")}
+	api[0].FloatingIP = types.StringValue("203.0.113.10")
+
+	list, diags := OrderNetworkAttachments(context.Background(), plan, api, OrderFromPlan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got := orderedAttachments(t, list)
+	if got[0].IPAddress.ValueString() != "10.0.0.5" {
+		t.Errorf("expected IP address from the NIC, got %s", got[0].IPAddress)
+	}
+	if got[0].FloatingIP.ValueString() != "203.0.113.10" {
+		t.Errorf("expected floating IP address from the NIC, got %s", got[0].FloatingIP)
+	}
+}
+
+func TestOrderNetworkAttachments_AddressFromAPI(t *testing.T) {
+	t.Parallel()
+
+	// The API reports the fixed-IP NIC without its address after an update.
+	plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true, "sg-1", "sg-2")}
+	plan[0].IPAddress = types.StringValue("10.0.0.50")
+	api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "", "sg-1", "sg-2")}
+	api[0].IPAddress = types.StringNull()
+
+	for _, source := range []AttachmentOrderSource{OrderFromPlan, OrderFromState} {
+		list, diags := OrderNetworkAttachments(context.Background(), plan, api, source)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		got := orderedAttachments(t, list)
+		if !got[0].IPAddress.IsNull() {
+			t.Errorf("source %d: expected the API address, got %s", source, got[0].IPAddress)
+		}
+		if sgs := attachmentSGs(t, got[0]); !reflect.DeepEqual(sgs, []string{"sg-1", "sg-2"}) {
+			t.Errorf("source %d: expected the updated security groups, got %v", source, sgs)
+		}
+	}
+}
+
+func TestOrderNetworkAttachments_MissingNIC(t *testing.T) {
+	t.Parallel()
+
+	plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true), planNIC(t, "net-gone", false)}
+	api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5")}
+
+	list, diags := OrderNetworkAttachments(context.Background(), plan, api, OrderFromPlan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	got := orderedAttachments(t, list)
+	if len(got) != 2 || !got[1].IPAddress.IsNull() {
+		t.Errorf("expected planned attachment kept with a null IP after apply, got %v", got)
+	}
+
+	list, diags = OrderNetworkAttachments(context.Background(), plan, api, OrderFromState)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := orderedAttachments(t, list); len(got) != 1 {
+		t.Errorf("expected detached NIC dropped on refresh, got %v", got)
+	}
+}
+
+func TestOrderNetworkAttachments_PlatformSecurityGroupsWarn(t *testing.T) {
+	t.Parallel()
+
+	plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true)}
+	api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5", "sg-default")}
+
+	list, diags := OrderNetworkAttachments(context.Background(), plan, api, OrderFromPlan)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if got := orderedAttachments(t, list); !got[0].SecurityGroupIDs.IsNull() {
+		t.Errorf("expected security_group_ids to stay null to match the plan, got %s", got[0].SecurityGroupIDs)
+	}
+}
+
+func TestOrderNetworkAttachments_NICSettingsCarriedOver(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []AttachmentOrderSource{OrderFromPlan, OrderFromState} {
+		plan := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true)}
+		plan[0].DNSNameservers = stringList(t, "1.1.1.1")
+		api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5"), apiNIC(t, "net-b", "10.1.0.5")}
+
+		list, diags := OrderNetworkAttachments(context.Background(), plan, api, source)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		got := orderedAttachments(t, list)
+		if !got[0].DNSNameservers.Equal(plan[0].DNSNameservers) {
+			t.Errorf("source %d: expected NIC settings kept, got %v", source, got[0])
+		}
+		if !got[1].DNSNameservers.IsNull() || !got[1].HostRoutes.IsNull() {
+			t.Errorf("source %d: expected null NIC settings on appended NIC, got %v", source, got[1])
+		}
+	}
+}
+
+func TestOrderNetworkAttachments_PrimaryKeptOnRefresh(t *testing.T) {
+	t.Parallel()
+
+	// MapServerToState marks the NIC with the lowest network_id primary.
+	api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5"), apiNIC(t, "net-b", "10.1.0.5")}
+	api[0].Primary = types.BoolValue(true)
+	prior := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", false), planNIC(t, "net-b", true)}
+
+	list, diags := OrderNetworkAttachments(context.Background(), prior, api, OrderFromState)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got := orderedAttachments(t, list)
+	if got[0].Primary.ValueBool() || !got[1].Primary.ValueBool() {
+		t.Errorf("expected the prior primary flags to be kept, got %v and %v", got[0].Primary, got[1].Primary)
+	}
+}
+
+func TestWithNICSettings(t *testing.T) {
+	t.Parallel()
+
+	current, diags := OrderNetworkAttachments(context.Background(), nil,
+		[]resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5"), apiNIC(t, "net-b", "10.1.0.5")}, OrderFromState)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	planned := []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-b", false)}
+	planned[0].DNSNameservers = stringList(t, "1.1.1.1")
+
+	list, diags := WithNICSettings(context.Background(), current, planned)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got := orderedAttachments(t, list)
+	if !got[0].DNSNameservers.IsNull() || !got[1].DNSNameservers.Equal(planned[0].DNSNameservers) {
+		t.Errorf("expected dns_nameservers applied to net-b only, got %v", got)
+	}
+	if got[1].IPAddress.ValueString() != "10.1.0.5" {
+		t.Errorf("expected other attributes untouched, got %v", got[1])
+	}
+}
+
+func TestNICSettingsNotAppliedWarning(t *testing.T) {
+	t.Parallel()
+
+	withRouting := func(networkID, nameserver string) resourcemodels.NetworkAttachmentModel {
+		att := planNIC(t, networkID, false)
+		att.DNSNameservers = stringList(t, nameserver)
+		att.HostRoutes = types.ListValueMust(types.ObjectType{AttrTypes: HostRouteAttrTypes}, []attr.Value{
+			types.ObjectValueMust(HostRouteAttrTypes, map[string]attr.Value{
+				"destination": types.StringValue("192.168.50.0/24"),
+				"nexthop":     types.StringValue("10.0.0.254"),
+			}),
+		})
+		return att
+	}
+
+	tests := []struct {
+		name     string
+		planned  []resourcemodels.NetworkAttachmentModel
+		prior    []resourcemodels.NetworkAttachmentModel
+		warnings int
+	}{
+		{
+			name:    "unset",
+			planned: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", false)},
+		},
+		{
+			name: "set on create",
+			planned: []resourcemodels.NetworkAttachmentModel{
+				withRouting("net-a", "1.1.1.1"),
+				withRouting("net-b", "8.8.8.8"),
+			},
+			warnings: 2,
+		},
+		{
+			name:     "dns and routes on create",
+			planned:  []resourcemodels.NetworkAttachmentModel{withRouting("net-a", "1.1.1.1")},
+			warnings: 1,
+		},
+		{
+			name:    "dns and routes unchanged on update",
+			planned: []resourcemodels.NetworkAttachmentModel{withRouting("net-a", "1.1.1.1")},
+			prior:   []resourcemodels.NetworkAttachmentModel{withRouting("net-a", "1.1.1.1")},
+		},
+		{
+			name:     "dns changed on update",
+			planned:  []resourcemodels.NetworkAttachmentModel{withRouting("net-a", "8.8.8.8")},
+			prior:    []resourcemodels.NetworkAttachmentModel{withRouting("net-a", "1.1.1.1")},
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := NICSettingsNotAppliedWarning(tt.planned, tt.prior)
+			if diags.HasError() || diags.WarningsCount() != tt.warnings {
+				t.Errorf("expected %d warnings, got %v", tt.warnings, diags)
+			}
+		})
+	}
+}
+
+func TestLockoutWarning(t *testing.T) {
+	t.Parallel()
+
+	withNames := func(networkID string, names ...string) resourcemodels.NetworkAttachmentModel {
+		att := planNIC(t, networkID, false)
+		att.SecurityGroupNames = stringList(t, names...)
+		return att
+	}
+	withUnknownSGs := planNIC(t, "net-b", false)
+	withUnknownSGs.SecurityGroupIDs = types.ListUnknown(types.StringType)
+
+	tests := []struct {
+		name        string
+		attachments []resourcemodels.NetworkAttachmentModel
+		keypair     types.String
+		password    types.String
+		warnings    int
+	}{
+		{
+			name:        "no security groups or credentials",
+			attachments: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true), withNames("net-b", []string{}...)},
+			keypair:     types.StringNull(),
+			password:    types.StringNull(),
+			warnings:    1,
+		},
+		{
+			name:        "security group on one attachment",
+			attachments: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true), planNIC(t, "net-b", false, "sg-1")},
+			keypair:     types.StringNull(),
+			password:    types.StringNull(),
+		},
+		{
+			name:        "security group by name",
+			attachments: []resourcemodels.NetworkAttachmentModel{withNames("net-a", "ssh")},
+			keypair:     types.StringNull(),
+			password:    types.StringNull(),
+		},
+		{
+			name:        "unknown security groups",
+			attachments: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true), withUnknownSGs},
+			keypair:     types.StringNull(),
+			password:    types.StringNull(),
+		},
+		{
+			name:        "keypair",
+			attachments: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true)},
+			keypair:     types.StringValue("ops"),
+			password:    types.StringNull(),
+		},
+		{
+			name:        "unknown password",
+			attachments: []resourcemodels.NetworkAttachmentModel{planNIC(t, "net-a", true)},
+			keypair:     types.StringNull(),
+			password:    types.StringUnknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := LockoutWarning(tt.attachments, tt.keypair, tt.password)
+			if diags.HasError() || diags.WarningsCount() != tt.warnings {
+				t.Errorf("expected %d warnings, got %v", tt.warnings, diags)
+			}
+		})
+	}
+}
+
+func TestWithNamedSecurityGroups(t *testing.T) {
+	t.Parallel()
+
+	named := planNIC(t, "net-a", true, "sg-1")
+	named.SecurityGroupNames = stringList(t, "web", "ssh", "db")
+	unnamed := planNIC(t, "net-b", false)
+	unnamed.SecurityGroupNames = stringList(t)
+
+	var values []attr.Value
+	for _, att := range []resourcemodels.NetworkAttachmentModel{named, unnamed} {
+		obj, diags := networkAttachmentObject(att)
+		if diags.HasError() {
+			t.Fatalf("failed to build attachment: %v", diags)
+		}
+		values = append(values, obj)
+	}
+	attachments := types.ListValueMust(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, values)
+
+	// "ssh" resolves to a group already listed by ID; "db" did not resolve.
+	list, diags := WithNamedSecurityGroups(context.Background(), attachments, map[string]string{"web": "sg-2", "ssh": "sg-1"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got := orderedAttachments(t, list)
+	if sgs := attachmentSGs(t, got[0]); !reflect.DeepEqual(sgs, []string{"sg-1", "sg-2"}) {
+		t.Errorf("expected configured IDs followed by resolved names, got %v", sgs)
+	}
+	if !got[1].SecurityGroupIDs.IsNull() {
+		t.Errorf("expected attachment without names untouched, got %s", got[1].SecurityGroupIDs)
+	}
+}
+
+func TestWithoutNamedSecurityGroups(t *testing.T) {
+	t.Parallel()
+
+	nameIDs := map[string]string{"web": "sg-web", "ssh": "sg-ssh"}
+
+	tests := []struct {
+		name          string
+		source        AttachmentOrderSource
+		configuredIDs []string
+		names         []string
+		attached      []string
+		expectIDs     []string
+		expectNames   []string
+	}{
+		{
+			name:        "names only",
+			source:      OrderFromPlan,
+			names:       []string{"web", "ssh"},
+			attached:    []string{"sg-ssh", "sg-web"},
+			expectNames: []string{"web", "ssh"},
+		},
+		{
+			name:          "ids and names",
+			source:        OrderFromState,
+			configuredIDs: []string{"sg-1"},
+			names:         []string{"web"},
+			attached:      []string{"sg-1", "sg-web"},
+			expectIDs:     []string{"sg-1"},
+			expectNames:   []string{"web"},
+		},
+		{
+			name:          "group listed both ways stays in ids",
+			source:        OrderFromState,
+			configuredIDs: []string{"sg-web"},
+			names:         []string{"web"},
+			attached:      []string{"sg-web"},
+			expectIDs:     []string{"sg-web"},
+			expectNames:   []string{"web"},
+		},
+		{
+			name:        "named group detached out of band",
+			source:      OrderFromState,
+			names:       []string{"web", "ssh"},
+			attached:    []string{"sg-ssh"},
+			expectNames: []string{"ssh"},
+		},
+		{
+			name:        "unresolved name kept",
+			source:      OrderFromState,
+			names:       []string{"gone"},
+			attached:    []string{"sg-old"},
+			expectIDs:   []string{"sg-old"},
+			expectNames: []string{"gone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prior := planNIC(t, "net-a", true, tt.configuredIDs...)
+			prior.SecurityGroupNames = stringList(t, tt.names...)
+			api := []resourcemodels.NetworkAttachmentModel{apiNIC(t, "net-a", "10.0.0.5", tt.attached...)}
+
+			order, nics, diags := WithoutNamedSecurityGroups(context.Background(), []resourcemodels.NetworkAttachmentModel{prior}, api, nameIDs, tt.source)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			list, diags := OrderNetworkAttachments(context.Background(), order, nics, tt.source)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			got := orderedAttachments(t, list)[0]
+			if sgs := attachmentSGs(t, got); !reflect.DeepEqual(sgs, append([]string{}, tt.expectIDs...)) {
+				t.Errorf("expected security_group_ids %v, got %v", tt.expectIDs, sgs)
+			}
+			names, _ := SecurityGroupIDsFromList(context.Background(), got.SecurityGroupNames)
+			if !reflect.DeepEqual(names, tt.expectNames) {
+				t.Errorf("expected security_group_names %v, got %v", tt.expectNames, names)
+			}
+		})
+	}
+}
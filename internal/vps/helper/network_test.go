@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAllocationPools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cidr     string
+		gateway  string
+		expected [][2]string
+		wantErr  bool
+	}{
+		{
+			name:     "gateway at start",
+			cidr:     "10.0.0.0/24",
+			gateway:  "10.0.0.1",
+			expected: [][2]string{{"10.0.0.2", "10.0.0.254"}},
+		},
+		{
+			name:     "gateway in middle",
+			cidr:     "192.168.1.0/24",
+			gateway:  "192.168.1.100",
+			expected: [][2]string{{"192.168.1.1", "192.168.1.99"}, {"192.168.1.101", "192.168.1.254"}},
+		},
+		{
+			name:     "no gateway",
+			cidr:     "172.16.0.0/28",
+			gateway:  "",
+			expected: [][2]string{{"172.16.0.1", "172.16.0.14"}},
+		},
+		{
+			name:     "gateway outside cidr",
+			cidr:     "10.1.0.0/30",
+			gateway:  "10.2.0.1",
+			expected: [][2]string{{"10.1.0.1", "10.1.0.2"}},
+		},
+		{
+			name:     "unmasked cidr",
+			cidr:     "10.0.0.5/24",
+			gateway:  "10.0.0.254",
+			expected: [][2]string{{"10.0.0.1", "10.0.0.253"}},
+		},
+		{
+			name:     "ipv6",
+			cidr:     "fd00::/120",
+			gateway:  "fd00::1",
+			expected: [][2]string{{"fd00::2", "fd00::ff"}},
+		},
+		{
+			name:    "invalid cidr",
+			cidr:    "not-a-cidr",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pools, err := AllocationPools(tt.cidr, tt.gateway)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got pools %v", pools)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([][2]string, 0, len(pools))
+			for _, pool := range pools {
+				got = append(got, [2]string{pool.Start.String(), pool.End.String()})
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFixedIPCandidates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cidr     string
+		gateway  string
+		offsets  []int
+		expected []string
+	}{
+		{
+			name:     "offsets from pool start",
+			cidr:     "10.0.0.0/24",
+			gateway:  "10.0.0.1",
+			offsets:  []int{10, 20},
+			expected: []string{"10.0.0.12", "10.0.0.22"},
+		},
+		{
+			name:     "gateway skipped",
+			cidr:     "10.0.0.0/24",
+			gateway:  "10.0.0.11",
+			offsets:  []int{10, 11},
+			expected: []string{"10.0.0.12"},
+		},
+		{
+			name:     "outside small subnet",
+			cidr:     "10.0.0.0/28",
+			gateway:  "10.0.0.1",
+			offsets:  []int{10, 20},
+			expected: []string{"10.0.0.12"},
+		},
+		{
+			name:     "invalid cidr",
+			cidr:     "bogus",
+			offsets:  []int{10},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FixedIPCandidates(tt.cidr, tt.gateway, tt.offsets)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFirstFreeIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		cidr        string
+		gateway     string
+		used        []string
+		expected    string
+		expectError bool
+	}{
+		{name: "empty subnet", cidr: "10.0.0.0/24", gateway: "10.0.0.1", expected: "10.0.0.2"},
+		{name: "skips used addresses", cidr: "10.0.0.0/24", gateway: "10.0.0.1", used: []string{"10.0.0.3", "10.0.0.2", "not-an-ip"}, expected: "10.0.0.4"},
+		{name: "fills a gap", cidr: "10.0.0.0/24", gateway: "10.0.0.1", used: []string{"10.0.0.2", "10.0.0.4"}, expected: "10.0.0.3"},
+		{name: "continues past the gateway", cidr: "10.0.0.0/29", gateway: "10.0.0.2", used: []string{"10.0.0.1"}, expected: "10.0.0.3"},
+		{name: "subnet full", cidr: "10.0.0.0/30", gateway: "10.0.0.1", used: []string{"10.0.0.2"}, expectError: true},
+		{name: "invalid cidr", cidr: "bogus", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FirstFreeIP(tt.cidr, tt.gateway, tt.used)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckFixedIPInSubnet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ip      string
+		cidr    string
+		gateway string
+		wantErr bool
+	}{
+		{name: "inside pool", ip: "10.0.0.50", cidr: "10.0.0.0/24", gateway: "10.0.0.1"},
+		{name: "last host", ip: "10.0.0.254", cidr: "10.0.0.0/24", gateway: "10.0.0.1"},
+		{name: "outside cidr", ip: "10.0.1.5", cidr: "10.0.0.0/24", gateway: "10.0.0.1", wantErr: true},
+		{name: "gateway", ip: "10.0.0.1", cidr: "10.0.0.0/24", gateway: "10.0.0.1", wantErr: true},
+		{name: "network address", ip: "10.0.0.0", cidr: "10.0.0.0/24", gateway: "10.0.0.1", wantErr: true},
+		{name: "broadcast address", ip: "10.0.0.255", cidr: "10.0.0.0/24", gateway: "10.0.0.1", wantErr: true},
+		{name: "not an ip", ip: "10.0.0", cidr: "10.0.0.0/24", gateway: "10.0.0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckFixedIPInSubnet(tt.ip, tt.cidr, tt.gateway)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFindNetworksByName(t *testing.T) {
+	t.Parallel()
+
+	// The API matches names by substring
+	projectClient := newTestProjectClient(t, "/networks", `{"networks":[
+		{"id":"net-3","name":"prod","cidr":"10.0.2.0/24"},
+		{"id":"net-1","name":"prod-dmz","cidr":"10.0.0.0/24"},
+		{"id":"net-2","name":"prod","cidr":"10.0.1.0/24"}
+	]}`)
+
+	matches, err := FindNetworksByName(context.Background(), projectClient, "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 2 || matches[0].ID != "net-2" || matches[1].ID != "net-3" {
+		t.Errorf("expected exact matches net-2 and net-3, got %+v", matches)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+
+	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+)
+
+// ServerPassword returns the administrator password a Windows server
+// generated on first boot, or "" while it is not available yet. The API
+// decrypts the password with privateKey, the PEM private key of the server's
+// keypair. The SDK's Action call drops the response body, so the get_pwd
+// action is sent directly.
+func (c *APIClient) ServerPassword(ctx context.Context, serverID, privateKey string) (string, error) {
+	req := servermodels.ServerActionRequest{
+		Action:     servermodels.ServerActionGetPwd,
+		PrivateKey: base64.StdEncoding.EncodeToString([]byte(privateKey)),
+	}
+
+	var out servermodels.ServerActionResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/servers/"+url.PathEscape(serverID)+"/action", nil, req, &out); err != nil {
+		return "", err
+	}
+	return out.Password, nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import cloudsdk "github.com/Zillaforge/cloud-sdk"
+
+// ProviderData is handed to resources through ResourceData. Data sources
+// receive the *cloudsdk.ProjectClient directly.
+type ProviderData struct {
+	Client *cloudsdk.ProjectClient
+
+	// Project is the project_id or project_sys_code the provider was
+	// configured with.
+	Project string
+
+	// API reaches the endpoints the SDK does not wrap.
+	API *APIClient
+
+	// PrecheckQuota mirrors the provider's precheck_quota option.
+	PrecheckQuota bool
+
+	// PrecheckNameUnique mirrors the provider's precheck_name_unique option.
+	PrecheckNameUnique bool
+
+	// EmitOperationEvents mirrors the provider's emit_operation_events option.
+	EmitOperationEvents bool
+}
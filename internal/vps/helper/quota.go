@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	flavorsmodels "github.com/Zillaforge/cloud-sdk/models/vps/flavors"
+)
+
+// Quota is the project quota and usage returned by the VPS quotas endpoint.
+// The endpoint is not part of the SDK's API contracts, so its response shape
+// is assumed; a quota the response does not report is nil and never checked.
+type Quota struct {
+	VM   *QuotaDetail `json:"vm"`
+	VCPU *QuotaDetail `json:"vcpu"`
+	RAM  *QuotaDetail `json:"ram"`
+	GPU  *QuotaDetail `json:"gpu"`
+}
+
+// QuotaDetail is the limit and current usage of one quota. A negative limit
+// means unlimited.
+type QuotaDetail struct {
+	Limit int `json:"limit"`
+	Usage int `json:"usage"`
+}
+
+// Quota returns the current quota and usage of the project. It calls
+// /quotas directly until the SDK ships a quota client, so callers must treat
+// its result as best-effort.
+func (c *APIClient) Quota(ctx context.Context) (*Quota, error) {
+	var quota Quota
+	if err := c.getJSON(ctx, "/quotas", nil, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// ServerQuotaShortfalls returns one message per quota that creating a server
+// of the given flavor would exceed, e.g. "vcpu quota exceeded: need 4, have 2".
+func ServerQuotaShortfalls(quota *Quota, flavor *flavorsmodels.Flavor) []string {
+	gpus := 0
+	if flavor.GPU != nil {
+		gpus = flavor.GPU.Count
+	}
+
+	checks := []struct {
+		name   string
+		detail *QuotaDetail
+		need   int
+	}{
+		{"instance", quota.VM, 1},
+		{"vcpu", quota.VCPU, flavor.VCPU},
+		{"ram", quota.RAM, flavor.Memory},
+		{"gpu", quota.GPU, gpus},
+	}
+
+	var shortfalls []string
+	for _, check := range checks {
+		if check.need <= 0 || check.detail == nil || check.detail.Limit < 0 {
+			continue
+		}
+		available := check.detail.Limit - check.detail.Usage
+		if available < 0 {
+			available = 0
+		}
+		if check.need > available {
+			shortfalls = append(shortfalls, fmt.Sprintf("%s quota exceeded: need %d, have %d (limit %d, in use %d)",
+				check.name, check.need, available, check.detail.Limit, check.detail.Usage))
+		}
+	}
+	return shortfalls
+}
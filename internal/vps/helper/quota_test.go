@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	flavorsmodels "github.com/Zillaforge/cloud-sdk/models/vps/flavors"
+)
+
+func TestAPIClient_Quota(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vps/api/v1/project/proj-1/quotas" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"vm":{"limit":10,"usage":3},"vcpu":{"limit":-1,"usage":12}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &APIClient{BaseURL: srv.URL + "/vps", ProjectID: "proj-1", Token: "token", HTTPClient: srv.Client()}
+	quota, err := client.Quota(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quota.VM == nil || *quota.VM != (QuotaDetail{Limit: 10, Usage: 3}) || quota.VCPU == nil || quota.VCPU.Limit != -1 {
+		t.Errorf("unexpected quota: %+v", quota)
+	}
+	if quota.RAM != nil || quota.GPU != nil {
+		t.Errorf("expected quotas missing from the response to be nil, got %+v", quota)
+	}
+
+	client.ProjectID = "other"
+	if _, err := client.Quota(context.Background()); err == nil {
+		t.Error("expected an error when the quota endpoint is unavailable")
+	}
+}
+
+func TestServerQuotaShortfalls(t *testing.T) {
+	t.Parallel()
+
+	flavor := &flavorsmodels.Flavor{Name: "m1", VCPU: 4, Memory: 8192, GPU: &flavorsmodels.GPUInfo{Count: 1}}
+
+	tests := []struct {
+		name     string
+		quota    Quota
+		expected []string
+	}{
+		{
+			name: "fits",
+			quota: Quota{
+				VM:   &QuotaDetail{Limit: 10, Usage: 1},
+				VCPU: &QuotaDetail{Limit: 16, Usage: 4},
+				RAM:  &QuotaDetail{Limit: 65536, Usage: 0},
+				GPU:  &QuotaDetail{Limit: 2, Usage: 1},
+			},
+		},
+		{
+			name: "unlimited",
+			quota: Quota{
+				VM:   &QuotaDetail{Limit: -1, Usage: 100},
+				VCPU: &QuotaDetail{Limit: -1, Usage: 100},
+				RAM:  &QuotaDetail{Limit: -1, Usage: 100},
+				GPU:  &QuotaDetail{Limit: -1, Usage: 100},
+			},
+		},
+		{
+			name: "not reported",
+			quota: Quota{
+				VM: &QuotaDetail{Limit: 10, Usage: 1},
+			},
+		},
+		{
+			name: "exceeded",
+			quota: Quota{
+				VM:   &QuotaDetail{Limit: 10, Usage: 10},
+				VCPU: &QuotaDetail{Limit: 16, Usage: 14},
+				RAM:  &QuotaDetail{Limit: 65536, Usage: 0},
+				GPU:  &QuotaDetail{Limit: 2, Usage: 3},
+			},
+			expected: []string{
+				"instance quota exceeded: need 1, have 0 (limit 10, in use 10)",
+				"vcpu quota exceeded: need 4, have 2 (limit 16, in use 14)",
+				"gpu quota exceeded: need 1, have 0 (limit 2, in use 3)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ServerQuotaShortfalls(&tt.quota, flavor); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
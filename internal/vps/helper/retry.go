@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryConfig controls how WithRetry retries a failed call.
+type RetryConfig struct {
+	// MaxAttempts is the total number of calls, including the first.
+	MaxAttempts int
+
+	// InitialInterval is the wait before the first retry. It doubles on
+	// every further retry, up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// DefaultRetryConfig is used by WithRetry. Together with the SDK's 30s
+// request timeout it bounds a call that keeps failing to connect to about
+// 4*30s plus 2s+4s+8s of backoff; the data source docs quote that figure.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:     4,
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     10 * time.Second,
+}
+
+// WithRetry calls fn until it succeeds, returns an error that is not a
+// connection failure, or DefaultRetryConfig.MaxAttempts is reached. The SDK
+// already retries GETs answered with 429, 502, 503 or 504, so only requests
+// that got no answer at all are retried here. Only wrap idempotent reads: a
+// write that lost its connection may still have been applied.
+func WithRetry[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	return withRetry(ctx, DefaultRetryConfig, fn)
+}
+
+func withRetry[T any](ctx context.Context, config RetryConfig, fn func(context.Context) (T, error)) (T, error) {
+	interval := config.InitialInterval
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil || attempt >= config.MaxAttempts || !isConnectionError(err) {
+			return result, err
+		}
+
+		tflog.Debug(ctx, "Retrying API read after connection error", map[string]interface{}{
+			"attempt": attempt,
+			"backoff": interval.String(),
+			"error":   err.Error(),
+		})
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > config.MaxInterval {
+			interval = config.MaxInterval
+		}
+	}
+}
+
+// DefaultInUseDeleteTimeout bounds DeleteWithInUseRetry when the resource's
+// timeouts block does not set delete.
+const DefaultInUseDeleteTimeout = 5 * time.Minute
+
+// inUseRetryConfig is used by DeleteWithInUseRetry. MaxAttempts is unused:
+// the retries are bounded by the delete timeout instead.
+var inUseRetryConfig = RetryConfig{
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     15 * time.Second,
+}
+
+// DeleteWithInUseRetry calls del until it succeeds or fails with an error
+// other than IsInUse. An object still in use, typically by a server that
+// Terraform is deleting in the same apply, is retried with jittered
+// exponential backoff until ctx is done or the next wait would run past
+// timeout; the last error is returned.
+func DeleteWithInUseRetry(ctx context.Context, timeout time.Duration, del func(context.Context) error) error {
+	return deleteWithInUseRetry(ctx, inUseRetryConfig, timeout, del)
+}
+
+func deleteWithInUseRetry(ctx context.Context, config RetryConfig, timeout time.Duration, del func(context.Context) error) error {
+	deadline := time.Now().Add(timeout)
+	for attempt := 1; ; attempt++ {
+		err := del(ctx)
+		if err == nil || !IsInUse(err) {
+			return err
+		}
+
+		backoff := jitteredBackoff(config, attempt)
+		if time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+
+		tflog.Debug(ctx, "Retrying delete of an object still in use", map[string]interface{}{
+			"attempt": attempt,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// jitteredBackoff returns the wait before retry number attempt, counting
+// from 1: InitialInterval doubled per earlier retry and capped at
+// MaxInterval, then drawn at random from the upper half of that range so
+// callers that failed together do not retry in lockstep.
+func jitteredBackoff(config RetryConfig, attempt int) time.Duration {
+	interval := config.InitialInterval
+	for i := 1; i < attempt && interval < config.MaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > config.MaxInterval {
+		interval = config.MaxInterval
+	}
+	if interval < 2 {
+		return interval
+	}
+	half := interval / 2
+	return half + rand.N(interval-half+1)
+}
+
+// IsRetryable reports whether err is an API response worth retrying:
+// rate limiting (429) or a gateway or availability failure (502, 503, 504).
+func IsRetryable(err error) bool {
+	var sdkErr *cloudsdk.SDKError
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+	switch sdkErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isConnectionError reports whether err is a request the SDK could not get
+// any answer for, such as a refused or reset connection. Timeouts are not
+// included: retrying them would multiply the 30s request timeout.
+func isConnectionError(err error) bool {
+	var sdkErr *cloudsdk.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.StatusCode != 0 {
+		return false
+	}
+	category, _ := sdkErr.Meta["category"].(string)
+	return category == "network"
+}
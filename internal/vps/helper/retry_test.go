@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+)
+
+// flakyClient fails its first len(errs) calls with errs, in order, then
+// returns "ok".
+type flakyClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *flakyClient) Get(ctx context.Context) (string, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return "", c.errs[c.calls-1]
+	}
+	return "ok", nil
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	refused := cloudsdk.NewNetworkError("dial tcp: connection refused", nil)
+	config := RetryConfig{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		expectErr   bool
+		expectCalls int
+	}{
+		{name: "first call succeeds", expectCalls: 1},
+		{name: "fails twice then succeeds", errs: []error{refused, fmt.Errorf("failed to list: %w", refused)}, expectCalls: 3},
+		{name: "attempts exhausted", errs: []error{refused, refused, refused}, expectErr: true, expectCalls: 3},
+		{name: "already retried by the sdk", errs: []error{cloudsdk.NewSDKError(503, 0, "service unavailable", nil, nil)}, expectErr: true, expectCalls: 1},
+		{name: "timeout", errs: []error{cloudsdk.NewTimeoutError(nil)}, expectErr: true, expectCalls: 1},
+		{name: "not retryable", errs: []error{cloudsdk.NewSDKError(400, 0, "bad request", nil, nil)}, expectErr: true, expectCalls: 1},
+		{name: "not an api error", errs: []error{errors.New("connection refused")}, expectErr: true, expectCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &flakyClient{errs: tt.errs}
+			got, err := withRetry(context.Background(), config, client.Get)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+			if !tt.expectErr && got != "ok" {
+				t.Errorf("expected %q, got %q", "ok", got)
+			}
+			if client.calls != tt.expectCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectCalls, client.calls)
+			}
+		})
+	}
+}
+
+func TestWithRetry_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &flakyClient{errs: []error{cloudsdk.NewNetworkError("dial tcp: connection refused", nil)}}
+	config := RetryConfig{MaxAttempts: 3, InitialInterval: time.Hour, MaxInterval: time.Hour}
+	if _, err := withRetry(ctx, config, client.Get); err == nil {
+		t.Fatal("expected the last error once the context is done")
+	}
+	if client.calls != 1 {
+		t.Errorf("expected 1 call, got %d", client.calls)
+	}
+}
+
+func TestDeleteWithInUseRetry(t *testing.T) {
+	t.Parallel()
+
+	inUse := cloudsdk.NewSDKError(409, 0, "(neutron)Security Group 0a1b in use.", nil, nil)
+	config := RetryConfig{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		timeout     time.Duration
+		expectErr   bool
+		expectCalls int
+	}{
+		{name: "first call succeeds", timeout: time.Minute, expectCalls: 1},
+		{name: "in use until the server is gone", errs: []error{inUse, inUse, inUse}, timeout: time.Minute, expectCalls: 4},
+		{name: "other error", errs: []error{cloudsdk.NewSDKError(403, 0, "forbidden", nil, nil)}, timeout: time.Minute, expectErr: true, expectCalls: 1},
+		{name: "still in use at the timeout", errs: []error{inUse, inUse}, timeout: 0, expectErr: true, expectCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &flakyClient{errs: tt.errs}
+			err := deleteWithInUseRetry(context.Background(), config, tt.timeout, func(ctx context.Context) error {
+				_, err := client.Get(ctx)
+				return err
+			})
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+			if client.calls != tt.expectCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectCalls, client.calls)
+			}
+		})
+	}
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	t.Parallel()
+
+	config := RetryConfig{MaxAttempts: 10, InitialInterval: time.Second, MaxInterval: 8 * time.Second}
+
+	// Each attempt's ceiling doubles until MaxInterval; the wait is drawn
+	// from the upper half of that ceiling.
+	ceilings := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second}
+	for i, ceiling := range ceilings {
+		attempt := i + 1
+		for n := 0; n < 100; n++ {
+			got := jitteredBackoff(config, attempt)
+			if got < ceiling/2 || got > ceiling {
+				t.Fatalf("attempt %d: expected backoff in [%s, %s], got %s", attempt, ceiling/2, ceiling, got)
+			}
+		}
+	}
+}
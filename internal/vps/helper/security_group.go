@@ -6,11 +6,14 @@ package helper
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"sort"
 	"strconv"
 	"strings"
 
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	sgsdk "github.com/Zillaforge/cloud-sdk/modules/vps/securitygroups"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
@@ -18,9 +21,139 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-// BuildSecurityGroupRules converts Terraform rule models to SDK rule creation requests.
+// ProtocolICMPv6 is the rule protocol for ICMP over IPv6 (neighbor
+// discovery, ping6). The SDK has no constant for it.
+const ProtocolICMPv6 sgmodels.Protocol = "icmpv6"
+
+// protocolNumbers maps the IANA protocol numbers the network service may
+// report instead of names to the names rules are configured with.
+var protocolNumbers = map[string]string{
+	"1":  "icmp",
+	"6":  "tcp",
+	"17": "udp",
+	"58": string(ProtocolICMPv6),
+}
+
+// normalizeProtocol lowercases a rule protocol and maps the network service's
+// "ipv6-icmp" spelling and protocol numbers such as "1" to the configured
+// names.
+func normalizeProtocol(protocol string) string {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	if protocol == "ipv6-icmp" {
+		return string(ProtocolICMPv6)
+	}
+	if name, ok := protocolNumbers[protocol]; ok {
+		return name
+	}
+	return protocol
+}
+
+// protocolHasPorts reports whether rules of the protocol are scoped to ports.
+// icmp, icmpv6 and any rules always cover all ports.
+func protocolHasPorts(protocol string) bool {
+	protocol = normalizeProtocol(protocol)
+	return protocol == "tcp" || protocol == "udp"
+}
+
+// portRangeForProtocol formats the port range of an API rule. Protocols
+// without ports read back as "all" whatever min/max the API reports (0/0,
+// 1/65535, -1/-1, ...), so the state matches the only value the
+// configuration can hold.
+func portRangeForProtocol(protocol string, portMin, portMax int) string {
+	if !protocolHasPorts(protocol) {
+		return "all"
+	}
+	return formatPortRange(portMin, portMax)
+}
+
+// checkRulePortRange rejects a port-scoped range on a protocol without ports,
+// which the API would drop and Terraform would then report as drift.
+func checkRulePortRange(protocol, portRange string) error {
+	if protocolHasPorts(protocol) || strings.ToLower(portRange) == "all" {
+		return nil
+	}
+	return fmt.Errorf("protocol %s applies to all ports, so port_range must be \"all\", got %q", normalizeProtocol(protocol), portRange)
+}
+
+// ValidateRulePortRanges reports, at plan time, each rule of model whose
+// protocol applies to all ports but whose port_range is not "all". Rules with
+// unknown values are skipped.
+func ValidateRulePortRanges(ctx context.Context, model resourcemodels.SecurityGroupResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, block := range []struct {
+		name  string
+		rules types.List
+	}{
+		{"ingress_rule", model.IngressRule},
+		{"egress_rule", model.EgressRule},
+	} {
+		if block.rules.IsNull() || block.rules.IsUnknown() {
+			continue
+		}
+		for i, element := range block.rules.Elements() {
+			obj, ok := element.(types.Object)
+			if !ok || obj.IsUnknown() {
+				continue
+			}
+			var rule resourcemodels.SecurityRuleModel
+			diags.Append(obj.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+			if diags.HasError() {
+				return diags
+			}
+			if rule.Protocol.IsUnknown() || rule.PortRange.IsUnknown() {
+				continue
+			}
+			if err := checkRulePortRange(rule.Protocol.ValueString(), rule.PortRange.ValueString()); err != nil {
+				diags.AddAttributeError(
+					path.Root(block.name).AtListIndex(i).AtName("port_range"),
+					"Invalid Port Range",
+					err.Error(),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// checkRuleAddressFamily rejects an icmpv6 rule whose CIDR is IPv4.
+func checkRuleAddressFamily(protocol, cidr string) error {
+	if normalizeProtocol(protocol) != string(ProtocolICMPv6) {
+		return nil
+	}
+	if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Addr().Is4() {
+		return fmt.Errorf("protocol icmpv6 only applies to IPv6 traffic, but %s is an IPv4 CIDR. Use an IPv6 CIDR such as ::/0, or protocol icmp for IPv4", cidr)
+	}
+	return nil
+}
+
+// SecurityRuleAttrTypes is the element type of the ingress_rule and
+// egress_rule blocks of the security group resource.
+var SecurityRuleAttrTypes = map[string]attr.Type{
+	"protocol":          types.StringType,
+	"port_range":        types.StringType,
+	"source_cidr":       types.StringType,
+	"source_cidrs":      types.ListType{ElemType: types.StringType},
+	"destination_cidr":  types.StringType,
+	"destination_cidrs": types.ListType{ElemType: types.StringType},
+}
+
+// ruleCIDRs returns the CIDRs a rule applies to: its singular CIDR, or each
+// element of its CIDR list when that is set.
+func ruleCIDRs(ctx context.Context, cidr types.String, cidrs types.List) ([]string, diag.Diagnostics) {
+	if cidrs.IsNull() || cidrs.IsUnknown() {
+		return []string{cidr.ValueString()}, nil
+	}
+	var values []string
+	diags := cidrs.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// BuildSecurityGroupRules converts Terraform rule models to SDK rule creation
+// requests, one per CIDR of each rule.
 func BuildSecurityGroupRules(ctx context.Context, model resourcemodels.SecurityGroupResourceModel) ([]sgmodels.SecurityGroupRuleCreateRequest, diag.Diagnostics) {
 	var rules []sgmodels.SecurityGroupRuleCreateRequest
 	var diags diag.Diagnostics
@@ -44,20 +177,45 @@ func BuildSecurityGroupRules(ctx context.Context, model resourcemodels.SecurityG
 				continue
 			}
 
-			sdkRule := sgmodels.SecurityGroupRuleCreateRequest{
-				Direction:  sgmodels.DirectionIngress,
-				Protocol:   sgmodels.Protocol(strings.ToLower(rule.Protocol.ValueString())),
-				RemoteCIDR: rule.SourceCIDR.ValueString(),
+			if err := checkRulePortRange(rule.Protocol.ValueString(), rule.PortRange.ValueString()); err != nil {
+				diags.AddAttributeError(
+					path.Root("ingress_rule").AtListIndex(i).AtName("port_range"),
+					"Invalid Port Range",
+					err.Error(),
+				)
+				continue
 			}
 
-			// Only set ports for TCP/UDP (not ICMP/any)
-			protocol := strings.ToLower(rule.Protocol.ValueString())
-			if protocol == "tcp" || protocol == "udp" {
-				sdkRule.PortMin = portMin
-				sdkRule.PortMax = portMax
+			cidrs, cidrDiags := ruleCIDRs(ctx, rule.SourceCIDR, rule.SourceCIDRs)
+			diags.Append(cidrDiags...)
+			if cidrDiags.HasError() {
+				continue
 			}
 
-			rules = append(rules, sdkRule)
+			for _, cidr := range cidrs {
+				if err := checkRuleAddressFamily(rule.Protocol.ValueString(), cidr); err != nil {
+					diags.AddAttributeError(
+						path.Root("ingress_rule").AtListIndex(i).AtName("protocol"),
+						"Invalid Protocol For CIDR",
+						err.Error(),
+					)
+					break
+				}
+
+				sdkRule := sgmodels.SecurityGroupRuleCreateRequest{
+					Direction:  sgmodels.DirectionIngress,
+					Protocol:   sgmodels.Protocol(normalizeProtocol(rule.Protocol.ValueString())),
+					RemoteCIDR: cidr,
+				}
+
+				// Only set ports for TCP/UDP (not ICMP/ICMPv6/any)
+				if protocolHasPorts(rule.Protocol.ValueString()) {
+					sdkRule.PortMin = portMin
+					sdkRule.PortMax = portMax
+				}
+
+				rules = append(rules, sdkRule)
+			}
 		}
 	}
 
@@ -80,20 +238,45 @@ func BuildSecurityGroupRules(ctx context.Context, model resourcemodels.SecurityG
 				continue
 			}
 
-			sdkRule := sgmodels.SecurityGroupRuleCreateRequest{
-				Direction:  sgmodels.DirectionEgress,
-				Protocol:   sgmodels.Protocol(strings.ToLower(rule.Protocol.ValueString())),
-				RemoteCIDR: rule.DestinationCIDR.ValueString(),
+			if err := checkRulePortRange(rule.Protocol.ValueString(), rule.PortRange.ValueString()); err != nil {
+				diags.AddAttributeError(
+					path.Root("egress_rule").AtListIndex(i).AtName("port_range"),
+					"Invalid Port Range",
+					err.Error(),
+				)
+				continue
 			}
 
-			// Only set ports for TCP/UDP (not ICMP/any)
-			protocol := strings.ToLower(rule.Protocol.ValueString())
-			if protocol == "tcp" || protocol == "udp" {
-				sdkRule.PortMin = portMin
-				sdkRule.PortMax = portMax
+			cidrs, cidrDiags := ruleCIDRs(ctx, rule.DestinationCIDR, rule.DestinationCIDRs)
+			diags.Append(cidrDiags...)
+			if cidrDiags.HasError() {
+				continue
 			}
 
-			rules = append(rules, sdkRule)
+			for _, cidr := range cidrs {
+				if err := checkRuleAddressFamily(rule.Protocol.ValueString(), cidr); err != nil {
+					diags.AddAttributeError(
+						path.Root("egress_rule").AtListIndex(i).AtName("protocol"),
+						"Invalid Protocol For CIDR",
+						err.Error(),
+					)
+					break
+				}
+
+				sdkRule := sgmodels.SecurityGroupRuleCreateRequest{
+					Direction:  sgmodels.DirectionEgress,
+					Protocol:   sgmodels.Protocol(normalizeProtocol(rule.Protocol.ValueString())),
+					RemoteCIDR: cidr,
+				}
+
+				// Only set ports for TCP/UDP (not ICMP/ICMPv6/any)
+				if protocolHasPorts(rule.Protocol.ValueString()) {
+					sdkRule.PortMin = portMin
+					sdkRule.PortMax = portMax
+				}
+
+				rules = append(rules, sdkRule)
+			}
 		}
 	}
 
@@ -112,8 +295,10 @@ func MapSDKRulesToTerraform(ctx context.Context, sdkRules []sgmodels.SecurityGro
 
 	for _, sdkRule := range sdkRules {
 		tfRule := resourcemodels.SecurityRuleModel{
-			Protocol:  types.StringValue(string(sdkRule.Protocol)),
-			PortRange: types.StringValue(formatPortRange(sdkRule.PortMin, sdkRule.PortMax)),
+			Protocol:         types.StringValue(normalizeProtocol(string(sdkRule.Protocol))),
+			PortRange:        types.StringValue(portRangeForProtocol(string(sdkRule.Protocol), sdkRule.PortMin, sdkRule.PortMax)),
+			SourceCIDRs:      types.ListNull(types.StringType),
+			DestinationCIDRs: types.ListNull(types.StringType),
 		}
 
 		if sdkRule.Direction == sgmodels.DirectionIngress {
@@ -128,24 +313,10 @@ func MapSDKRulesToTerraform(ctx context.Context, sdkRules []sgmodels.SecurityGro
 	}
 
 	// Convert to types.List
-	ingressList, ingressDiags := types.ListValueFrom(ctx, types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"protocol":         types.StringType,
-			"port_range":       types.StringType,
-			"source_cidr":      types.StringType,
-			"destination_cidr": types.StringType,
-		},
-	}, ingressRules)
+	ingressList, ingressDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: SecurityRuleAttrTypes}, ingressRules)
 	diags.Append(ingressDiags...)
 
-	egressList, egressDiags := types.ListValueFrom(ctx, types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"protocol":         types.StringType,
-			"port_range":       types.StringType,
-			"source_cidr":      types.StringType,
-			"destination_cidr": types.StringType,
-		},
-	}, egressRules)
+	egressList, egressDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: SecurityRuleAttrTypes}, egressRules)
 	diags.Append(egressDiags...)
 
 	return ingressList, egressList, diags
@@ -201,83 +372,361 @@ func formatPortRange(portMin, portMax int) string {
 
 // ReorderRulesToMatchPlan reorders API rules to match the order in the plan.
 // This prevents Terraform from detecting phantom changes due to API reordering.
+//
+// Rules are matched by content, one to one. A planned rule with no match
+// takes the position of an unmatched API rule with the same protocol and
+// port range, typically the same rule with its CIDR changed outside
+// Terraform. The remaining API rules, such as rules added outside Terraform,
+// follow in API order. The rules both sides share keep their planned
+// positions, so drift shows up in the plan as the individual rules to add,
+// change or remove rather than as every block changing.
 func ReorderRulesToMatchPlan(ctx context.Context, planList types.List, apiList types.List) types.List {
-	// If plan is null/unknown or has different length, return API list as-is
 	if planList.IsNull() || planList.IsUnknown() || apiList.IsNull() {
 		return apiList
 	}
 
 	var planRules []resourcemodels.SecurityRuleModel
 	var apiRules []resourcemodels.SecurityRuleModel
+	if planList.ElementsAs(ctx, &planRules, false).HasError() || apiList.ElementsAs(ctx, &apiRules, false).HasError() {
+		return apiList
+	}
 
-	planList.ElementsAs(ctx, &planRules, false)
-	apiList.ElementsAs(ctx, &apiRules, false)
+	// matched[i] is the index of the API rule placed at plan position i
+	used := make([]bool, len(apiRules))
+	matched := make([]int, len(planRules))
+	match := func(i int, key func(resourcemodels.SecurityRuleModel) string) {
+		for j, apiRule := range apiRules {
+			if !used[j] && key(apiRule) == key(planRules[i]) {
+				used[j] = true
+				matched[i] = j
+				return
+			}
+		}
+	}
+	for i := range planRules {
+		matched[i] = -1
+		match(i, ruleContentKey)
+	}
+	for i := range planRules {
+		if matched[i] == -1 {
+			match(i, ruleGroupKey)
+		}
+	}
 
-	if len(planRules) != len(apiRules) {
+	// Use the API rules, which have all computed fields properly set
+	reorderedRules := make([]resourcemodels.SecurityRuleModel, 0, len(apiRules))
+	for _, j := range matched {
+		if j != -1 {
+			reorderedRules = append(reorderedRules, apiRules[j])
+		}
+	}
+	for j, apiRule := range apiRules {
+		if !used[j] {
+			reorderedRules = append(reorderedRules, apiRule)
+		}
+	}
+
+	reorderedList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: SecurityRuleAttrTypes}, reorderedRules)
+	if diags.HasError() {
+		return apiList
+	}
+	return reorderedList
+}
+
+// ReconcileRulesAsSet is the rules_as_set counterpart of
+// ReorderRulesToMatchPlan. When the plan and the API hold the same rules,
+// ignoring order and repeats, the plan's list is kept as written (with the
+// API's computed fields), so neither a reordering nor a duplicate rule the API
+// collapsed shows up as a diff. Otherwise the real drift is reported, with
+// the rules ordered as ReorderRulesToMatchPlan does.
+func ReconcileRulesAsSet(ctx context.Context, planList types.List, apiList types.List) types.List {
+	if planList.IsNull() || planList.IsUnknown() || apiList.IsNull() {
 		return apiList
 	}
 
-	// Create a map of API rules for quick lookup
-	// Key: protocol+portRange+cidr (using the non-null CIDR field)
-	apiRuleMap := make(map[string]resourcemodels.SecurityRuleModel)
+	var planRules, apiRules []resourcemodels.SecurityRuleModel
+	if planList.ElementsAs(ctx, &planRules, false).HasError() || apiList.ElementsAs(ctx, &apiRules, false).HasError() {
+		return apiList
+	}
+	if !sameRuleSet(planRules, apiRules) {
+		return ReorderRulesToMatchPlan(ctx, planList, apiList)
+	}
+
+	apiRuleMap := make(map[string]resourcemodels.SecurityRuleModel, len(apiRules))
 	for _, rule := range apiRules {
-		var cidr string
-		if !rule.SourceCIDR.IsNull() && !rule.SourceCIDR.IsUnknown() {
-			cidr = rule.SourceCIDR.ValueString()
-		} else if !rule.DestinationCIDR.IsNull() && !rule.DestinationCIDR.IsUnknown() {
-			cidr = rule.DestinationCIDR.ValueString()
+		apiRuleMap[ruleContentKey(rule)] = rule
+	}
+	reconciled := make([]resourcemodels.SecurityRuleModel, 0, len(planRules))
+	for _, planRule := range planRules {
+		reconciled = append(reconciled, apiRuleMap[ruleContentKey(planRule)])
+	}
+
+	reconciledList, diags := types.ListValueFrom(ctx, apiList.ElementType(ctx), reconciled)
+	if diags.HasError() {
+		return apiList
+	}
+	return reconciledList
+}
+
+// RulesEqualAsSet reports whether two rule lists hold the same rules,
+// ignoring order and repeats.
+func RulesEqualAsSet(ctx context.Context, a, b types.List) bool {
+	if a.IsUnknown() || b.IsUnknown() {
+		return false
+	}
+	var aRules, bRules []resourcemodels.SecurityRuleModel
+	if a.ElementsAs(ctx, &aRules, false).HasError() || b.ElementsAs(ctx, &bRules, false).HasError() {
+		return false
+	}
+	return sameRuleSet(aRules, bRules)
+}
+
+// sameRuleSet compares two rule slices by membership only.
+func sameRuleSet(a, b []resourcemodels.SecurityRuleModel) bool {
+	aKeys := make(map[string]bool, len(a))
+	for _, rule := range a {
+		aKeys[ruleContentKey(rule)] = true
+	}
+	bKeys := make(map[string]bool, len(b))
+	for _, rule := range b {
+		key := ruleContentKey(rule)
+		if !aKeys[key] {
+			return false
 		}
-		key := rule.Protocol.ValueString() + "|" + rule.PortRange.ValueString() + "|" + cidr
-		apiRuleMap[key] = rule
+		bKeys[key] = true
 	}
+	return len(aKeys) == len(bKeys)
+}
 
-	// Reorder API rules to match plan order
-	var reorderedRules []resourcemodels.SecurityRuleModel
-	for _, planRule := range planRules {
-		var cidr string
-		if !planRule.SourceCIDR.IsNull() && !planRule.SourceCIDR.IsUnknown() {
-			cidr = planRule.SourceCIDR.ValueString()
-		} else if !planRule.DestinationCIDR.IsNull() && !planRule.DestinationCIDR.IsUnknown() {
-			cidr = planRule.DestinationCIDR.ValueString()
+// ruleContentKey identifies a rule block by protocol, port range, address
+// family and CIDR (using the non-null CIDR field). The family keeps an icmpv6
+// rule on an IPv6 CIDR apart from an icmp rule on an IPv4 one, and CIDRs are
+// compared in canonical form. A rule with a CIDR list is keyed by its sorted
+// CIDRs, so it never matches a single-CIDR rule.
+func ruleContentKey(rule resourcemodels.SecurityRuleModel) string {
+	if list := ruleCIDRList(rule); !list.IsNull() {
+		keys := make([]string, 0, len(list.Elements()))
+		for _, cidr := range stringElements(list) {
+			keys = append(keys, cidrKey(cidr))
 		}
-		key := planRule.Protocol.ValueString() + "|" + planRule.PortRange.ValueString() + "|" + cidr
+		sort.Strings(keys)
+		return ruleGroupKey(rule) + "|[" + strings.Join(keys, ",") + "]"
+	}
+	return ruleGroupKey(rule) + "|" + cidrKey(ruleCIDR(rule))
+}
 
-		if apiRule, found := apiRuleMap[key]; found {
-			// Use the API rule which has all computed fields properly set
-			reorderedRules = append(reorderedRules, apiRule)
+// ruleGroupKey identifies the rules a CIDR list expands into: those sharing
+// its protocol and port range.
+func ruleGroupKey(rule resourcemodels.SecurityRuleModel) string {
+	return normalizeProtocol(rule.Protocol.ValueString()) + "|" + rule.PortRange.ValueString()
+}
+
+// cidrKey is the address family and canonical form of a CIDR.
+func cidrKey(cidr string) string {
+	family := ""
+	if prefix, err := netip.ParsePrefix(cidr); err == nil {
+		cidr = prefix.Masked().String()
+		family = "v4"
+		if prefix.Addr().Is6() {
+			family = "v6"
+		}
+	}
+	return family + "|" + cidr
+}
+
+// ruleCIDR returns the non-null singular CIDR of a rule.
+func ruleCIDR(rule resourcemodels.SecurityRuleModel) string {
+	if !rule.SourceCIDR.IsNull() && !rule.SourceCIDR.IsUnknown() {
+		return rule.SourceCIDR.ValueString()
+	}
+	if !rule.DestinationCIDR.IsNull() && !rule.DestinationCIDR.IsUnknown() {
+		return rule.DestinationCIDR.ValueString()
+	}
+	return ""
+}
+
+// ruleCIDRList returns the known CIDR list of a rule, or a null list when the
+// rule uses a singular CIDR.
+func ruleCIDRList(rule resourcemodels.SecurityRuleModel) types.List {
+	if !rule.SourceCIDRs.IsNull() && !rule.SourceCIDRs.IsUnknown() {
+		return rule.SourceCIDRs
+	}
+	if !rule.DestinationCIDRs.IsNull() && !rule.DestinationCIDRs.IsUnknown() {
+		return rule.DestinationCIDRs
+	}
+	return types.ListNull(types.StringType)
+}
+
+// stringElements returns the known string elements of list.
+func stringElements(list types.List) []string {
+	values := make([]string, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
+
+// CollapseRulesToMatch folds API rules, which hold one CIDR each, back into
+// the source_cidrs/destination_cidrs rules of refList (the plan or prior
+// state). An API rule joins a list rule with the same protocol and port range
+// when its CIDR is in the list; the other rules with that protocol and port
+// range join it as well, so a CIDR added outside Terraform shows up as a
+// change to the list. A rule matching a single-CIDR rule of refList is left
+// alone, as is every rule when refList has no CIDR lists. The result is in
+// API order; ReorderRulesToMatchPlan or ReconcileRulesAsSet aligns it after.
+func CollapseRulesToMatch(ctx context.Context, refList, apiList types.List) types.List {
+	if refList.IsNull() || refList.IsUnknown() || apiList.IsNull() {
+		return apiList
+	}
+
+	var refRules, apiRules []resourcemodels.SecurityRuleModel
+	if refList.ElementsAs(ctx, &refRules, false).HasError() || apiList.ElementsAs(ctx, &apiRules, false).HasError() {
+		return apiList
+	}
+
+	// Single-CIDR rules keep their API rule, so a list never claims it.
+	reserved := make([]bool, len(apiRules))
+	for _, ref := range refRules {
+		if !ruleCIDRList(ref).IsNull() {
+			continue
+		}
+		key := ruleContentKey(ref)
+		for i, api := range apiRules {
+			if !reserved[i] && ruleContentKey(api) == key {
+				reserved[i] = true
+				break
+			}
+		}
+	}
+
+	// groupOf maps each claimed API rule to the index of its list rule.
+	groupOf := make(map[int]int)
+	owners := make(map[string]int)
+	positions := make(map[int]map[string]int)
+	for r, ref := range refRules {
+		list := ruleCIDRList(ref)
+		if list.IsNull() {
+			continue
+		}
+		group := ruleGroupKey(ref)
+		if _, ok := owners[group]; !ok {
+			owners[group] = r
+		}
+		positions[r] = make(map[string]int)
+		for p, cidr := range stringElements(list) {
+			positions[r][cidrKey(cidr)] = p
+		}
+		for i, api := range apiRules {
+			if _, claimed := groupOf[i]; claimed || reserved[i] || ruleGroupKey(api) != group {
+				continue
+			}
+			if _, inList := positions[r][cidrKey(ruleCIDR(api))]; inList {
+				groupOf[i] = r
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return apiList
+	}
+	for i, api := range apiRules {
+		if _, claimed := groupOf[i]; claimed || reserved[i] {
+			continue
+		}
+		if r, ok := owners[ruleGroupKey(api)]; ok {
+			groupOf[i] = r
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range apiRules {
+		if r, ok := groupOf[i]; ok {
+			members[r] = append(members[r], i)
+		}
+	}
+
+	collapsed := make([]resourcemodels.SecurityRuleModel, 0, len(apiRules))
+	emitted := make(map[int]bool)
+	for i, api := range apiRules {
+		r, claimed := groupOf[i]
+		if !claimed {
+			collapsed = append(collapsed, api)
+			continue
+		}
+		if emitted[r] {
+			continue
+		}
+		emitted[r] = true
+
+		// Keep the CIDRs in the order the list declares them; CIDRs the list
+		// does not declare follow in API order.
+		group := members[r]
+		position := func(i int) int {
+			if p, ok := positions[r][cidrKey(ruleCIDR(apiRules[i]))]; ok {
+				return p
+			}
+			return len(positions[r])
+		}
+		sort.SliceStable(group, func(a, b int) bool { return position(group[a]) < position(group[b]) })
+
+		cidrs := make([]attr.Value, 0, len(group))
+		for _, m := range group {
+			cidrs = append(cidrs, types.StringValue(ruleCIDR(apiRules[m])))
+		}
+		rule := api
+		if !refRules[r].SourceCIDRs.IsNull() {
+			rule.SourceCIDR = types.StringNull()
+			rule.SourceCIDRs = types.ListValueMust(types.StringType, cidrs)
 		} else {
-			// If not found, this shouldn't happen in normal cases
-			// Fall back to API list without reordering
-			return apiList
+			rule.DestinationCIDR = types.StringNull()
+			rule.DestinationCIDRs = types.ListValueMust(types.StringType, cidrs)
 		}
+		collapsed = append(collapsed, rule)
 	}
 
-	// Convert back to types.List
-	reorderedList, _ := types.ListValueFrom(ctx, types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"protocol":         types.StringType,
-			"port_range":       types.StringType,
-			"source_cidr":      types.StringType,
-			"destination_cidr": types.StringType,
-		},
-	}, reorderedRules)
+	collapsedList, diags := types.ListValueFrom(ctx, apiList.ElementType(ctx), collapsed)
+	if diags.HasError() {
+		return apiList
+	}
+	return collapsedList
+}
 
-	return reorderedList
+// RulesEqual reports whether two rule lists hold the same rules in the same
+// order, ignoring the computed CIDR attribute of the other direction.
+func RulesEqual(ctx context.Context, a, b types.List) bool {
+	if a.IsUnknown() || b.IsUnknown() {
+		return false
+	}
+	var aRules, bRules []resourcemodels.SecurityRuleModel
+	if a.ElementsAs(ctx, &aRules, false).HasError() || b.ElementsAs(ctx, &bRules, false).HasError() {
+		return false
+	}
+	if len(aRules) != len(bRules) {
+		return false
+	}
+	for i := range aRules {
+		if ruleContentKey(aRules[i]) != ruleContentKey(bRules[i]) {
+			return false
+		}
+	}
+	return true
 }
 
-// MapSDKSecurityGroupToModel converts an SDK security group to the data source model.
+// MapSDKSecurityGroupToModel converts an SDK security group to the data source
+// model. An empty description is null, as in the security group resource.
 func MapSDKSecurityGroupToModel(sg sgmodels.SecurityGroup) (model.SecurityGroupDataModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	_model := model.SecurityGroupDataModel{
 		ID:          types.StringValue(sg.ID),
 		Name:        types.StringValue(sg.Name),
-		Description: types.StringValue(sg.Description),
+		Description: DescriptionValue(sg.Description, types.StringNull()),
 	}
 
 	// Map rules - initialize as empty slices to ensure they're never nil
 	type tmpRule struct {
-		model model.SecurityRuleModel
+		model model.SecurityRuleDataModel
 		min   int
 		max   int
 	}
@@ -286,19 +735,24 @@ func MapSDKSecurityGroupToModel(sg sgmodels.SecurityGroup) (model.SecurityGroupD
 	egressTmp := make([]tmpRule, 0)
 
 	for _, sdkRule := range sg.Rules {
-		rule := model.SecurityRuleModel{
-			Protocol:  types.StringValue(string(sdkRule.Protocol)),
-			PortRange: types.StringValue(formatPortRange(sdkRule.PortMin, sdkRule.PortMax)),
+		rule := model.SecurityRuleDataModel{
+			Protocol:  types.StringValue(normalizeProtocol(string(sdkRule.Protocol))),
+			PortRange: types.StringValue(portRangeForProtocol(string(sdkRule.Protocol), sdkRule.PortMin, sdkRule.PortMax)),
+		}
+
+		portMin, portMax := sdkRule.PortMin, sdkRule.PortMax
+		if !protocolHasPorts(string(sdkRule.Protocol)) {
+			portMin, portMax = 0, 0
 		}
 
 		if sdkRule.Direction == sgmodels.DirectionIngress {
 			rule.SourceCIDR = types.StringValue(sdkRule.RemoteCIDR)
 			rule.DestinationCIDR = types.StringNull()
-			ingressTmp = append(ingressTmp, tmpRule{model: rule, min: sdkRule.PortMin, max: sdkRule.PortMax})
+			ingressTmp = append(ingressTmp, tmpRule{model: rule, min: portMin, max: portMax})
 		} else {
 			rule.SourceCIDR = types.StringNull()
 			rule.DestinationCIDR = types.StringValue(sdkRule.RemoteCIDR)
-			egressTmp = append(egressTmp, tmpRule{model: rule, min: sdkRule.PortMin, max: sdkRule.PortMax})
+			egressTmp = append(egressTmp, tmpRule{model: rule, min: portMin, max: portMax})
 		}
 	}
 
@@ -329,12 +783,12 @@ func MapSDKSecurityGroupToModel(sg sgmodels.SecurityGroup) (model.SecurityGroupD
 		return egressTmp[i].model.DestinationCIDR.ValueString() < egressTmp[j].model.DestinationCIDR.ValueString()
 	})
 
-	ingressRules := make([]model.SecurityRuleModel, 0, len(ingressTmp))
+	ingressRules := make([]model.SecurityRuleDataModel, 0, len(ingressTmp))
 	for _, t := range ingressTmp {
 		ingressRules = append(ingressRules, t.model)
 	}
 
-	egressRules := make([]model.SecurityRuleModel, 0, len(egressTmp))
+	egressRules := make([]model.SecurityRuleDataModel, 0, len(egressTmp))
 	for _, t := range egressTmp {
 		egressRules = append(egressRules, t.model)
 	}
@@ -351,3 +805,318 @@ func SortSecurityGroupsByID(sgs []model.SecurityGroupDataModel) {
 		return sgs[i].ID.ValueString() < sgs[j].ID.ValueString()
 	})
 }
+
+// DefaultRuleAttrTypes is the element type of the default_rules attribute.
+var DefaultRuleAttrTypes = map[string]attr.Type{
+	"direction":  types.StringType,
+	"protocol":   types.StringType,
+	"port_range": types.StringType,
+	"cidr":       types.StringType,
+}
+
+// DefaultSecurityGroupRules returns the rules seeded when create_default_rules
+// is true: all egress traffic over IPv4 and IPv6, plus inbound ICMP so that
+// instances answer ping.
+func DefaultSecurityGroupRules() []sgmodels.SecurityGroupRuleCreateRequest {
+	return []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "::/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolICMP, RemoteCIDR: "0.0.0.0/0"},
+	}
+}
+
+// DefaultRulesValue returns the default_rules state value: the seeded rules
+// when enabled, otherwise an empty list.
+func DefaultRulesValue(ctx context.Context, enabled bool) (types.List, diag.Diagnostics) {
+	rules := make([]resourcemodels.DefaultRuleModel, 0)
+	if enabled {
+		for _, rule := range DefaultSecurityGroupRules() {
+			rules = append(rules, resourcemodels.DefaultRuleModel{
+				Direction: types.StringValue(string(rule.Direction)),
+				Protocol:  types.StringValue(string(rule.Protocol)),
+				PortRange: types.StringValue("all"),
+				CIDR:      types.StringValue(rule.RemoteCIDR),
+			})
+		}
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DefaultRuleAttrTypes}, rules)
+}
+
+// ruleKey identifies a rule by direction, protocol, normalized port range and
+// CIDR, so that user rules, default rules and API rules can be compared.
+func ruleKey(direction sgmodels.Direction, protocol string, portMin, portMax int, cidr string) string {
+	return string(direction) + "|" + normalizeProtocol(protocol) + "|" + portRangeForProtocol(protocol, portMin, portMax) + "|" + cidr
+}
+
+func createRequestKey(rule sgmodels.SecurityGroupRuleCreateRequest) string {
+	portMin, portMax := 0, 0
+	if rule.PortMin != nil {
+		portMin = *rule.PortMin
+	}
+	if rule.PortMax != nil {
+		portMax = *rule.PortMax
+	}
+	return ruleKey(rule.Direction, string(rule.Protocol), portMin, portMax, rule.RemoteCIDR)
+}
+
+// DedupeRules drops repeated rules, keeping the first of each, so that a
+// rule declared twice under rules_as_set is only created once.
+func DedupeRules(rules []sgmodels.SecurityGroupRuleCreateRequest) []sgmodels.SecurityGroupRuleCreateRequest {
+	seen := make(map[string]bool, len(rules))
+	deduped := make([]sgmodels.SecurityGroupRuleCreateRequest, 0, len(rules))
+	for _, rule := range rules {
+		key := createRequestKey(rule)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, rule)
+	}
+	return deduped
+}
+
+// AppendDefaultRules adds the default rules to the user's rules, skipping
+// any default the user already declares so the API never sees a duplicate.
+func AppendDefaultRules(rules []sgmodels.SecurityGroupRuleCreateRequest) []sgmodels.SecurityGroupRuleCreateRequest {
+	declared := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		declared[createRequestKey(rule)] = true
+	}
+	for _, rule := range DefaultSecurityGroupRules() {
+		if !declared[createRequestKey(rule)] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// WithoutDefaultRules drops the API copy of each seeded default rule that the
+// user did not also declare, so that ingress_rule/egress_rule only reflect the
+// configuration. userRules are the rules built from the plan or prior state.
+func WithoutDefaultRules(sdkRules []sgmodels.SecurityGroupRule, userRules []sgmodels.SecurityGroupRuleCreateRequest) []sgmodels.SecurityGroupRule {
+	declared := make(map[string]bool, len(userRules))
+	for _, rule := range userRules {
+		declared[createRequestKey(rule)] = true
+	}
+
+	seeded := make(map[string]bool)
+	for _, rule := range DefaultSecurityGroupRules() {
+		if key := createRequestKey(rule); !declared[key] {
+			seeded[key] = true
+		}
+	}
+
+	filtered := make([]sgmodels.SecurityGroupRule, 0, len(sdkRules))
+	for _, rule := range sdkRules {
+		key := ruleKey(rule.Direction, string(rule.Protocol), rule.PortMin, rule.PortMax, rule.RemoteCIDR)
+		if seeded[key] {
+			// Drop a single copy only.
+			delete(seeded, key)
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+// PlanRuleReplacement splits replacing a group's existing rules with rules
+// into the rules to create and the existing rules to delete. An existing rule
+// equivalent to a planned one is kept instead of being created again, since
+// the platform may reject the copy as a duplicate.
+func PlanRuleReplacement(existing []sgmodels.SecurityGroupRule, rules []sgmodels.SecurityGroupRuleCreateRequest) (toCreate []sgmodels.SecurityGroupRuleCreateRequest, toDelete []sgmodels.SecurityGroupRule) {
+	planned := make(map[string]int, len(rules))
+	for _, rule := range rules {
+		planned[createRequestKey(rule)]++
+	}
+
+	kept := make(map[string]int)
+	for _, rule := range existing {
+		key := ruleKey(rule.Direction, string(rule.Protocol), rule.PortMin, rule.PortMax, rule.RemoteCIDR)
+		if kept[key] < planned[key] {
+			kept[key]++
+			continue
+		}
+		toDelete = append(toDelete, rule)
+	}
+
+	for _, rule := range rules {
+		key := createRequestKey(rule)
+		if kept[key] > 0 {
+			kept[key]--
+			continue
+		}
+		toCreate = append(toCreate, rule)
+	}
+	return toCreate, toDelete
+}
+
+// SecurityGroupNameError is returned by ResolveSecurityGroupNames for names
+// that match no group or several groups. Problems maps each such name to the
+// reason it did not resolve.
+type SecurityGroupNameError struct {
+	Problems map[string]string
+}
+
+func (e *SecurityGroupNameError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+	return strings.Join(problems, "; ")
+}
+
+// ResolveSecurityGroupNames maps each of names to the ID of the one security
+// group with exactly that name. Names that match no group or several groups
+// are left out of the result and reported together in a
+// *SecurityGroupNameError.
+func ResolveSecurityGroupNames(ctx context.Context, projectClient *cloudsdk.ProjectClient, names []string) (map[string]string, error) {
+	if len(names) == 0 {
+		return map[string]string{}, nil
+	}
+	if projectClient == nil {
+		return nil, fmt.Errorf("no project client available")
+	}
+
+	groups, err := projectClient.VPS().SecurityGroups().List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	idsByName := make(map[string][]string)
+	for _, sg := range groups {
+		idsByName[sg.SecurityGroup.Name] = append(idsByName[sg.SecurityGroup.Name], sg.SecurityGroup.ID)
+	}
+
+	resolved := make(map[string]string, len(names))
+	problems := make(map[string]string)
+	for _, name := range names {
+		ids := idsByName[name]
+		switch len(ids) {
+		case 0:
+			problems[name] = fmt.Sprintf("no security group is named %q", name)
+		case 1:
+			resolved[name] = ids[0]
+		default:
+			sort.Strings(ids)
+			problems[name] = fmt.Sprintf("%d security groups are named %q (IDs: %s)", len(ids), name, strings.Join(ids, ", "))
+		}
+	}
+
+	if len(problems) > 0 {
+		return resolved, &SecurityGroupNameError{Problems: problems}
+	}
+	return resolved, nil
+}
+
+// DefaultSecurityGroupName is the name of the security group the platform
+// creates in every project and attaches to NICs created without one.
+const DefaultSecurityGroupName = "default"
+
+// FindDefaultSecurityGroup returns the project's platform-provided default
+// security group. It fails when the project has none, or several groups
+// named "default" so that the platform's one cannot be told apart.
+func FindDefaultSecurityGroup(ctx context.Context, projectClient *cloudsdk.ProjectClient) (*sgsdk.SecurityGroupResource, error) {
+	if projectClient == nil {
+		return nil, fmt.Errorf("no project client available")
+	}
+
+	groups, err := projectClient.VPS().SecurityGroups().List(ctx, &sgmodels.ListSecurityGroupsOptions{Name: DefaultSecurityGroupName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	var matches []*sgsdk.SecurityGroupResource
+	for _, sg := range groups {
+		// The name filter may match partially
+		if sg.SecurityGroup.Name == DefaultSecurityGroupName {
+			matches = append(matches, sg)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no security group is named %q in this project", DefaultSecurityGroupName)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, sg := range matches {
+			ids[i] = sg.SecurityGroup.ID
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("%d security groups are named %q (IDs: %s); import the platform's one by ID", len(ids), DefaultSecurityGroupName, strings.Join(ids, ", "))
+	}
+}
+
+// RuleReplacement reports the progress of ReplaceSecurityGroupRules.
+type RuleReplacement struct {
+	// Completed counts the rule creations and deletions done out of Total.
+	Completed int
+	Total     int
+	// Deferred counts new rules created only after the old rules were
+	// deleted, leaving servers briefly without them.
+	Deferred int
+}
+
+// ReplaceSecurityGroupRules gives a group exactly rules, creating the new
+// rules before deleting the old ones as PlanRuleReplacement splits them. A new
+// rule the platform rejects as a duplicate of a rule about to be deleted is
+// created again once the old rules are gone. Rules that are already deleted
+// are skipped. When ctx is done no further rule is touched and ctx.Err() is
+// returned; the result tells callers how far the replacement got.
+func ReplaceSecurityGroupRules(ctx context.Context, rulesClient interface {
+	Create(context.Context, sgmodels.SecurityGroupRuleCreateRequest) (*sgmodels.SecurityGroupRule, error)
+	Delete(context.Context, string) error
+}, existing []sgmodels.SecurityGroupRule, rules []sgmodels.SecurityGroupRuleCreateRequest) (RuleReplacement, error) {
+	toCreate, toDelete := PlanRuleReplacement(existing, rules)
+	result := RuleReplacement{Total: len(toCreate) + len(toDelete)}
+
+	var deferred []sgmodels.SecurityGroupRuleCreateRequest
+	for i, rule := range toCreate {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if _, err := rulesClient.Create(ctx, rule); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			if IsConflict(err) {
+				// The platform considers the rule a duplicate of one about
+				// to be deleted; add it and the rest once the old ones are gone
+				deferred = toCreate[i:]
+				break
+			}
+			return result, fmt.Errorf("creating security group rule: %w", err)
+		}
+		result.Completed++
+	}
+
+	for _, rule := range toDelete {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if err := rulesClient.Delete(ctx, rule.ID); err != nil && !IsNotFound(err) {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			return result, fmt.Errorf("deleting security group rule %s: %w", rule.ID, err)
+		}
+		result.Completed++
+	}
+
+	result.Deferred = len(deferred)
+	for _, rule := range deferred {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if _, err := rulesClient.Create(ctx, rule); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			return result, fmt.Errorf("creating security group rule: %w", err)
+		}
+		result.Completed++
+	}
+	return result, nil
+}
@@ -0,0 +1,944 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAppendDefaultRules_SkipsDeclaredDefaults(t *testing.T) {
+	t.Parallel()
+
+	port := 22
+	userRules := []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &port, PortMax: &port, RemoteCIDR: "0.0.0.0/0"},
+		// Same as a default rule
+		{Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "0.0.0.0/0"},
+	}
+
+	rules := AppendDefaultRules(userRules)
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules (2 user + 2 remaining defaults), got %d: %+v", len(rules), rules)
+	}
+}
+
+func TestWithoutDefaultRules(t *testing.T) {
+	t.Parallel()
+
+	sdkRules := []sgmodels.SecurityGroupRule{
+		{ID: "1", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "2", Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, PortMin: 0, PortMax: 0, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "3", Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, PortMin: 1, PortMax: 65535, RemoteCIDR: "::/0"},
+		{ID: "4", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolICMP, RemoteCIDR: "0.0.0.0/0"},
+	}
+
+	tests := []struct {
+		name      string
+		userRules []sgmodels.SecurityGroupRuleCreateRequest
+		expected  []string
+	}{
+		{
+			name:     "no user rules",
+			expected: []string{"1"},
+		},
+		{
+			name: "default also declared by user",
+			userRules: []sgmodels.SecurityGroupRuleCreateRequest{
+				{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolICMP, RemoteCIDR: "0.0.0.0/0"},
+			},
+			expected: []string{"1", "4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := WithoutDefaultRules(sdkRules, tt.userRules)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected rules %v, got %+v", tt.expected, got)
+			}
+			for i, rule := range got {
+				if rule.ID != tt.expected[i] {
+					t.Errorf("rule %d: expected ID %s, got %s", i, tt.expected[i], rule.ID)
+				}
+			}
+		})
+	}
+}
+
+func securityRuleList(t *testing.T, rules ...resourcemodels.SecurityRuleModel) types.List {
+	t.Helper()
+
+	list, diags := types.ListValueFrom(context.Background(), types.ObjectType{AttrTypes: SecurityRuleAttrTypes}, rules)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return list
+}
+
+func ingressRule(protocol, portRange, cidr string) resourcemodels.SecurityRuleModel {
+	return resourcemodels.SecurityRuleModel{
+		Protocol:         types.StringValue(protocol),
+		PortRange:        types.StringValue(portRange),
+		SourceCIDR:       types.StringValue(cidr),
+		SourceCIDRs:      types.ListNull(types.StringType),
+		DestinationCIDR:  types.StringNull(),
+		DestinationCIDRs: types.ListNull(types.StringType),
+	}
+}
+
+func TestBuildSecurityGroupRules_ICMPv6(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		rule        resourcemodels.SecurityRuleModel
+		expectError bool
+	}{
+		{
+			name: "ipv6 cidr",
+			rule: ingressRule("icmpv6", "all", "::/0"),
+		},
+		{
+			name: "mixed case",
+			rule: ingressRule("ICMPv6", "all", "2001:db8::/32"),
+		},
+		{
+			name:        "ipv4 cidr",
+			rule:        ingressRule("icmpv6", "all", "0.0.0.0/0"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := resourcemodels.SecurityGroupResourceModel{
+				IngressRule: securityRuleList(t, tt.rule),
+				EgressRule:  types.ListNull(types.ObjectType{}),
+			}
+			rules, diags := BuildSecurityGroupRules(context.Background(), plan)
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, diags)
+			}
+			if tt.expectError {
+				return
+			}
+			if len(rules) != 1 || rules[0].Protocol != ProtocolICMPv6 || rules[0].PortMin != nil || rules[0].PortMax != nil {
+				t.Errorf("expected a portless icmpv6 rule, got %+v", rules)
+			}
+		})
+	}
+}
+
+func TestMapSDKRulesToTerraform_ICMPv6(t *testing.T) {
+	t.Parallel()
+
+	ingress, _, diags := MapSDKRulesToTerraform(context.Background(), []sgmodels.SecurityGroupRule{
+		{Direction: sgmodels.DirectionIngress, Protocol: "ipv6-icmp", RemoteCIDR: "::/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: ProtocolICMPv6, RemoteCIDR: "2001:db8::/32"},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var rules []resourcemodels.SecurityRuleModel
+	ingress.ElementsAs(context.Background(), &rules, false)
+	for i, rule := range rules {
+		if rule.Protocol.ValueString() != "icmpv6" || rule.PortRange.ValueString() != "all" {
+			t.Errorf("rule %d: expected icmpv6/all, got %s/%s", i, rule.Protocol.ValueString(), rule.PortRange.ValueString())
+		}
+	}
+}
+
+func TestMapSDKRulesToTerraform_ICMPPortRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		protocol sgmodels.Protocol
+		min, max int
+		expected string
+	}{
+		{name: "unset", protocol: "icmp", min: 0, max: 0, expected: "icmp"},
+		{name: "echo request type", protocol: "icmp", min: 8, max: 0, expected: "icmp"},
+		{name: "destination unreachable type and code", protocol: "icmp", min: 3, max: 4, expected: "icmp"},
+		{name: "negative", protocol: "icmp", min: -1, max: -1, expected: "icmp"},
+		{name: "full port range", protocol: "icmp", min: 1, max: 65535, expected: "icmp"},
+		{name: "uppercase", protocol: "ICMP", min: 8, max: 8, expected: "icmp"},
+		{name: "protocol number", protocol: "1", min: 8, max: 0, expected: "icmp"},
+		{name: "icmpv6 protocol number", protocol: "58", min: 128, max: 0, expected: "icmpv6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ingress, _, diags := MapSDKRulesToTerraform(context.Background(), []sgmodels.SecurityGroupRule{
+				{Direction: sgmodels.DirectionIngress, Protocol: tt.protocol, PortMin: tt.min, PortMax: tt.max, RemoteCIDR: "0.0.0.0/0"},
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var rules []resourcemodels.SecurityRuleModel
+			ingress.ElementsAs(context.Background(), &rules, false)
+			if len(rules) != 1 || rules[0].Protocol.ValueString() != tt.expected || rules[0].PortRange.ValueString() != "all" {
+				t.Errorf("expected %s/all, got %+v", tt.expected, rules)
+			}
+		})
+	}
+}
+
+func TestValidateRulePortRanges(t *testing.T) {
+	t.Parallel()
+
+	unknownPort := ingressRule("icmp", "", "0.0.0.0/0")
+	unknownPort.PortRange = types.StringUnknown()
+
+	tests := []struct {
+		name          string
+		ingress       []resourcemodels.SecurityRuleModel
+		expectedPaths []string
+	}{
+		{
+			name:    "valid",
+			ingress: []resourcemodels.SecurityRuleModel{ingressRule("icmp", "all", "0.0.0.0/0"), ingressRule("tcp", "22", "0.0.0.0/0")},
+		},
+		{
+			name:          "icmp with port",
+			ingress:       []resourcemodels.SecurityRuleModel{ingressRule("tcp", "22", "0.0.0.0/0"), ingressRule("ICMP", "8", "0.0.0.0/0")},
+			expectedPaths: []string{"ingress_rule[1].port_range"},
+		},
+		{
+			name:    "unknown port range",
+			ingress: []resourcemodels.SecurityRuleModel{unknownPort},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := resourcemodels.SecurityGroupResourceModel{
+				IngressRule: securityRuleList(t, tt.ingress...),
+				EgressRule:  types.ListUnknown(securityRuleList(t).ElementType(context.Background())),
+			}
+			var paths []string
+			for _, d := range ValidateRulePortRanges(context.Background(), model) {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					paths = append(paths, withPath.Path().String())
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.expectedPaths) {
+				t.Errorf("expected errors at %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestReorderRulesToMatchPlan_ICMPFamilies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	plan := securityRuleList(t,
+		ingressRule("icmpv6", "all", "::/0"),
+		ingressRule("icmp", "all", "0.0.0.0/0"),
+	)
+	api := securityRuleList(t,
+		ingressRule("icmp", "all", "0.0.0.0/0"),
+		ingressRule("icmpv6", "all", "0::/0"),
+	)
+
+	var got []resourcemodels.SecurityRuleModel
+	ReorderRulesToMatchPlan(ctx, plan, api).ElementsAs(ctx, &got, false)
+	if len(got) != 2 || got[0].Protocol.ValueString() != "icmpv6" || got[1].Protocol.ValueString() != "icmp" {
+		t.Errorf("expected icmpv6 then icmp, got %+v", got)
+	}
+	if got[0].SourceCIDR.ValueString() != "0::/0" {
+		t.Errorf("expected the API rule to be kept, got CIDR %s", got[0].SourceCIDR.ValueString())
+	}
+}
+
+func TestReorderRulesToMatchPlan_Drift(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ssh := ingressRule("tcp", "22", "203.0.113.0/24")
+	https := ingressRule("tcp", "443", "0.0.0.0/0")
+	dns := ingressRule("udp", "53", "10.0.0.0/8")
+
+	tests := []struct {
+		name     string
+		prior    []resourcemodels.SecurityRuleModel
+		api      []resourcemodels.SecurityRuleModel
+		expected []resourcemodels.SecurityRuleModel
+	}{
+		{
+			name:     "rule added outside terraform follows the managed rules",
+			prior:    []resourcemodels.SecurityRuleModel{https, ssh},
+			api:      []resourcemodels.SecurityRuleModel{dns, ssh, https},
+			expected: []resourcemodels.SecurityRuleModel{https, ssh, dns},
+		},
+		{
+			name:     "rule removed outside terraform leaves the others in place",
+			prior:    []resourcemodels.SecurityRuleModel{dns, https, ssh},
+			api:      []resourcemodels.SecurityRuleModel{ssh, dns},
+			expected: []resourcemodels.SecurityRuleModel{dns, ssh},
+		},
+		{
+			name:     "cidr changed outside terraform keeps the position",
+			prior:    []resourcemodels.SecurityRuleModel{https, ssh},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "22", "198.51.100.0/24"), https, dns},
+			expected: []resourcemodels.SecurityRuleModel{https, ingressRule("tcp", "22", "198.51.100.0/24"), dns},
+		},
+		{
+			name:     "repeated rule added outside terraform",
+			prior:    []resourcemodels.SecurityRuleModel{ssh, https},
+			api:      []resourcemodels.SecurityRuleModel{https, ssh, ssh},
+			expected: []resourcemodels.SecurityRuleModel{ssh, https, ssh},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ReorderRulesToMatchPlan(ctx, securityRuleList(t, tt.prior...), securityRuleList(t, tt.api...))
+			if expected := securityRuleList(t, tt.expected...); !got.Equal(expected) {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		portRange   string
+		min, max    int
+		expectError bool
+	}{
+		{portRange: "all", min: 1, max: 65535},
+		{portRange: "ALL", min: 1, max: 65535},
+		{portRange: "22", min: 22, max: 22},
+		{portRange: "8000-8100", min: 8000, max: 8100},
+		{portRange: "1-2-3", expectError: true},
+		{portRange: "ssh", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.portRange, func(t *testing.T) {
+			t.Parallel()
+
+			portMin, portMax, err := parsePortRange(tt.portRange)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %d-%d", *portMin, *portMax)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *portMin != tt.min || *portMax != tt.max {
+				t.Errorf("expected %d-%d, got %d-%d", tt.min, tt.max, *portMin, *portMax)
+			}
+		})
+	}
+}
+
+func TestPortRangeForProtocol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		protocol string
+		min, max int
+		expected string
+	}{
+		{name: "tcp single", protocol: "tcp", min: 22, max: 22, expected: "22"},
+		{name: "tcp range", protocol: "tcp", min: 8000, max: 8100, expected: "8000-8100"},
+		{name: "tcp full range", protocol: "tcp", min: 1, max: 65535, expected: "all"},
+		{name: "udp unset", protocol: "udp", min: 0, max: 0, expected: "all"},
+		{name: "any unset", protocol: "any", min: 0, max: 0, expected: "all"},
+		{name: "any full range", protocol: "any", min: 1, max: 65535, expected: "all"},
+		{name: "any zero to max", protocol: "any", min: 0, max: 65535, expected: "all"},
+		{name: "any negative", protocol: "any", min: -1, max: -1, expected: "all"},
+		{name: "icmp type code", protocol: "icmp", min: 8, max: 0, expected: "all"},
+		{name: "icmpv6 alias", protocol: "ipv6-icmp", min: 0, max: 0, expected: "all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := portRangeForProtocol(tt.protocol, tt.min, tt.max); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityGroupRules_AnyProtocol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		rule        resourcemodels.SecurityRuleModel
+		expectError bool
+	}{
+		{
+			name: "all ports",
+			rule: ingressRule("any", "all", "0.0.0.0/0"),
+		},
+		{
+			name:        "single port",
+			rule:        ingressRule("any", "22", "0.0.0.0/0"),
+			expectError: true,
+		},
+		{
+			name:        "icmp with range",
+			rule:        ingressRule("icmp", "1-10", "0.0.0.0/0"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := resourcemodels.SecurityGroupResourceModel{
+				IngressRule: securityRuleList(t, tt.rule),
+				EgressRule:  types.ListNull(types.ObjectType{}),
+			}
+			rules, diags := BuildSecurityGroupRules(context.Background(), plan)
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, diags)
+			}
+			if tt.expectError {
+				return
+			}
+			if len(rules) != 1 || rules[0].PortMin != nil || rules[0].PortMax != nil {
+				t.Fatalf("expected a portless rule, got %+v", rules)
+			}
+
+			// Whatever ports the API reports back, the rule reads as "all".
+			for _, ports := range [][2]int{{0, 0}, {1, 65535}, {0, 65535}, {-1, -1}} {
+				ingress, _, diags := MapSDKRulesToTerraform(context.Background(), []sgmodels.SecurityGroupRule{
+					{Direction: sgmodels.DirectionIngress, Protocol: rules[0].Protocol, PortMin: ports[0], PortMax: ports[1], RemoteCIDR: rules[0].RemoteCIDR},
+				})
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				var got []resourcemodels.SecurityRuleModel
+				ingress.ElementsAs(context.Background(), &got, false)
+				if len(got) != 1 || got[0].PortRange.ValueString() != "all" {
+					t.Errorf("ports %v: expected port_range all, got %+v", ports, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSecurityGroupRules_AnyEgressRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// The default egress rule most configurations declare.
+	egress := resourcemodels.SecurityRuleModel{
+		Protocol:         types.StringValue("any"),
+		PortRange:        types.StringValue("all"),
+		SourceCIDR:       types.StringNull(),
+		SourceCIDRs:      types.ListNull(types.StringType),
+		DestinationCIDR:  types.StringValue("0.0.0.0/0"),
+		DestinationCIDRs: types.ListNull(types.StringType),
+	}
+	plan := resourcemodels.SecurityGroupResourceModel{
+		IngressRule: types.ListNull(types.ObjectType{}),
+		EgressRule:  securityRuleList(t, egress),
+	}
+
+	rules, diags := BuildSecurityGroupRules(ctx, plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	expected := []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "0.0.0.0/0"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, rules)
+	}
+
+	// The API echoes the rule back, possibly with the full port range.
+	for _, ports := range [][2]int{{0, 0}, {1, 65535}} {
+		ingress, egressList, diags := MapSDKRulesToTerraform(ctx, []sgmodels.SecurityGroupRule{
+			{ID: "rule-1", Direction: rules[0].Direction, Protocol: rules[0].Protocol, PortMin: ports[0], PortMax: ports[1], RemoteCIDR: rules[0].RemoteCIDR},
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(ingress.Elements()) != 0 {
+			t.Errorf("ports %v: expected no ingress rules, got %v", ports, ingress)
+		}
+
+		var got []resourcemodels.SecurityRuleModel
+		egressList.ElementsAs(ctx, &got, false)
+		if len(got) != 1 || !reflect.DeepEqual(got[0], egress) {
+			t.Errorf("ports %v: expected the rule to read back as %+v, got %+v", ports, egress, got)
+		}
+		if !RulesEqual(ctx, plan.EgressRule, egressList) {
+			t.Errorf("ports %v: expected no diff against the configured rule", ports)
+		}
+	}
+}
+
+func ingressRuleCIDRs(protocol, portRange string, cidrs ...string) resourcemodels.SecurityRuleModel {
+	rule := ingressRule(protocol, portRange, "")
+	rule.SourceCIDR = types.StringNull()
+	rule.SourceCIDRs, _ = types.ListValueFrom(context.Background(), types.StringType, cidrs)
+	return rule
+}
+
+func TestBuildSecurityGroupRules_CIDRLists(t *testing.T) {
+	t.Parallel()
+
+	egress := ingressRule("any", "all", "")
+	egress.SourceCIDR = types.StringNull()
+	egress.DestinationCIDRs, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"0.0.0.0/0", "::/0"})
+
+	plan := resourcemodels.SecurityGroupResourceModel{
+		IngressRule: securityRuleList(t, ingressRuleCIDRs("tcp", "443", "10.0.0.0/8", "192.168.0.0/16"), ingressRule("tcp", "22", "203.0.113.0/24")),
+		EgressRule:  securityRuleList(t, egress),
+	}
+	rules, diags := BuildSecurityGroupRules(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got []string
+	for _, rule := range rules {
+		got = append(got, createRequestKey(rule))
+	}
+	expected := []string{
+		"ingress|tcp|443|10.0.0.0/8",
+		"ingress|tcp|443|192.168.0.0/16",
+		"ingress|tcp|22|203.0.113.0/24",
+		"egress|any|all|0.0.0.0/0",
+		"egress|any|all|::/0",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCollapseRulesToMatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	https := ingressRuleCIDRs("tcp", "443", "10.0.0.0/8", "192.168.0.0/16")
+	ssh := ingressRule("tcp", "22", "203.0.113.0/24")
+
+	tests := []struct {
+		name     string
+		ref      []resourcemodels.SecurityRuleModel
+		api      []resourcemodels.SecurityRuleModel
+		expected []resourcemodels.SecurityRuleModel
+	}{
+		{
+			name:     "round trip",
+			ref:      []resourcemodels.SecurityRuleModel{https, ssh},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "192.168.0.0/16"), ssh, ingressRule("tcp", "443", "10.0.0.0/8")},
+			expected: []resourcemodels.SecurityRuleModel{https, ssh},
+		},
+		{
+			name:     "cidr added outside terraform joins the list",
+			ref:      []resourcemodels.SecurityRuleModel{https},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "172.16.0.0/12"), ingressRule("tcp", "443", "10.0.0.0/8"), ingressRule("tcp", "443", "192.168.0.0/16")},
+			expected: []resourcemodels.SecurityRuleModel{ingressRuleCIDRs("tcp", "443", "10.0.0.0/8", "192.168.0.0/16", "172.16.0.0/12")},
+		},
+		{
+			name:     "cidr removed outside terraform",
+			ref:      []resourcemodels.SecurityRuleModel{https, ssh},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "10.0.0.0/8"), ssh},
+			expected: []resourcemodels.SecurityRuleModel{ingressRuleCIDRs("tcp", "443", "10.0.0.0/8"), ssh},
+		},
+		{
+			name:     "single cidr rule with the same ports keeps its rule",
+			ref:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "203.0.113.0/24"), https},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "10.0.0.0/8"), ingressRule("tcp", "443", "203.0.113.0/24"), ingressRule("tcp", "443", "192.168.0.0/16")},
+			expected: []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "203.0.113.0/24"), https},
+		},
+		{
+			name:     "no cidr lists",
+			ref:      []resourcemodels.SecurityRuleModel{ssh},
+			api:      []resourcemodels.SecurityRuleModel{ingressRule("tcp", "443", "10.0.0.0/8"), ssh},
+			expected: []resourcemodels.SecurityRuleModel{ssh, ingressRule("tcp", "443", "10.0.0.0/8")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref := securityRuleList(t, tt.ref...)
+			got := ReorderRulesToMatchPlan(ctx, ref, CollapseRulesToMatch(ctx, ref, securityRuleList(t, tt.api...)))
+			if expected := securityRuleList(t, tt.expected...); !got.Equal(expected) {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestReconcileRulesAsSet(t *testing.T) {
+	t.Parallel()
+
+	ssh := ingressRule("tcp", "22", "0.0.0.0/0")
+	web := ingressRule("tcp", "443", "0.0.0.0/0")
+	ping := ingressRule("icmp", "all", "0.0.0.0/0")
+
+	tests := []struct {
+		name     string
+		plan     []resourcemodels.SecurityRuleModel
+		api      []resourcemodels.SecurityRuleModel
+		expected []resourcemodels.SecurityRuleModel
+	}{
+		{
+			name:     "reordered",
+			plan:     []resourcemodels.SecurityRuleModel{ssh, web, ping},
+			api:      []resourcemodels.SecurityRuleModel{ping, ssh, web},
+			expected: []resourcemodels.SecurityRuleModel{ssh, web, ping},
+		},
+		{
+			name:     "duplicate collapsed by the api",
+			plan:     []resourcemodels.SecurityRuleModel{ssh, web, ssh},
+			api:      []resourcemodels.SecurityRuleModel{web, ssh},
+			expected: []resourcemodels.SecurityRuleModel{ssh, web, ssh},
+		},
+		{
+			name:     "duplicate returned by the api",
+			plan:     []resourcemodels.SecurityRuleModel{web, ssh},
+			api:      []resourcemodels.SecurityRuleModel{ssh, web, ssh},
+			expected: []resourcemodels.SecurityRuleModel{web, ssh},
+		},
+		{
+			name:     "rule removed outside terraform",
+			plan:     []resourcemodels.SecurityRuleModel{ssh, web},
+			api:      []resourcemodels.SecurityRuleModel{web},
+			expected: []resourcemodels.SecurityRuleModel{web},
+		},
+		{
+			name:     "rule added outside terraform",
+			plan:     []resourcemodels.SecurityRuleModel{ssh},
+			api:      []resourcemodels.SecurityRuleModel{ping, ssh},
+			expected: []resourcemodels.SecurityRuleModel{ssh, ping},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ReconcileRulesAsSet(context.Background(), securityRuleList(t, tt.plan...), securityRuleList(t, tt.api...))
+			if expected := securityRuleList(t, tt.expected...); !got.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestRulesEqualAsSet(t *testing.T) {
+	t.Parallel()
+
+	ssh := ingressRule("tcp", "22", "0.0.0.0/0")
+	web := ingressRule("tcp", "443", "0.0.0.0/0")
+	ctx := context.Background()
+
+	if !RulesEqualAsSet(ctx, securityRuleList(t, ssh, web, ssh), securityRuleList(t, web, ssh)) {
+		t.Error("expected reordered and repeated rules to be equal as a set")
+	}
+	if RulesEqual(ctx, securityRuleList(t, ssh, web), securityRuleList(t, web, ssh)) {
+		t.Error("expected reordered rules to differ in order")
+	}
+	if RulesEqualAsSet(ctx, securityRuleList(t, ssh), securityRuleList(t, ssh, web)) {
+		t.Error("expected different rule sets to differ")
+	}
+}
+
+func TestDedupeRules(t *testing.T) {
+	t.Parallel()
+
+	port := 22
+	rules := DedupeRules([]sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &port, PortMax: &port, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolTCP, PortMin: &port, PortMax: &port, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: "TCP", PortMin: &port, PortMax: &port, RemoteCIDR: "0.0.0.0/0"},
+	})
+	if len(rules) != 2 || rules[0].Direction != sgmodels.DirectionIngress || rules[1].Direction != sgmodels.DirectionEgress {
+		t.Errorf("expected one ingress and one egress rule, got %+v", rules)
+	}
+}
+
+func TestPlanRuleReplacement(t *testing.T) {
+	t.Parallel()
+
+	ssh, https := 22, 443
+	existing := []sgmodels.SecurityGroupRule{
+		{ID: "ssh", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "ssh-copy", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "http", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 80, PortMax: 80, RemoteCIDR: "0.0.0.0/0"},
+	}
+	rules := []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionIngress, Protocol: "TCP", PortMin: &ssh, PortMax: &ssh, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &https, PortMax: &https, RemoteCIDR: "0.0.0.0/0"},
+	}
+
+	toCreate, toDelete := PlanRuleReplacement(existing, rules)
+	if len(toCreate) != 1 || *toCreate[0].PortMin != 443 {
+		t.Errorf("expected only the 443 rule to be created, got %+v", toCreate)
+	}
+	var deleted []string
+	for _, rule := range toDelete {
+		deleted = append(deleted, rule.ID)
+	}
+	if !reflect.DeepEqual(deleted, []string{"ssh-copy", "http"}) {
+		t.Errorf("expected the duplicate ssh and the http rule to be deleted, got %v", deleted)
+	}
+}
+
+func TestResolveSecurityGroupNames(t *testing.T) {
+	t.Parallel()
+
+	projectClient := newTestProjectClient(t, "/security_groups", `{"security_groups":[
+		{"id":"sg-1","name":"web"},
+		{"id":"sg-2","name":"ssh"},
+		{"id":"sg-4","name":"shared"},
+		{"id":"sg-3","name":"shared"}
+	]}`)
+
+	tests := []struct {
+		name      string
+		names     []string
+		expected  map[string]string
+		expectErr string
+	}{
+		{
+			name:     "no names",
+			expected: map[string]string{},
+		},
+		{
+			name:     "exact matches",
+			names:    []string{"web", "ssh", "web"},
+			expected: map[string]string{"web": "sg-1", "ssh": "sg-2"},
+		},
+		{
+			name:      "unknown name",
+			names:     []string{"web", "we"},
+			expected:  map[string]string{"web": "sg-1"},
+			expectErr: `no security group is named "we"`,
+		},
+		{
+			name:      "ambiguous name",
+			names:     []string{"shared"},
+			expected:  map[string]string{},
+			expectErr: "(IDs: sg-3, sg-4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ResolveSecurityGroupNames(context.Background(), projectClient, tt.names)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectErr, err)
+			}
+			var nameErr *SecurityGroupNameError
+			if tt.expectErr != "" && (!errors.As(err, &nameErr) || len(nameErr.Problems) != 1) {
+				t.Errorf("expected a SecurityGroupNameError with one problem, got %#v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMapSDKSecurityGroupToModel_Description(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		description string
+		expected    types.String
+	}{
+		{name: "set", description: "web tier", expected: types.StringValue("web tier")},
+		{name: "empty", description: "", expected: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sg, diags := MapSDKSecurityGroupToModel(sgmodels.SecurityGroup{ID: "sg-1", Name: "web", Description: tt.description})
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !sg.Description.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, sg.Description)
+			}
+		})
+	}
+}
+
+func TestFindDefaultSecurityGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		expectedID string
+		expectErr  string
+	}{
+		{
+			name:       "platform default",
+			body:       `{"security_groups":[{"id":"sg-1","name":"default-web"},{"id":"sg-2","name":"default"}]}`,
+			expectedID: "sg-2",
+		},
+		{
+			name:      "no default",
+			body:      `{"security_groups":[{"id":"sg-1","name":"web"}]}`,
+			expectErr: `no security group is named "default"`,
+		},
+		{
+			name:      "several defaults",
+			body:      `{"security_groups":[{"id":"sg-3","name":"default"},{"id":"sg-2","name":"default"}]}`,
+			expectErr: "(IDs: sg-2, sg-3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectClient := newTestProjectClient(t, "/security_groups", tt.body)
+
+			sg, err := FindDefaultSecurityGroup(context.Background(), projectClient)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sg.SecurityGroup.ID != tt.expectedID {
+				t.Errorf("expected security group %s, got %s", tt.expectedID, sg.SecurityGroup.ID)
+			}
+		})
+	}
+}
+
+// fakeRulesClient records rule operations. The first create of a rule whose
+// port is in conflicts fails as a duplicate, and deleting a rule in missing
+// reports it not found.
+type fakeRulesClient struct {
+	calls     []string
+	conflicts map[int]bool
+	missing   map[string]bool
+}
+
+func (f *fakeRulesClient) Create(ctx context.Context, rule sgmodels.SecurityGroupRuleCreateRequest) (*sgmodels.SecurityGroupRule, error) {
+	port := *rule.PortMin
+	if f.conflicts[port] {
+		f.conflicts[port] = false
+		return nil, errors.New("409 Conflict: security group rule already exists")
+	}
+	f.calls = append(f.calls, fmt.Sprintf("create %d", port))
+	return &sgmodels.SecurityGroupRule{}, nil
+}
+
+func (f *fakeRulesClient) Delete(ctx context.Context, ruleID string) error {
+	if f.missing[ruleID] {
+		return errors.New("404: security group rule not found")
+	}
+	f.calls = append(f.calls, "delete "+ruleID)
+	return nil
+}
+
+func TestReplaceSecurityGroupRules(t *testing.T) {
+	t.Parallel()
+
+	ssh, http, https := 22, 80, 443
+	existing := []sgmodels.SecurityGroupRule{
+		{ID: "ssh", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "http", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 80, PortMax: 80, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "gone", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 8080, PortMax: 8080, RemoteCIDR: "0.0.0.0/0"},
+	}
+	rules := []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &ssh, PortMax: &ssh, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &https, PortMax: &https, RemoteCIDR: "0.0.0.0/0"},
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &http, PortMax: &http, RemoteCIDR: "10.0.0.0/8"},
+	}
+
+	client := &fakeRulesClient{conflicts: map[int]bool{80: true}, missing: map[string]bool{"gone": true}}
+	replaced, err := ReplaceSecurityGroupRules(context.Background(), client, existing, rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The kept ssh rule is not recreated, and the conflicting rule is added
+	// once the old rules are deleted
+	expected := []string{"create 443", "delete http", "create 80"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected %v, got %v", expected, client.calls)
+	}
+	if expected := (RuleReplacement{Completed: 4, Total: 4, Deferred: 1}); replaced != expected {
+		t.Errorf("expected %+v, got %+v", expected, replaced)
+	}
+}
+
+func TestReplaceSecurityGroupRules_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ssh := 22
+	existing := []sgmodels.SecurityGroupRule{
+		{ID: "http", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 80, PortMax: 80, RemoteCIDR: "0.0.0.0/0"},
+	}
+	rules := []sgmodels.SecurityGroupRuleCreateRequest{
+		{Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: &ssh, PortMax: &ssh, RemoteCIDR: "0.0.0.0/0"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &fakeRulesClient{}
+	replaced, err := ReplaceSecurityGroupRules(ctx, client, existing, rules)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no rule operations, got %v", client.calls)
+	}
+	if expected := (RuleReplacement{Total: 2}); replaced != expected {
+		t.Errorf("expected %+v, got %+v", expected, replaced)
+	}
+}
@@ -5,13 +5,20 @@ package helper
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"net/netip"
+	"os"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
+	floatingipmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
-	vpscore "github.com/Zillaforge/cloud-sdk/modules/vps/core"
+	vrmcommon "github.com/Zillaforge/cloud-sdk/models/vrm/common"
 	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
 	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
@@ -21,6 +28,39 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// SecurityGroupIDsFromList converts a security_group_ids list into the slice
+// sent to the API. The result is never nil: sg_ids is serialized without
+// omitempty, and a NIC without security groups must be sent as `[]` rather
+// than `null` so the platform does not fall back to its default group.
+func SecurityGroupIDsFromList(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	securityGroupIDs := make([]string, 0)
+	if list.IsNull() || list.IsUnknown() {
+		return securityGroupIDs, nil
+	}
+
+	var sgList []types.String
+	diags := list.ElementsAs(ctx, &sgList, false)
+	if diags.HasError() {
+		return securityGroupIDs, diags
+	}
+
+	for _, sg := range sgList {
+		securityGroupIDs = append(securityGroupIDs, sg.ValueString())
+	}
+	return securityGroupIDs, diags
+}
+
+// SecurityGroupIDsListValue builds the security_group_ids state value. An
+// empty API result stays null when the prior (plan or state) value was null,
+// so omitting security_group_ids in config does not produce a `[]` vs null
+// diff after apply.
+func SecurityGroupIDsListValue(sgVals []attr.Value, prior types.List) (types.List, diag.Diagnostics) {
+	if len(sgVals) == 0 && prior.IsNull() {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValue(types.StringType, sgVals)
+}
+
 // BuildServerCreateRequest maps Terraform plan to cloud-SDK ServerCreateRequest.
 func BuildServerCreateRequest(ctx context.Context, plan resourcemodels.ServerResourceModel) (*servermodels.ServerCreateRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -35,17 +75,12 @@ func BuildServerCreateRequest(ctx context.Context, plan resourcemodels.ServerRes
 	nics := make([]servermodels.ServerNICCreateRequest, len(networkAttachments))
 	for i, att := range networkAttachments {
 		// Extract security_group_ids from nested block
-		var sgList []types.String
-		diags.Append(att.SecurityGroupIDs.ElementsAs(ctx, &sgList, false)...)
+		securityGroupIDs, d := SecurityGroupIDsFromList(ctx, att.SecurityGroupIDs)
+		diags.Append(d...)
 		if diags.HasError() {
 			return nil, diags
 		}
 
-		securityGroupIDs := make([]string, len(sgList))
-		for j, sg := range sgList {
-			securityGroupIDs[j] = sg.ValueString()
-		}
-
 		fixedIP := ""
 		if !att.IPAddress.IsNull() {
 			fixedIP = att.IPAddress.ValueString()
@@ -80,10 +115,25 @@ func BuildServerCreateRequest(ctx context.Context, plan resourcemodels.ServerRes
 		// UserData (BootScript) should be base64 encoded
 		req.BootScript = base64.StdEncoding.EncodeToString([]byte(plan.UserData.ValueString()))
 	}
-
 	return req, diags
 }
 
+// ImageRequiresConfigDrive reports whether an image declares that cloud-init
+// can only read its data from a config drive, through the
+// img_config_drive = "mandatory" image property.
+func ImageRequiresConfigDrive(tag *vrmcommon.Tag) bool {
+	if tag == nil {
+		return false
+	}
+	value, ok := tag.Extra["img_config_drive"].(string)
+	return ok && strings.EqualFold(value, "mandatory")
+}
+
+// ImageIsWindows reports whether an image tag belongs to a Windows repository.
+func ImageIsWindows(tag *vrmcommon.Tag) bool {
+	return tag != nil && tag.Repository != nil && strings.EqualFold(tag.Repository.OperatingSystem, "windows")
+}
+
 // BuildServerUpdateRequest maps changed attributes from Terraform plan to cloud-SDK ServerUpdateRequest.
 // Returns the update context with server changes and network changes, and diagnostics.
 func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.ServerResourceModel) (*resourcemodels.UpdateContext, diag.Diagnostics) {
@@ -104,9 +154,12 @@ func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.Se
 		})
 	}
 
-	// Update description if changed
+	// Update description if changed. An empty value is dropped from the
+	// request body by the SDK, so removing the description is flagged
+	// separately and sent as an explicit clear.
 	if !plan.Description.Equal(state.Description) {
 		updateCtx.ServerUpdate.Description = plan.Description.ValueString()
+		updateCtx.ClearDescription = updateCtx.ServerUpdate.Description == "" && state.Description.ValueString() != ""
 		updateCtx.HasChanges = true
 		tflog.Debug(ctx, "Description changed", map[string]interface{}{
 			"old": state.Description.ValueString(),
@@ -114,6 +167,30 @@ func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.Se
 		})
 	}
 
+	// A changed reboot_trigger requests a reboot. Clearing the trigger (setting
+	// it to null) only stops tracking it and does not reboot.
+	if !plan.RebootTrigger.Equal(state.RebootTrigger) {
+		updateCtx.HasChanges = true
+		if !plan.RebootTrigger.IsNull() && !plan.RebootTrigger.IsUnknown() {
+			updateCtx.Reboot = true
+			tflog.Debug(ctx, "Reboot trigger changed", map[string]interface{}{
+				"old": state.RebootTrigger.ValueString(),
+				"new": plan.RebootTrigger.ValueString(),
+			})
+		}
+	}
+
+	// Growing root_disk_gb extends the root volume. Shrinking is rejected at
+	// plan time by the GrowOnly plan modifier.
+	if !plan.RootDiskGB.IsNull() && !plan.RootDiskGB.IsUnknown() && !plan.RootDiskGB.Equal(state.RootDiskGB) {
+		updateCtx.RootDiskGB = int(plan.RootDiskGB.ValueInt64())
+		updateCtx.HasChanges = true
+		tflog.Debug(ctx, "Root disk size changed", map[string]interface{}{
+			"old": state.RootDiskGB.ValueInt64(),
+			"new": updateCtx.RootDiskGB,
+		})
+	}
+
 	// Disallow changing flavor or image in-place: these represent platform-level
 	// resize or reprovision operations and are out of scope for in-place updates.
 	if !plan.FlavorID.Equal(state.FlavorID) {
@@ -167,21 +244,19 @@ func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.Se
 		for networkID, planAtt := range planByNetwork {
 			if _, exists := stateByNetwork[networkID]; !exists {
 				// Extract security_group_ids for the new NIC
-				var sgList []types.String
-				diags.Append(planAtt.SecurityGroupIDs.ElementsAs(ctx, &sgList, false)...)
+				securityGroupIDs, d := SecurityGroupIDsFromList(ctx, planAtt.SecurityGroupIDs)
+				diags.Append(d...)
 				if diags.HasError() {
 					return updateCtx, diags
 				}
 
-				securityGroupIDs := make([]string, len(sgList))
-				for j, sg := range sgList {
-					securityGroupIDs[j] = sg.ValueString()
-				}
-
-				// When creating a NEW NIC (network doesn't exist in state), do NOT use
-				// ip_address from plan because it might be from an old network that was replaced.
-				// The cloud will auto-assign an IP, which will be reflected in the final state.
+				// A known ip_address on a new network was set in config: the plan
+				// modifier marks it unknown when a block moves to another network
+				// without one. Otherwise the cloud auto-assigns an IP.
 				fixedIP := ""
+				if !planAtt.IPAddress.IsUnknown() {
+					fixedIP = planAtt.IPAddress.ValueString()
+				}
 
 				updateCtx.NetworksToCreate = append(updateCtx.NetworksToCreate, servermodels.ServerNICCreateRequest{
 					NetworkID: networkID,
@@ -198,18 +273,47 @@ func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.Se
 		// Check for security_group_ids changes in existing networks
 		for networkID, stateAtt := range stateByNetwork {
 			if planAtt, exists := planByNetwork[networkID]; exists {
-				// Check if security_group_ids changed
-				if !planAtt.SecurityGroupIDs.Equal(stateAtt.SecurityGroupIDs) {
-					// Extract security_group_ids
-					var sgList []types.String
-					diags.Append(planAtt.SecurityGroupIDs.ElementsAs(ctx, &sgList, false)...)
+				// A new fixed IP on the same network cannot be applied to the
+				// existing NIC, so it is detached and re-added with the new
+				// address and the planned security groups.
+				if fixedIPChanged(planAtt.IPAddress, stateAtt.IPAddress) {
+					securityGroupIDs, d := SecurityGroupIDsFromList(ctx, planAtt.SecurityGroupIDs)
+					diags.Append(d...)
 					if diags.HasError() {
 						return updateCtx, diags
 					}
 
-					securityGroupIDs := make([]string, len(sgList))
-					for j, sg := range sgList {
-						securityGroupIDs[j] = sg.ValueString()
+					priorSecurityGroupIDs, d := SecurityGroupIDsFromList(ctx, stateAtt.SecurityGroupIDs)
+					diags.Append(d...)
+					if diags.HasError() {
+						return updateCtx, diags
+					}
+
+					updateCtx.NetworksToReattach = append(updateCtx.NetworksToReattach, servermodels.ServerNICCreateRequest{
+						NetworkID: networkID,
+						SGIDs:     securityGroupIDs,
+						FixedIP:   planAtt.IPAddress.ValueString(),
+					})
+					if updateCtx.NICRestores == nil {
+						updateCtx.NICRestores = make(map[string]servermodels.ServerNICCreateRequest)
+					}
+					updateCtx.NICRestores[networkID] = servermodels.ServerNICCreateRequest{
+						NetworkID: networkID,
+						SGIDs:     priorSecurityGroupIDs,
+						FixedIP:   stateAtt.IPAddress.ValueString(),
+					}
+					updateCtx.HasChanges = true
+					tflog.Debug(ctx, "Fixed IP changed for network, NIC will be reattached", map[string]interface{}{
+						"network_id": networkID,
+						"old":        stateAtt.IPAddress.ValueString(),
+						"new":        planAtt.IPAddress.ValueString(),
+					})
+				} else if !planAtt.SecurityGroupIDs.Equal(stateAtt.SecurityGroupIDs) {
+					// Extract security_group_ids
+					securityGroupIDs, d := SecurityGroupIDsFromList(ctx, planAtt.SecurityGroupIDs)
+					diags.Append(d...)
+					if diags.HasError() {
+						return updateCtx, diags
 					}
 
 					updateCtx.NetworkChanges[networkID] = servermodels.ServerNICUpdateRequest{
@@ -234,11 +338,56 @@ func BuildServerUpdateRequest(ctx context.Context, plan, state resourcemodels.Se
 				}
 			}
 		}
+
+		// A floating_ip_id moved to another network_attachment block keeps the
+		// server; the address is released from the old NIC and bound to the new.
+		updateCtx.FloatingIPMoves = FloatingIPMoves(planAttachments, stateAttachments)
+		for _, move := range updateCtx.FloatingIPMoves {
+			tflog.Debug(ctx, "Floating IP moved to another network", map[string]interface{}{
+				"floating_ip_id": move.FloatingIPID,
+				"from":           move.FromNetworkID,
+				"to":             move.ToNetworkID,
+			})
+			updateCtx.HasChanges = true
+		}
 	}
 
 	return updateCtx, diags
 }
 
+// fixedIPChanged reports whether the plan requests a different fixed IP than
+// the NIC currently has. An unknown or empty planned address leaves the
+// assignment to the cloud and is not a change.
+func fixedIPChanged(planned, current types.String) bool {
+	if planned.IsNull() || planned.IsUnknown() || planned.ValueString() == "" {
+		return false
+	}
+	return planned.ValueString() != current.ValueString()
+}
+
+// UpdateRequiresActiveWait reports whether the changes in updateCtx can cycle
+// the server (NIC add/remove/reattach/update, a root disk extend or a reboot). Name and description
+// updates are metadata-only, so Update skips the active waiter for them.
+func UpdateRequiresActiveWait(updateCtx *resourcemodels.UpdateContext) bool {
+	return len(updateCtx.NetworksToCreate) > 0 ||
+		len(updateCtx.NetworksToDelete) > 0 ||
+		len(updateCtx.NetworksToReattach) > 0 ||
+		len(updateCtx.NetworkChanges) > 0 ||
+		updateCtx.RootDiskGB > 0 ||
+		updateCtx.Reboot
+}
+
+// NormalizeServerStatus returns the documented lowercase form of a server
+// status, e.g. "active" for ACTIVE and "building" for BUILD. Matching is
+// case-insensitive, so the result does not depend on the API's casing.
+func NormalizeServerStatus(status servermodels.ServerStatus) string {
+	normalized := strings.ToLower(strings.TrimSpace(string(status)))
+	if normalized == strings.ToLower(string(servermodels.ServerStatusBuild)) {
+		return "building"
+	}
+	return normalized
+}
+
 // MapServerToState maps cloud-SDK ServerResource to Terraform state.
 func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource) (resourcemodels.ServerResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -255,8 +404,8 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 	state.Name = types.StringValue(server.Name)
 	state.FlavorID = types.StringValue(server.FlavorID)
 	state.ImageID = types.StringValue(server.ImageID)
-	state.Status = types.StringValue(string(server.Status))
-	state.CreatedAt = types.StringValue(server.CreatedAt)
+	state.Status = types.StringValue(NormalizeServerStatus(server.Status))
+	state.CreatedAt = TimestampValue(server.CreatedAt, types.StringNull())
 
 	if server.Description != "" {
 		state.Description = types.StringValue(server.Description)
@@ -271,42 +420,36 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 		state.Keypair = types.StringNull()
 	}
 
+	if server.RootDiskSize > 0 {
+		state.RootDiskGB = types.Int64Value(int64(server.RootDiskSize))
+	} else {
+		state.RootDiskGB = types.Int64Null()
+	}
+
 	// Fetch NICs to populate network_attachment
 	nics, err := serverRes.NICs().List(ctx)
 	if err != nil {
 		diags.AddWarning("Failed to fetch server NICs", fmt.Sprintf("Could not retrieve network interfaces: %s", err.Error()))
 		// Set empty list
 		state.NetworkAttachment, _ = types.ListValue(
-			types.ObjectType{AttrTypes: map[string]attr.Type{
-				"network_id":         types.StringType,
-				"ip_address":         types.StringType,
-				"primary":            types.BoolType,
-				"security_group_ids": types.ListType{ElemType: types.StringType},
-				"floating_ip_id":     types.StringType,
-				"floating_ip":        types.StringType,
-			}},
+			types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes},
 			[]attr.Value{},
 		)
+		state.NICIDs = types.MapValueMust(types.StringType, map[string]attr.Value{})
 	} else {
 		// Map NICs to network_attachment blocks. Ensure deterministic ordering:
 		// - Primary NIC appears first (if API exposes IsPrimary)
 		// - Remaining NICs are sorted by NetworkID to make ordering stable
-		networkAttachmentAttrTypes := map[string]attr.Type{
-			"network_id":         types.StringType,
-			"ip_address":         types.StringType,
-			"primary":            types.BoolType,
-			"security_group_ids": types.ListType{ElemType: types.StringType},
-			"floating_ip_id":     types.StringType,
-			"floating_ip":        types.StringType,
-		}
-
 		// Sort NICs by NetworkID for deterministic ordering
 		sort.SliceStable(nics, func(i, j int) bool {
 			return nics[i].NetworkID < nics[j].NetworkID
 		})
 
 		networkAttachments := make([]attr.Value, len(nics))
+		nicIDs := make(map[string]attr.Value, len(nics))
 		for i, nic := range nics {
+			nicIDs[nic.NetworkID] = types.StringValue(nic.ID)
+
 			// Log NIC information for debugging
 			tflog.Debug(ctx, "Mapping NIC to network_attachment", map[string]interface{}{
 				"nic_id":          nic.ID,
@@ -329,7 +472,7 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 			for _, sg := range sgIDs {
 				sgVals = append(sgVals, types.StringValue(sg))
 			}
-			sgList, d := types.ListValue(types.StringType, sgVals)
+			sgList, d := SecurityGroupIDsListValue(sgVals, types.ListNull(types.StringType))
 			diags.Append(d...)
 
 			// Get IP address (use first address if available)
@@ -349,24 +492,35 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 				floatingIPAddress = types.StringValue(nic.FloatingIP.Address)
 			}
 
-			attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
+			attObj, d := types.ObjectValue(NetworkAttachmentAttrTypes, map[string]attr.Value{
 				"network_id":         types.StringValue(nic.NetworkID),
 				"ip_address":         ipAddress,
 				"primary":            types.BoolValue(isPrimary),
 				"security_group_ids": sgList,
 				"floating_ip_id":     floatingIPID,
 				"floating_ip":        floatingIPAddress,
+				"public_ip":          floatingIPAddress,
+
+				"security_group_names": types.ListNull(types.StringType),
+				"dns_nameservers":      types.ListNull(types.StringType),
+				"host_routes":          types.ListNull(types.ObjectType{AttrTypes: HostRouteAttrTypes}),
+
+				"fixed_ip_strategy": types.StringNull(),
 			})
 			diags.Append(d...)
 			networkAttachments[i] = attObj
 		}
 
 		networkAttachmentList, d := types.ListValue(
-			types.ObjectType{AttrTypes: networkAttachmentAttrTypes},
+			types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes},
 			networkAttachments,
 		)
 		diags.Append(d...)
 		state.NetworkAttachment = networkAttachmentList
+
+		nicIDMap, d := types.MapValue(types.StringType, nicIDs)
+		diags.Append(d...)
+		state.NICIDs = nicIDMap
 	}
 
 	// Map IP addresses to list (combine private and public IPs)
@@ -381,6 +535,12 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 	diags.Append(d...)
 	state.IPAddresses = ipList
 
+	ipv4, ipv6 := IPAddressesByFamily(allIPs)
+	state.IPv4Addresses, d = types.ListValueFrom(ctx, types.StringType, ipv4)
+	diags.Append(d...)
+	state.IPv6Addresses, d = types.ListValueFrom(ctx, types.StringType, ipv6)
+	diags.Append(d...)
+
 	// User data and password are not returned by API for security
 	state.UserData = types.StringNull()
 	state.Password = types.StringNull()
@@ -388,145 +548,517 @@ func MapServerToState(ctx context.Context, serverRes *serversdk.ServerResource)
 	return state, diags
 }
 
-// WaitForServerActive waits for the server to reach "active" using the SDK-provided waiter helper.
+// IPAddressesByFamily splits addresses into IPv4 and IPv6, each sorted
+// numerically so that the first element is stable across refreshes.
+// Addresses that do not parse are left out of both.
+func IPAddressesByFamily(ips []string) (ipv4, ipv6 []string) {
+	var v4, v6 []netip.Addr
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			continue
+		}
+		if addr.Is4() || addr.Is4In6() {
+			v4 = append(v4, addr.Unmap())
+		} else {
+			v6 = append(v6, addr)
+		}
+	}
+
+	toStrings := func(addrs []netip.Addr) []string {
+		sort.Slice(addrs, func(i, j int) bool { return addrs[i].Less(addrs[j]) })
+		out := make([]string, len(addrs))
+		for i, addr := range addrs {
+			out[i] = addr.String()
+		}
+		return out
+	}
+	return toStrings(v4), toStrings(v6)
+}
+
+// serverStatusPollInterval is how often WaitForServerStatuses reads the server.
+var serverStatusPollInterval = 5 * time.Second
+
+// DefaultServerWaitTimeout is how long the server waiters run when the
+// resource's timeouts block does not set the operation's timeout.
+const DefaultServerWaitTimeout = 10 * time.Minute
+
+// WaitForServerStatuses polls the server until its status is one of targets,
+// compared case-insensitively, and returns the server as last read. It fails
+// as soon as the server enters ERROR, unless ERROR is one of the targets, and
+// when the timeout expires.
+func WaitForServerStatuses(ctx context.Context, serversClient *serversdk.Client, serverID string, targets []servermodels.ServerStatus, timeout time.Duration) (*serversdk.ServerResource, error) {
+	deadline := time.Now().Add(timeout)
+	waitsForError := slices.ContainsFunc(targets, func(target servermodels.ServerStatus) bool {
+		return strings.EqualFold(string(target), string(servermodels.ServerStatusError))
+	})
+
+	for {
+		serverRes, err := serversClient.Get(ctx, serverID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server status: %w", err)
+		}
+
+		status := string(serverRes.Server.Status)
+		for _, target := range targets {
+			if strings.EqualFold(status, string(target)) {
+				return serverRes, nil
+			}
+		}
+		if !waitsForError && strings.EqualFold(status, string(servermodels.ServerStatusError)) {
+			return nil, fmt.Errorf("server entered ERROR state while waiting for %s", joinStatuses(targets))
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("timed out after %s waiting for %s, last status %s", timeout, joinStatuses(targets), status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(serverStatusPollInterval, remaining)):
+		}
+	}
+}
+
+// joinStatuses formats target statuses for error messages, e.g. "ACTIVE or SHUTOFF".
+func joinStatuses(statuses []servermodels.ServerStatus) string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, " or ")
+}
+
+// WaitForServerActive waits for the server to reach "active".
+// When the server errors or the timeout expires, the error names the last
+// status and fault message seen, as described by activeWaitError.
 func WaitForServerActive(ctx context.Context, serversClient *serversdk.Client, serverID string, timeout time.Duration) (*serversdk.ServerResource, error) {
-	// Use context with timeout so the SDK waiter respects the configured duration.
-	waitCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	start := time.Now()
+	serverRes, err := WaitForServerStatuses(ctx, serversClient, serverID, []servermodels.ServerStatus{servermodels.ServerStatusActive}, timeout)
+	if err != nil {
+		return nil, activeWaitError(ctx, serversClient, serverID, time.Since(start), timeout, err)
+	}
+	return serverRes, nil
+}
+
+// ServerWaitStatuses are the values accepted by wait_until_status, in the
+// documented lowercase form of the status attribute.
+var ServerWaitStatuses = []string{"building", "active", "reboot", "shutoff", "suspended"}
+
+// CreateWaitTargets returns the statuses Create waits for after creating a
+// server. wait_until_status, when set, overrides wait_for_active: the wait
+// ends when the server reaches that status or ACTIVE, whichever comes first.
+// Otherwise Create waits for ACTIVE unless wait_for_active is false. No
+// targets means Create does not wait.
+func CreateWaitTargets(waitUntilStatus types.String, waitForActive types.Bool) []servermodels.ServerStatus {
+	if !waitUntilStatus.IsNull() && !waitUntilStatus.IsUnknown() {
+		target := servermodels.ServerStatus(strings.ToUpper(waitUntilStatus.ValueString()))
+		if target == "BUILDING" {
+			target = servermodels.ServerStatusBuild
+		}
+		if target == servermodels.ServerStatusActive {
+			return []servermodels.ServerStatus{servermodels.ServerStatusActive}
+		}
+		return []servermodels.ServerStatus{target, servermodels.ServerStatusActive}
+	}
+	if !waitForActive.IsNull() && !waitForActive.ValueBool() {
+		return nil
+	}
+	return []servermodels.ServerStatus{servermodels.ServerStatusActive}
+}
+
+// activeWaitError explains why a server did not become active: its last
+// status and fault message when the server can still be read, how long the
+// wait ran, and where to look next.
+func activeWaitError(ctx context.Context, serversClient *serversdk.Client, serverID string, elapsed, timeout time.Duration, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("waiting for server to become active: %w", err)
+	}
 
-	if err := vpscore.WaitForServerStatus(waitCtx, vpscore.ServerWaiterConfig{
-		Client:       serversClient,
-		ServerID:     serverID,
-		TargetStatus: servermodels.ServerStatusActive,
-	}); err != nil {
-		return nil, fmt.Errorf("waiting for server to become active: %w", err)
+	serverRes, getErr := serversClient.Get(ctx, serverID)
+	if getErr != nil {
+		return fmt.Errorf("waiting for server to become active: %w (last status unavailable: %s)", err, getErr)
 	}
 
-	// After waiter completes, fetch the latest server resource
-	return serversClient.Get(ctx, serverID)
+	server := serverRes.Server
+	observed := fmt.Sprintf("last status %s after %s", server.Status, elapsed.Round(time.Second))
+	if server.StatusReason != "" {
+		observed += ", fault: " + server.StatusReason
+	}
+
+	const hint = "Check the project quota (see the precheck_quota provider option)"
+	if server.Status == servermodels.ServerStatusError {
+		return fmt.Errorf("server %s entered ERROR status (%s). %s; the server must be recreated: %w", serverID, observed, hint, err)
+	}
+	return fmt.Errorf("server %s did not become active within %s (%s). %s, or raise the create/update timeout: %w", serverID, timeout, observed, hint, err)
 }
 
-// WaitForServerDeleted polls until server is deleted or timeout.
-func WaitForServerDeleted(ctx context.Context, client interface {
-	Get(context.Context, string) (*serversdk.ServerResource, error)
-}, serverID string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(5 * time.Second)
+// RebootServer issues a soft reboot, falling back to a hard reboot when the
+// platform rejects the soft one (e.g. the guest OS is unresponsive). It then
+// waits briefly for the server to leave ACTIVE so a subsequent
+// WaitForServerActive does not return before the reboot has started.
+func RebootServer(ctx context.Context, serversClient *serversdk.Client, serverID string) error {
+	err := serversClient.Action(ctx, serverID, &servermodels.ServerActionRequest{
+		Action:     servermodels.ServerActionReboot,
+		RebootType: servermodels.RebootTypeSoft,
+	})
+	if err != nil {
+		tflog.Warn(ctx, "Soft reboot failed, falling back to hard reboot", map[string]interface{}{
+			"server_id": serverID,
+			"error":     err.Error(),
+		})
+		err = serversClient.Action(ctx, serverID, &servermodels.ServerActionRequest{
+			Action:     servermodels.ServerActionReboot,
+			RebootType: servermodels.RebootTypeHard,
+		})
+		if err != nil {
+			return fmt.Errorf("rebooting server %s: %w", serverID, err)
+		}
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
-	for {
+	for time.Now().Before(deadline) {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if time.Now().After(deadline) {
-				return fmt.Errorf("timeout waiting for server to be deleted")
-			}
-
-			_, err := client.Get(ctx, serverID)
+			serverRes, err := serversClient.Get(ctx, serverID)
 			if err != nil {
-				// 404 error means server is deleted
-				// This is the success condition
+				continue
+			}
+			if serverRes.Server.Status != servermodels.ServerStatusActive {
 				return nil
 			}
+		}
+	}
 
-			// Server still exists, continue waiting
-			continue
+	// The reboot may have completed between polls; the caller's active wait
+	// covers that case.
+	return nil
+}
+
+// PreserveEmptyDescription keeps a description configured as "" instead of
+// the null MapServerToState reports, since the API does not distinguish an
+// empty description from none.
+func PreserveEmptyDescription(mapped, prior types.String) types.String {
+	if mapped.IsNull() && !prior.IsNull() && !prior.IsUnknown() && prior.ValueString() == "" {
+		return prior
+	}
+	return mapped
+}
+
+// DescriptionValue maps a description reported by the API to state. The API
+// reports no description as "", which resources and data sources store as
+// null; a "" already in prior is kept so that description = "" in the
+// configuration stays consistent after apply.
+func DescriptionValue(description string, prior types.String) types.String {
+	if description != "" {
+		return types.StringValue(description)
+	}
+	return PreserveEmptyDescription(types.StringNull(), prior)
+}
+
+// ServerUserData returns the cloud-init user data of a server, read from
+// user_data_file when that is set instead of user_data. The result is unknown
+// while the file name is, and null when neither is set.
+func ServerUserData(m resourcemodels.ServerResourceModel) (types.String, error) {
+	if m.UserDataFile.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+	if m.UserDataFile.IsNull() {
+		return m.UserData, nil
+	}
+
+	content, err := os.ReadFile(m.UserDataFile.ValueString())
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(string(content)), nil
+}
+
+// ServerRevision derives a server's revision from the inputs that cannot
+// change without recreating it: image_id, flavor_id, keypair and user_data.
+// user_data only contributes its SHA-256, so the revision does not reveal it.
+// The revision is unknown while any input is unknown.
+func ServerRevision(imageID, flavorID, keypair, userData types.String) types.String {
+	for _, input := range []types.String{imageID, flavorID, keypair, userData} {
+		if input.IsUnknown() {
+			return types.StringUnknown()
+		}
+	}
+
+	userDataHash := ""
+	if !userData.IsNull() {
+		sum := sha256.Sum256([]byte(userData.ValueString()))
+		userDataHash = hex.EncodeToString(sum[:])
+	}
+
+	// One input per line, so that no two sets of inputs hash the same text.
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		"image_id=" + imageID.ValueString(),
+		"flavor_id=" + flavorID.ValueString(),
+		"keypair=" + keypair.ValueString(),
+		"user_data_sha256=" + userDataHash,
+	}, "\n")))
+	return types.StringValue(hex.EncodeToString(sum[:16]))
+}
+
+// ExtendRootDisk grows the server's root volume to sizeGB and waits for the
+// server to return to ACTIVE.
+func ExtendRootDisk(ctx context.Context, serversClient *serversdk.Client, serverID string, sizeGB int, timeout time.Duration) (*serversdk.ServerResource, error) {
+	err := serversClient.Action(ctx, serverID, &servermodels.ServerActionRequest{
+		Action:   servermodels.ServerActionExtendRoot,
+		RootSize: sizeGB,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("extending root disk of server %s to %d GB: %w", serverID, sizeGB, err)
+	}
+
+	return WaitForServerActive(ctx, serversClient, serverID, timeout)
+}
+
+// serverStatusSoftDeleted is reported by platforms that keep deleted
+// servers recoverable for a while. The SDK has no constant for it.
+const serverStatusSoftDeleted servermodels.ServerStatus = "SOFT_DELETED"
+
+// isServerDeleted reports whether a server that can still be read is gone:
+// DELETED, or SOFT_DELETED while it waits in the recycle bin.
+func isServerDeleted(status servermodels.ServerStatus) bool {
+	return strings.EqualFold(string(status), string(servermodels.ServerStatusDeleted)) ||
+		strings.EqualFold(string(status), string(serverStatusSoftDeleted))
+}
+
+// WaitForServerDeleted polls until the server is deleted or the timeout
+// expires. The server is deleted once it is not found or reports a DELETED
+// or SOFT_DELETED status. Other errors reading it are retried until the
+// timeout, since they do not show that the server is gone.
+func WaitForServerDeleted(ctx context.Context, client interface {
+	Get(context.Context, string) (*serversdk.ServerResource, error)
+}, serverID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		var last string
+		serverRes, err := client.Get(ctx, serverID)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			tflog.Warn(ctx, "Error fetching server while waiting for deletion", map[string]interface{}{
+				"id":    serverID,
+				"error": err.Error(),
+			})
+			last = "unavailable: " + err.Error()
+		case serverRes == nil || serverRes.Server == nil:
+			last = "unavailable"
+		case isServerDeleted(serverRes.Server.Status):
+			return nil
+		default:
+			last = string(serverRes.Server.Status)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s waiting for server to be deleted, last status %s", timeout, last)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(serverStatusPollInterval, remaining)):
 		}
 	}
 }
 
-// WaitForFloatingIPAssociated waits for a floating IP to be associated with a server.
-// Uses the SDK-provided waiter helper for floating IP status.
+// floatingIPPollInterval is how often WaitForFloatingIPAssociated reads the
+// floating IP.
+var floatingIPPollInterval = 2 * time.Second
+
+// WaitForFloatingIPAssociated polls a floating IP until it reports serverID
+// as its device, or the timeout expires.
 func WaitForFloatingIPAssociated(ctx context.Context, floatingIPClient interface {
-	Get(context.Context, string) (interface{}, error)
+	Get(context.Context, string) (*floatingipmodels.FloatingIP, error)
 }, floatingIPID string, serverID string, timeout time.Duration) error {
-	// Use context with timeout so the SDK waiter respects the configured duration
 	waitCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Note: The actual SDK waiter signature is:
-	// vpscore.WaitForFloatingIPStatus(ctx, vpscore.FloatingIPWaiterConfig{
-	//     Client:         floatingIPClient,
-	//     FloatingIPID:   floatingIPID,
-	//     TargetStatus:   floatingipmodels.FloatingIPStatusActive,
-	//     TargetDeviceID: serverID,
-	// })
-	//
-	// For now, implement simple polling until SDK integration is complete
-	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(floatingIPPollInterval)
 	defer ticker.Stop()
 
 	for {
+		fip, err := floatingIPClient.Get(waitCtx, floatingIPID)
+		switch {
+		case err != nil:
+			tflog.Warn(ctx, "Error fetching floating IP during wait", map[string]interface{}{
+				"floating_ip_id": floatingIPID,
+				"error":          err.Error(),
+			})
+		case fip.DeviceID == serverID:
+			return nil
+		}
+
 		select {
 		case <-waitCtx.Done():
-			return fmt.Errorf("context cancelled while waiting for floating IP association: %w", waitCtx.Err())
-		case <-ticker.C:
-			if time.Now().After(deadline) {
-				return fmt.Errorf("timeout waiting for floating IP %s to be associated with server %s", floatingIPID, serverID)
-			}
-
-			// Get current floating IP status
-			fip, err := floatingIPClient.Get(ctx, floatingIPID)
-			if err != nil {
-				tflog.Warn(ctx, "Error fetching floating IP during wait", map[string]interface{}{
-					"floating_ip_id": floatingIPID,
-					"error":          err.Error(),
-				})
-				continue
+			if ctx.Err() != nil {
+				return fmt.Errorf("context cancelled while waiting for floating IP association: %w", ctx.Err())
 			}
-
-			// Check if floating IP is associated with the expected server
-			// This is a simplified check - actual implementation will need to inspect
-			// the floating IP resource structure from SDK
-			_ = fip // Placeholder until SDK types are fully defined
-
-			// For now, assume association is immediate (will be refined with actual SDK integration)
-			return nil
+			return fmt.Errorf("timeout waiting for floating IP %s to be associated with server %s", floatingIPID, serverID)
+		case <-ticker.C:
 		}
 	}
 }
 
-// WaitForFloatingIPDisassociated waits for a floating IP to be disassociated from a server.
-// Uses polling to verify the floating IP status is "DOWN" and device_id is empty.
+// WaitForFloatingIPDisassociated polls a floating IP until it no longer
+// reports a device, or the timeout expires. A floating IP that was deleted
+// in the meantime counts as disassociated.
 func WaitForFloatingIPDisassociated(ctx context.Context, floatingIPClient interface {
-	Get(context.Context, string) (interface{}, error)
+	Get(context.Context, string) (*floatingipmodels.FloatingIP, error)
 }, floatingIPID string, timeout time.Duration) error {
-	// Use context with timeout
 	waitCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(floatingIPPollInterval)
 	defer ticker.Stop()
 
 	for {
+		fip, err := floatingIPClient.Get(waitCtx, floatingIPID)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			tflog.Warn(ctx, "Error fetching floating IP during wait", map[string]interface{}{
+				"floating_ip_id": floatingIPID,
+				"error":          err.Error(),
+			})
+		case fip.DeviceID == "":
+			return nil
+		}
+
 		select {
 		case <-waitCtx.Done():
-			return fmt.Errorf("context cancelled while waiting for floating IP disassociation: %w", waitCtx.Err())
-		case <-ticker.C:
-			if time.Now().After(deadline) {
-				return fmt.Errorf("timeout waiting for floating IP %s to be disassociated", floatingIPID)
+			if ctx.Err() != nil {
+				return fmt.Errorf("context cancelled while waiting for floating IP disassociation: %w", ctx.Err())
 			}
+			return fmt.Errorf("timeout waiting for floating IP %s to be disassociated", floatingIPID)
+		case <-ticker.C:
+		}
+	}
+}
 
-			// Get current floating IP status
-			fip, err := floatingIPClient.Get(ctx, floatingIPID)
-			if err != nil {
-				// If floating IP no longer exists (404), it's disassociated
-				// This is the success condition for Delete operation
-				return nil
-			}
+// FindServerByName returns the ID of a server other than excludeID whose name
+// is exactly name, or "" when there is none. Deleted servers are ignored.
+func FindServerByName(ctx context.Context, serversClient *serversdk.Client, name, excludeID string) (string, error) {
+	servers, err := serversClient.List(ctx, &servermodels.ServersListRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	for _, serverRes := range servers {
+		server := serverRes.Server
+		if server.Name == name && server.ID != excludeID && server.Status != servermodels.ServerStatusDeleted {
+			return server.ID, nil
+		}
+	}
+	return "", nil
+}
 
-			// Check if floating IP is disassociated (status=DOWN, device_id=empty)
-			// This is a simplified check - actual implementation will need to inspect
-			// the floating IP resource structure from SDK
-			_ = fip // Placeholder until SDK types are fully defined
+// ServerIDsNamed returns the IDs of the servers whose name is exactly name.
+// Deleted servers are ignored.
+func ServerIDsNamed(ctx context.Context, serversClient *serversdk.Client, name string) (map[string]bool, error) {
+	servers, err := WithRetry(ctx, func(ctx context.Context) ([]*serversdk.ServerResource, error) {
+		return serversClient.List(ctx, &servermodels.ServersListRequest{Name: name})
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			// For now, assume disassociation is immediate (will be refined with actual SDK integration)
-			return nil
+	ids := make(map[string]bool)
+	for _, serverRes := range servers {
+		server := serverRes.Server
+		if server.Name == name && server.Status != servermodels.ServerStatusDeleted {
+			ids[server.ID] = true
 		}
 	}
+	return ids, nil
+}
+
+// FindCreatedServer returns the server made by a create request whose outcome
+// is unknown: the one server named name that is not in existingIDs, listed
+// before the request was sent. It returns nil when there is none, and an error
+// when there are several, since it cannot tell which one the request made.
+func FindCreatedServer(ctx context.Context, serversClient *serversdk.Client, name string, existingIDs map[string]bool) (*serversdk.ServerResource, error) {
+	ids, err := ServerIDsNamed(ctx, serversClient, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for id := range ids {
+		if !existingIDs[id] {
+			created = append(created, id)
+		}
+	}
+	switch len(created) {
+	case 0:
+		return nil, nil
+	case 1:
+		return serversClient.Get(ctx, created[0])
+	}
+	sort.Strings(created)
+	return nil, fmt.Errorf("found %d new servers named %q (%s); cannot tell which one the request created", len(created), name, strings.Join(created, ", "))
+}
+
+// DetachSecurityGroupFromServers removes sgID from every NIC of every server in
+// the project and returns the IDs of the servers it changed. Other groups on a
+// NIC are kept; a NIC whose only group was sgID is left with none.
+func DetachSecurityGroupFromServers(ctx context.Context, serversClient *serversdk.Client, sgID string) ([]string, error) {
+	servers, err := serversClient.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	var detached []string
+	for _, serverRes := range servers {
+		if serverRes.Server.Status == servermodels.ServerStatusDeleted {
+			continue
+		}
+
+		nics, err := serverRes.NICs().List(ctx)
+		if err != nil {
+			return detached, fmt.Errorf("failed to list NICs of server %s: %w", serverRes.Server.ID, err)
+		}
+
+		changed := false
+		for _, nic := range nics {
+			remaining := make([]string, 0, len(nic.SGIDs))
+			for _, id := range nic.SGIDs {
+				if id != sgID {
+					remaining = append(remaining, id)
+				}
+			}
+			if len(remaining) == len(nic.SGIDs) {
+				continue
+			}
+
+			tflog.Info(ctx, "Detaching security group from server NIC", map[string]interface{}{
+				"security_group_id": sgID,
+				"server_id":         serverRes.Server.ID,
+				"nic_id":            nic.ID,
+			})
+			if _, err := serverRes.NICs().Update(ctx, nic.ID, &servermodels.ServerNICUpdateRequest{SGIDs: remaining}); err != nil {
+				return detached, fmt.Errorf("failed to detach from NIC %s of server %s: %w", nic.ID, serverRes.Server.ID, err)
+			}
+			changed = true
+		}
+		if changed {
+			detached = append(detached, serverRes.Server.ID)
+		}
+	}
+	return detached, nil
 }
 
 // MapNetworkIDToNICID finds the NIC ID for a given network_id from the server's NICs.
@@ -557,12 +1089,177 @@ func MapNetworkIDToNICID(ctx context.Context, server interface{}, networkID stri
 	return "", fmt.Errorf("MapNetworkIDToNICID not yet implemented - requires server NIC list access")
 }
 
+// nicAddRetryConfig is used by AddNICWithRetry.
+var nicAddRetryConfig = RetryConfig{
+	MaxAttempts:     4,
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     20 * time.Second,
+}
+
+// IsIPAllocationError reports whether err is the network service failing to
+// allocate an address for a new NIC, which often succeeds on a retry.
+func IsIPAllocationError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "is not a valid IP for the specified subnet") || strings.Contains(msg, "(neutron)IP address")
+}
+
+// AddNICWithRetry attaches a NIC, retrying IP allocation errors with jittered
+// exponential backoff. Retries stop after nicAddRetryConfig.MaxAttempts, when
+// ctx is done, or when the next wait would run past timeout; the last error
+// is returned.
+func AddNICWithRetry(ctx context.Context, nicsClient interface {
+	Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error)
+}, req *servermodels.ServerNICCreateRequest, timeout time.Duration) error {
+	return addNICWithRetry(ctx, nicAddRetryConfig, nicsClient, req, timeout)
+}
+
+func addNICWithRetry(ctx context.Context, config RetryConfig, nicsClient interface {
+	Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error)
+}, req *servermodels.ServerNICCreateRequest, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for attempt := 1; ; attempt++ {
+		_, err := nicsClient.Add(ctx, req)
+		if err == nil || attempt >= config.MaxAttempts || !IsIPAllocationError(err) {
+			return err
+		}
+
+		backoff := jitteredBackoff(config, attempt)
+		if time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+
+		tflog.Warn(ctx, "Transient IP allocation error when adding NIC — retrying", map[string]interface{}{
+			"attempt":    attempt,
+			"backoff":    backoff.String(),
+			"err":        err.Error(),
+			"network_id": req.NetworkID,
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// ReattachNIC replaces the NIC nicID with a NIC added from req, retrying IP
+// allocation errors as AddNICWithRetry does until timeout. The NIC API cannot
+// change the address of a NIC, so the old one is deleted first. When the new
+// NIC cannot be added, restore is added in its place so the server does not
+// lose the interface, and the error says whether that worked.
+func ReattachNIC(ctx context.Context, nicsClient interface {
+	Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error)
+	Delete(ctx context.Context, nicID string) error
+}, nicID string, req, restore *servermodels.ServerNICCreateRequest, timeout time.Duration) error {
+	return reattachNIC(ctx, nicAddRetryConfig, nicsClient, nicID, req, restore, timeout)
+}
+
+func reattachNIC(ctx context.Context, config RetryConfig, nicsClient interface {
+	Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error)
+	Delete(ctx context.Context, nicID string) error
+}, nicID string, req, restore *servermodels.ServerNICCreateRequest, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	if err := nicsClient.Delete(ctx, nicID); err != nil {
+		return fmt.Errorf("detaching NIC %s: %w", nicID, err)
+	}
+
+	err := addNICWithRetry(ctx, config, nicsClient, req, time.Until(deadline))
+	if err == nil {
+		return nil
+	}
+
+	tflog.Warn(ctx, "Reattaching NIC failed, restoring its previous address", map[string]interface{}{
+		"network_id": req.NetworkID,
+		"fixed_ip":   req.FixedIP,
+		"restore_ip": restore.FixedIP,
+		"err":        err.Error(),
+	})
+	if restoreErr := addNICWithRetry(ctx, config, nicsClient, restore, time.Until(deadline)); restoreErr != nil {
+		return fmt.Errorf("NIC was detached but could not be reattached with IP %s (%w) nor restored with its previous IP %s (%s); the server has no NIC on this network until the next apply re-adds it",
+			req.FixedIP, err, restore.FixedIP, restoreErr)
+	}
+	return fmt.Errorf("NIC could not be reattached with IP %s and was restored with its previous IP %s and security groups: %w", req.FixedIP, restore.FixedIP, err)
+}
+
+// nicAddressPollInterval is how often WaitForNICAddress lists the NICs.
+var nicAddressPollInterval = 2 * time.Second
+
+// WaitForNICAddress polls the server's NICs until the NIC on networkID
+// reports fixedIP. It fails as soon as the NIC reports other addresses, e.g.
+// when the fixed IP was released and DHCP assigned a new one, and when the
+// NIC reports no address or is missing until the timeout expires.
+func WaitForNICAddress(ctx context.Context, nicsClient interface {
+	List(ctx context.Context) ([]*servermodels.ServerNIC, error)
+}, networkID, fixedIP string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(nicAddressPollInterval)
+	defer ticker.Stop()
+
+	for {
+		nics, err := nicsClient.List(waitCtx)
+		if err != nil {
+			tflog.Warn(ctx, "Error listing NICs while checking fixed IP", map[string]interface{}{
+				"network_id": networkID,
+				"error":      err.Error(),
+			})
+		}
+		for _, nic := range nics {
+			if nic.NetworkID != networkID || len(nic.Addresses) == 0 {
+				continue
+			}
+			if slices.Contains(nic.Addresses, fixedIP) {
+				return nil
+			}
+			return fmt.Errorf("NIC on network %s reports address %s instead of its fixed IP %s", networkID, strings.Join(nic.Addresses, ", "), fixedIP)
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("context cancelled while checking the fixed IP of the NIC on network %s: %w", networkID, ctx.Err())
+			}
+			return fmt.Errorf("NIC on network %s did not report its fixed IP %s within %s", networkID, fixedIP, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Values of the server's floating_ip_association attribute.
+const (
+	// FloatingIPAssociationStrict fails the apply when any floating IP
+	// cannot be associated.
+	FloatingIPAssociationStrict = "strict"
+
+	// FloatingIPAssociationBestEffort reports failed associations as
+	// warnings and keeps the ones that succeeded.
+	FloatingIPAssociationBestEffort = "best_effort"
+)
+
 // AssociateFloatingIPsForServer associates floating IPs with server NICs based on network_attachment configuration.
 // CRITICAL: Server must be ACTIVE before calling this function (NICs not ready until server is active).
+//
+// When floatingIPClient is not nil, each association is confirmed by waiting
+// up to timeout for the floating IP to report the server as its device, so a
+// server read afterwards lists it.
+//
+// Every attachment is attempted even after a failure. In strict mode each
+// failure is an error; in best-effort mode it is a warning, followed by a
+// summary of what failed.
 func AssociateFloatingIPsForServer(
 	ctx context.Context,
 	serverRes *serversdk.ServerResource,
+	floatingIPClient interface {
+		Get(context.Context, string) (*floatingipmodels.FloatingIP, error)
+	},
 	networkAttachments []resourcemodels.NetworkAttachmentModel,
+	mode string,
+	timeout time.Duration,
 ) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -582,11 +1279,19 @@ func AssociateFloatingIPsForServer(
 		nicMap[nic.NetworkID] = nic.ID
 	}
 
+	report := diags.AddError
+	if mode == FloatingIPAssociationBestEffort {
+		report = diags.AddWarning
+	}
+
 	// Associate floating IPs for each network attachment that has floating_ip_id
+	attempted := 0
+	var failed []string
 	for _, attachment := range networkAttachments {
 		if attachment.FloatingIPID.IsNull() || attachment.FloatingIPID.IsUnknown() {
 			continue
 		}
+		attempted++
 
 		floatingIPID := attachment.FloatingIPID.ValueString()
 		networkID := attachment.NetworkID.ValueString()
@@ -594,10 +1299,11 @@ func AssociateFloatingIPsForServer(
 		// Find the NIC ID for this network
 		nicID, exists := nicMap[networkID]
 		if !exists {
-			diags.AddError(
+			report(
 				"NIC not found for network",
 				fmt.Sprintf("Could not find NIC for network_id %s on server %s", networkID, serverRes.Server.ID),
 			)
+			failed = append(failed, fmt.Sprintf("%s (network %s)", floatingIPID, networkID))
 			continue
 		}
 
@@ -608,20 +1314,44 @@ func AssociateFloatingIPsForServer(
 			"nic_id":         nicID,
 		})
 
+		// A floating IP bound elsewhere would be rejected with a less clear error
+		if floatingIPClient != nil {
+			if fip, err := floatingIPClient.Get(ctx, floatingIPID); err == nil {
+				if err := CheckFloatingIPAvailable(fip, serverRes.Server.ID); err != nil {
+					report("Floating IP Already Associated", fmt.Sprintf("Cannot associate with network %s on server %s: %s.", networkID, serverRes.Server.ID, err))
+					failed = append(failed, fmt.Sprintf("%s (network %s)", floatingIPID, networkID))
+					continue
+				}
+			}
+		}
+
 		// Associate floating IP to NIC
 		req := &servermodels.ServerNICAssociateFloatingIPRequest{
 			FIPID: floatingIPID,
 		}
 		_, err := serverRes.NICs().AssociateFloatingIP(ctx, nicID, req)
 		if err != nil {
-			diags.AddError(
+			report(
 				"Failed to associate floating IP",
 				fmt.Sprintf("Could not associate floating IP %s to network %s (NIC %s) on server %s: %s",
 					floatingIPID, networkID, nicID, serverRes.Server.ID, err.Error()),
 			)
+			failed = append(failed, fmt.Sprintf("%s (network %s)", floatingIPID, networkID))
 			continue
 		}
 
+		if floatingIPClient != nil {
+			if err := WaitForFloatingIPAssociated(ctx, floatingIPClient, floatingIPID, serverRes.Server.ID, timeout); err != nil {
+				report(
+					"Floating IP Association Not Confirmed",
+					fmt.Sprintf("Floating IP %s was associated to network %s (NIC %s) on server %s, but did not report the server as its device: %s",
+						floatingIPID, networkID, nicID, serverRes.Server.ID, err.Error()),
+				)
+				failed = append(failed, fmt.Sprintf("%s (network %s)", floatingIPID, networkID))
+				continue
+			}
+		}
+
 		tflog.Info(ctx, "Successfully associated floating IP", map[string]interface{}{
 			"floating_ip_id": floatingIPID,
 			"server_id":      serverRes.Server.ID,
@@ -629,9 +1359,93 @@ func AssociateFloatingIPsForServer(
 		})
 	}
 
+	if mode == FloatingIPAssociationBestEffort && len(failed) > 0 {
+		diags.AddWarning(
+			"Floating IPs Partially Associated",
+			fmt.Sprintf("Associated %d of %d floating IP(s) on server %s. Not associated: %s.\n\n"+
+				"The missing associations show as changes on the next plan; apply again to retry only those.",
+				attempted-len(failed), attempted, serverRes.Server.ID, strings.Join(failed, ", ")),
+		)
+	}
+
 	return diags
 }
 
+// FloatingIPChanges compares the floating IPs of the planned and prior
+// network attachments by network. It returns the floating IPs to disassociate,
+// sorted, and the attachments whose floating IP must then be associated. A
+// floating IP swapped on the same network appears in both: the old address is
+// released before the new one is bound to the NIC. Networks in reattached
+// lost their floating IP with the old NIC and are associated again.
+func FloatingIPChanges(plan, state []resourcemodels.NetworkAttachmentModel, reattached map[string]bool) ([]string, []resourcemodels.NetworkAttachmentModel) {
+	planFIPsByNetwork := make(map[string]string)
+	for _, att := range plan {
+		if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
+			planFIPsByNetwork[att.NetworkID.ValueString()] = att.FloatingIPID.ValueString()
+		}
+	}
+	stateFIPsByNetwork := make(map[string]string)
+	for _, att := range state {
+		if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
+			stateFIPsByNetwork[att.NetworkID.ValueString()] = att.FloatingIPID.ValueString()
+		}
+	}
+
+	// Removed from the network, or replaced by another floating IP
+	toDisassociate := make([]string, 0)
+	for networkID, stateFIPID := range stateFIPsByNetwork {
+		if planFIPID, ok := planFIPsByNetwork[networkID]; !ok || planFIPID != stateFIPID {
+			toDisassociate = append(toDisassociate, stateFIPID)
+		}
+	}
+	sort.Strings(toDisassociate)
+
+	// New or replacing floating IPs
+	toAssociate := make([]resourcemodels.NetworkAttachmentModel, 0)
+	for _, planAtt := range plan {
+		if planAtt.FloatingIPID.IsNull() || planAtt.FloatingIPID.IsUnknown() {
+			continue
+		}
+		networkID := planAtt.NetworkID.ValueString()
+		if stateFIPID, ok := stateFIPsByNetwork[networkID]; !ok || stateFIPID != planAtt.FloatingIPID.ValueString() || reattached[networkID] {
+			toAssociate = append(toAssociate, planAtt)
+		}
+	}
+
+	return toDisassociate, toAssociate
+}
+
+// FloatingIPMoves returns the floating IPs that the plan attaches to a
+// different network than the prior state did, sorted by floating IP ID. A
+// move onto a NIC added by the same plan is included.
+func FloatingIPMoves(plan, state []resourcemodels.NetworkAttachmentModel) []resourcemodels.FloatingIPMove {
+	stateNetworkByFIP := make(map[string]string)
+	for _, att := range state {
+		if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
+			stateNetworkByFIP[att.FloatingIPID.ValueString()] = att.NetworkID.ValueString()
+		}
+	}
+
+	var moves []resourcemodels.FloatingIPMove
+	for _, att := range plan {
+		if att.FloatingIPID.IsNull() || att.FloatingIPID.IsUnknown() || att.NetworkID.IsUnknown() {
+			continue
+		}
+		floatingIPID := att.FloatingIPID.ValueString()
+		from, ok := stateNetworkByFIP[floatingIPID]
+		if !ok || from == att.NetworkID.ValueString() {
+			continue
+		}
+		moves = append(moves, resourcemodels.FloatingIPMove{
+			FloatingIPID:  floatingIPID,
+			FromNetworkID: from,
+			ToNetworkID:   att.NetworkID.ValueString(),
+		})
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].FloatingIPID < moves[j].FloatingIPID })
+	return moves
+}
+
 // DisassociateFloatingIPsForServer disassociates floating IPs from server NICs.
 // Uses the vpsClient.FloatingIPs().Disassociate() method which disassociates without deleting the resource.
 func DisassociateFloatingIPsForServer(
@@ -651,6 +1465,14 @@ func DisassociateFloatingIPsForServer(
 
 		err := floatingIPClient.Disassociate(ctx, floatingIPID)
 		if err != nil {
+			// A floating IP that no longer exists has nothing to disassociate
+			if IsNotFound(err) {
+				tflog.Warn(ctx, "Floating IP not found during disassociation, skipping", map[string]interface{}{
+					"floating_ip_id": floatingIPID,
+				})
+				continue
+			}
+
 			diags.AddError(
 				"Failed to disassociate floating IP",
 				fmt.Sprintf("Could not disassociate floating IP %s: %s", floatingIPID, err.Error()),
@@ -665,3 +1487,37 @@ func DisassociateFloatingIPsForServer(
 
 	return diags
 }
+
+// MapServerVolumes converts a server's volume attachments to data source
+// models sorted by device, then by volume ID. The API does not report a boot
+// order, so the system volume gets boot_index 0 and the others none.
+func MapServerVolumes(volumes []*servermodels.ServerVolume) []resourcemodels.ServerVolumeModel {
+	result := make([]resourcemodels.ServerVolumeModel, 0, len(volumes))
+	for _, v := range volumes {
+		if v == nil {
+			continue
+		}
+
+		m := resourcemodels.ServerVolumeModel{
+			ID:        types.StringValue(v.VolumeID),
+			Device:    types.StringValue(v.Device),
+			SizeGB:    types.Int64Null(),
+			BootIndex: types.Int64Null(),
+		}
+		if v.Volume != nil {
+			m.SizeGB = types.Int64Value(int64(v.Volume.Size))
+		}
+		if v.System {
+			m.BootIndex = types.Int64Value(0)
+		}
+		result = append(result, m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if di, dj := result[i].Device.ValueString(), result[j].Device.ValueString(); di != dj {
+			return di < dj
+		}
+		return result[i].ID.ValueString() < result[j].ID.ValueString()
+	})
+	return result
+}
@@ -0,0 +1,1442 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	floatingipmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	volumemodels "github.com/Zillaforge/cloud-sdk/models/vps/volumes"
+	vrmcommon "github.com/Zillaforge/cloud-sdk/models/vrm/common"
+	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUpdateRequiresActiveWait(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		updateCtx resourcemodels.UpdateContext
+		expected  bool
+	}{
+		{
+			name: "description only",
+			updateCtx: resourcemodels.UpdateContext{
+				ServerUpdate: &servermodels.ServerUpdateRequest{Description: "new description"},
+				HasChanges:   true,
+			},
+			expected: false,
+		},
+		{
+			name: "name only",
+			updateCtx: resourcemodels.UpdateContext{
+				ServerUpdate: &servermodels.ServerUpdateRequest{Name: "renamed"},
+				HasChanges:   true,
+			},
+			expected: false,
+		},
+		{
+			name: "nic added",
+			updateCtx: resourcemodels.UpdateContext{
+				NetworksToCreate: []servermodels.ServerNICCreateRequest{{NetworkID: "net-1"}},
+				HasChanges:       true,
+			},
+			expected: true,
+		},
+		{
+			name: "nic removed",
+			updateCtx: resourcemodels.UpdateContext{
+				NetworksToDelete: []string{"nic-1"},
+				HasChanges:       true,
+			},
+			expected: true,
+		},
+		{
+			name: "nic reattached",
+			updateCtx: resourcemodels.UpdateContext{
+				NetworksToReattach: []servermodels.ServerNICCreateRequest{{NetworkID: "net-1", FixedIP: "10.0.0.20"}},
+				HasChanges:         true,
+			},
+			expected: true,
+		},
+		{
+			name: "nic updated",
+			updateCtx: resourcemodels.UpdateContext{
+				NetworkChanges: map[string]servermodels.ServerNICUpdateRequest{"nic-1": {SGIDs: []string{"sg-1"}}},
+				HasChanges:     true,
+			},
+			expected: true,
+		},
+		{
+			name:      "reboot",
+			updateCtx: resourcemodels.UpdateContext{Reboot: true, HasChanges: true},
+			expected:  true,
+		},
+		{
+			name:      "root disk grown",
+			updateCtx: resourcemodels.UpdateContext{RootDiskGB: 40, HasChanges: true},
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := UpdateRequiresActiveWait(&tt.updateCtx); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildServerUpdateRequest_Description(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		state         types.String
+		plan          types.String
+		expectedDesc  string
+		expectedClear bool
+		expectChanges bool
+	}{
+		{
+			name:          "set",
+			state:         types.StringNull(),
+			plan:          types.StringValue("web tier"),
+			expectedDesc:  "web tier",
+			expectChanges: true,
+		},
+		{
+			name:          "changed",
+			state:         types.StringValue("old"),
+			plan:          types.StringValue("new"),
+			expectedDesc:  "new",
+			expectChanges: true,
+		},
+		{
+			name:          "removed",
+			state:         types.StringValue("old"),
+			plan:          types.StringNull(),
+			expectedClear: true,
+			expectChanges: true,
+		},
+		{
+			name:          "set to empty",
+			state:         types.StringValue("old"),
+			plan:          types.StringValue(""),
+			expectedClear: true,
+			expectChanges: true,
+		},
+		{
+			name:  "unchanged",
+			state: types.StringValue("same"),
+			plan:  types.StringValue("same"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			noAttachments := types.ListNull(types.ObjectType{})
+			state := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), Description: tt.state, NetworkAttachment: noAttachments}
+			plan := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), Description: tt.plan, NetworkAttachment: noAttachments}
+
+			updateCtx, diags := BuildServerUpdateRequest(context.Background(), plan, state)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if updateCtx.ServerUpdate.Description != tt.expectedDesc {
+				t.Errorf("expected description %q, got %q", tt.expectedDesc, updateCtx.ServerUpdate.Description)
+			}
+			if updateCtx.ClearDescription != tt.expectedClear {
+				t.Errorf("expected ClearDescription %t, got %t", tt.expectedClear, updateCtx.ClearDescription)
+			}
+			if updateCtx.HasChanges != tt.expectChanges {
+				t.Errorf("expected HasChanges %t, got %t", tt.expectChanges, updateCtx.HasChanges)
+			}
+		})
+	}
+}
+
+func TestBuildServerUpdateRequest_FixedIP(t *testing.T) {
+	t.Parallel()
+
+	attachments := func(networkID string, ip types.String) types.List {
+		obj := types.ObjectValueMust(NetworkAttachmentAttrTypes, map[string]attr.Value{
+			"network_id":           types.StringValue(networkID),
+			"ip_address":           ip,
+			"primary":              types.BoolValue(true),
+			"security_group_ids":   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("sg-1")}),
+			"floating_ip_id":       types.StringNull(),
+			"floating_ip":          types.StringNull(),
+			"public_ip":            types.StringNull(),
+			"security_group_names": types.ListNull(types.StringType),
+			"dns_nameservers":      types.ListNull(types.StringType),
+			"host_routes":          types.ListNull(types.ObjectType{AttrTypes: HostRouteAttrTypes}),
+			"fixed_ip_strategy":    types.StringNull(),
+		})
+		return types.ListValueMust(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, []attr.Value{obj})
+	}
+
+	tests := []struct {
+		name             string
+		state            types.List
+		plan             types.List
+		expectedCreate   []servermodels.ServerNICCreateRequest
+		expectedDelete   []string
+		expectedReattach []servermodels.ServerNICCreateRequest
+		expectedRestores map[string]servermodels.ServerNICCreateRequest
+	}{
+		{
+			name:  "new fixed ip on same network",
+			state: attachments("net-1", types.StringValue("10.0.0.5")),
+			plan:  attachments("net-1", types.StringValue("10.0.0.20")),
+			expectedReattach: []servermodels.ServerNICCreateRequest{
+				{NetworkID: "net-1", SGIDs: []string{"sg-1"}, FixedIP: "10.0.0.20"},
+			},
+			expectedRestores: map[string]servermodels.ServerNICCreateRequest{
+				"net-1": {NetworkID: "net-1", SGIDs: []string{"sg-1"}, FixedIP: "10.0.0.5"},
+			},
+		},
+		{
+			name:  "unchanged fixed ip",
+			state: attachments("net-1", types.StringValue("10.0.0.5")),
+			plan:  attachments("net-1", types.StringValue("10.0.0.5")),
+		},
+		{
+			name:           "new network with configured fixed ip",
+			state:          attachments("net-1", types.StringValue("10.0.0.5")),
+			plan:           attachments("net-2", types.StringValue("10.1.0.5")),
+			expectedCreate: []servermodels.ServerNICCreateRequest{{NetworkID: "net-2", SGIDs: []string{"sg-1"}, FixedIP: "10.1.0.5"}},
+			expectedDelete: []string{"net-1"},
+		},
+		{
+			name:           "new network without fixed ip",
+			state:          attachments("net-1", types.StringValue("10.0.0.5")),
+			plan:           attachments("net-2", types.StringUnknown()),
+			expectedCreate: []servermodels.ServerNICCreateRequest{{NetworkID: "net-2", SGIDs: []string{"sg-1"}}},
+			expectedDelete: []string{"net-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), NetworkAttachment: tt.state}
+			plan := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), NetworkAttachment: tt.plan}
+
+			updateCtx, diags := BuildServerUpdateRequest(context.Background(), plan, state)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(updateCtx.NetworksToCreate, tt.expectedCreate) {
+				t.Errorf("expected NetworksToCreate %v, got %v", tt.expectedCreate, updateCtx.NetworksToCreate)
+			}
+			if !reflect.DeepEqual(updateCtx.NetworksToDelete, tt.expectedDelete) {
+				t.Errorf("expected NetworksToDelete %v, got %v", tt.expectedDelete, updateCtx.NetworksToDelete)
+			}
+			if !reflect.DeepEqual(updateCtx.NetworksToReattach, tt.expectedReattach) {
+				t.Errorf("expected NetworksToReattach %v, got %v", tt.expectedReattach, updateCtx.NetworksToReattach)
+			}
+			if !reflect.DeepEqual(updateCtx.NICRestores, tt.expectedRestores) {
+				t.Errorf("expected NICRestores %v, got %v", tt.expectedRestores, updateCtx.NICRestores)
+			}
+			if len(updateCtx.NetworkChanges) != 0 {
+				t.Errorf("expected no NetworkChanges, got %v", updateCtx.NetworkChanges)
+			}
+		})
+	}
+}
+
+func TestBuildServerUpdateRequest_SecurityGroupsOnFixedIPNIC(t *testing.T) {
+	t.Parallel()
+
+	attachments := func(sgIDs ...string) types.List {
+		sgs := make([]attr.Value, 0, len(sgIDs))
+		for _, id := range sgIDs {
+			sgs = append(sgs, types.StringValue(id))
+		}
+		obj := types.ObjectValueMust(NetworkAttachmentAttrTypes, map[string]attr.Value{
+			"network_id":           types.StringValue("net-1"),
+			"ip_address":           types.StringValue("10.0.0.50"),
+			"primary":              types.BoolValue(true),
+			"security_group_ids":   types.ListValueMust(types.StringType, sgs),
+			"floating_ip_id":       types.StringNull(),
+			"floating_ip":          types.StringNull(),
+			"public_ip":            types.StringNull(),
+			"security_group_names": types.ListNull(types.StringType),
+			"dns_nameservers":      types.ListNull(types.StringType),
+			"host_routes":          types.ListNull(types.ObjectType{AttrTypes: HostRouteAttrTypes}),
+			"fixed_ip_strategy":    types.StringNull(),
+		})
+		return types.ListValueMust(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}, []attr.Value{obj})
+	}
+
+	tests := []struct {
+		name     string
+		state    []string
+		plan     []string
+		expected []string
+	}{
+		{name: "group added", state: []string{"sg-1"}, plan: []string{"sg-1", "sg-2"}, expected: []string{"sg-1", "sg-2"}},
+		{name: "groups reordered", state: []string{"sg-1", "sg-2"}, plan: []string{"sg-2", "sg-1"}, expected: []string{"sg-2", "sg-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), NetworkAttachment: attachments(tt.state...)}
+			plan := resourcemodels.ServerResourceModel{Name: types.StringValue("web"), NetworkAttachment: attachments(tt.plan...)}
+
+			updateCtx, diags := BuildServerUpdateRequest(context.Background(), plan, state)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			// The NIC is updated in place, so it keeps its address, and the
+			// groups are sent in the configured order.
+			expected := map[string]servermodels.ServerNICUpdateRequest{"net-1": {SGIDs: tt.expected}}
+			if !reflect.DeepEqual(updateCtx.NetworkChanges, expected) {
+				t.Errorf("expected NetworkChanges %v, got %v", expected, updateCtx.NetworkChanges)
+			}
+			if len(updateCtx.NetworksToCreate) != 0 || len(updateCtx.NetworksToDelete) != 0 || len(updateCtx.NetworksToReattach) != 0 {
+				t.Errorf("expected no NIC to be recreated, got create %v, delete %v, reattach %v",
+					updateCtx.NetworksToCreate, updateCtx.NetworksToDelete, updateCtx.NetworksToReattach)
+			}
+		})
+	}
+}
+
+func TestNormalizeServerStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status   servermodels.ServerStatus
+		expected string
+	}{
+		{status: servermodels.ServerStatusActive, expected: "active"},
+		{status: "active", expected: "active"},
+		{status: "Active", expected: "active"},
+		{status: servermodels.ServerStatusBuild, expected: "building"},
+		{status: "build", expected: "building"},
+		{status: servermodels.ServerStatusShutoff, expected: "shutoff"},
+		{status: servermodels.ServerStatusError, expected: "error"},
+		{status: servermodels.ServerStatusDeleted, expected: "deleted"},
+		{status: "MIGRATING", expected: "migrating"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			if got := NormalizeServerStatus(tt.status); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestImageRequiresConfigDrive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tag      *vrmcommon.Tag
+		expected bool
+	}{
+		{name: "nil tag"},
+		{name: "no property", tag: &vrmcommon.Tag{}},
+		{name: "mandatory", tag: &vrmcommon.Tag{Extra: map[string]interface{}{"img_config_drive": "mandatory"}}, expected: true},
+		{name: "optional", tag: &vrmcommon.Tag{Extra: map[string]interface{}{"img_config_drive": "optional"}}},
+		{name: "not a string", tag: &vrmcommon.Tag{Extra: map[string]interface{}{"img_config_drive": true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ImageRequiresConfigDrive(tt.tag); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestImageIsWindows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tag      *vrmcommon.Tag
+		expected bool
+	}{
+		{name: "nil tag"},
+		{name: "no repository", tag: &vrmcommon.Tag{}},
+		{name: "linux", tag: &vrmcommon.Tag{Repository: &vrmcommon.Repository{OperatingSystem: "linux"}}},
+		{name: "windows", tag: &vrmcommon.Tag{Repository: &vrmcommon.Repository{OperatingSystem: "windows"}}, expected: true},
+		{name: "mixed case", tag: &vrmcommon.Tag{Repository: &vrmcommon.Repository{OperatingSystem: "Windows"}}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ImageIsWindows(tt.tag); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIPAddressesByFamily(t *testing.T) {
+	t.Parallel()
+
+	ipv4, ipv6 := IPAddressesByFamily([]string{
+		"2001:db8::10", "10.0.0.10", "10.0.0.9", "203.0.113.5", "2001:db8::9", "::ffff:192.168.0.1", "not-an-ip",
+	})
+
+	if expected := []string{"10.0.0.9", "10.0.0.10", "192.168.0.1", "203.0.113.5"}; !reflect.DeepEqual(ipv4, expected) {
+		t.Errorf("expected IPv4 %v, got %v", expected, ipv4)
+	}
+	if expected := []string{"2001:db8::9", "2001:db8::10"}; !reflect.DeepEqual(ipv6, expected) {
+		t.Errorf("expected IPv6 %v, got %v", expected, ipv6)
+	}
+
+	ipv4, ipv6 = IPAddressesByFamily(nil)
+	if ipv4 == nil || ipv6 == nil || len(ipv4) != 0 || len(ipv6) != 0 {
+		t.Errorf("expected empty, non-nil lists, got %#v and %#v", ipv4, ipv6)
+	}
+}
+
+func TestFindServerByName(t *testing.T) {
+	t.Parallel()
+
+	projectClient := newTestProjectClient(t, "/servers", `{"servers":[
+		{"id":"srv-1","name":"web-1","status":"ACTIVE"},
+		{"id":"srv-2","name":"web","status":"DELETED"},
+		{"id":"srv-3","name":"web","status":"ACTIVE"}
+	]}`)
+	serversClient := projectClient.VPS().Servers()
+
+	tests := []struct {
+		name      string
+		excludeID string
+		expected  string
+	}{
+		{name: "conflict", expected: "srv-3"},
+		{name: "self excluded", excludeID: "srv-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindServerByName(context.Background(), serversClient, "web", tt.excludeID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFindCreatedServer(t *testing.T) {
+	t.Parallel()
+
+	projectClient := newTestProjectClient(t, "/servers", `{"servers":[
+		{"id":"srv-1","name":"web","status":"ACTIVE"},
+		{"id":"srv-2","name":"web","status":"DELETED"},
+		{"id":"srv-3","name":"web","status":"BUILD"},
+		{"id":"srv-4","name":"web-1","status":"BUILD"}
+	]}`)
+	serversClient := projectClient.VPS().Servers()
+
+	tests := []struct {
+		name        string
+		existingIDs map[string]bool
+		expectFound bool
+		expectError bool
+	}{
+		{name: "created", existingIDs: map[string]bool{"srv-1": true}, expectFound: true},
+		{name: "not created", existingIDs: map[string]bool{"srv-1": true, "srv-3": true}},
+		{name: "ambiguous", existingIDs: map[string]bool{}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			serverRes, err := FindCreatedServer(context.Background(), serversClient, "web", tt.existingIDs)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found := serverRes != nil; found != tt.expectFound {
+				t.Fatalf("expected found %t, got %t", tt.expectFound, found)
+			}
+		})
+	}
+}
+
+func TestMapServerToState_NetworkAttachments(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[` +
+				`{"id":"nic-2","network_id":"net-2","addresses":["10.0.2.5"],"sg_ids":["sg-b","sg-a"],"floating_ip":{"id":"fip-1","address":"203.0.113.7"}},` +
+				`{"id":"nic-1","network_id":"net-1","addresses":["10.0.1.5"]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"srv-1","name":"web","status":"ACTIVE","private_ips":["10.0.2.5","10.0.1.5"],"public_ips":["203.0.113.7"]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+	serverRes, err := projectClient.VPS().Servers().Get(context.Background(), "srv-1")
+	if err != nil {
+		t.Fatalf("failed to get server: %v", err)
+	}
+
+	state, diags := MapServerToState(context.Background(), serverRes)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !state.NetworkAttachment.ElementType(context.Background()).Equal(types.ObjectType{AttrTypes: NetworkAttachmentAttrTypes}) {
+		t.Fatalf("network_attachment has element type %s", state.NetworkAttachment.ElementType(context.Background()))
+	}
+
+	var attachments []resourcemodels.NetworkAttachmentModel
+	if diags := state.NetworkAttachment.ElementsAs(context.Background(), &attachments, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(attachments))
+	}
+
+	first, second := attachments[0], attachments[1]
+	if first.NetworkID.ValueString() != "net-1" || !first.Primary.ValueBool() || !first.FloatingIPID.IsNull() || !first.FloatingIP.IsNull() {
+		t.Errorf("unexpected first attachment: %+v", first)
+	}
+	if second.NetworkID.ValueString() != "net-2" || second.IPAddress.ValueString() != "10.0.2.5" ||
+		second.FloatingIPID.ValueString() != "fip-1" || second.FloatingIP.ValueString() != "203.0.113.7" {
+		t.Errorf("unexpected second attachment: %+v", second)
+	}
+	// ip_address keeps the private address and public_ip reports the floating IP.
+	if !first.PublicIP.IsNull() || second.PublicIP.ValueString() != "203.0.113.7" {
+		t.Errorf("expected public_ip to match floating_ip, got %s and %s", first.PublicIP, second.PublicIP)
+	}
+	var sgIDs []string
+	second.SecurityGroupIDs.ElementsAs(context.Background(), &sgIDs, false)
+	if !reflect.DeepEqual(sgIDs, []string{"sg-a", "sg-b"}) {
+		t.Errorf("expected sorted security group IDs, got %v", sgIDs)
+	}
+	if state.NICIDs.Elements()["net-2"] != types.StringValue("nic-2") {
+		t.Errorf("unexpected nic_ids: %s", state.NICIDs)
+	}
+}
+
+func TestWaitForServerStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		status         string
+		targets        []servermodels.ServerStatus
+		expectedError  string
+		expectedStatus servermodels.ServerStatus
+	}{
+		{
+			name:           "any target",
+			status:         "SHUTOFF",
+			targets:        []servermodels.ServerStatus{servermodels.ServerStatusActive, servermodels.ServerStatusShutoff},
+			expectedStatus: servermodels.ServerStatusShutoff,
+		},
+		{
+			name:           "case insensitive",
+			status:         "active",
+			targets:        []servermodels.ServerStatus{servermodels.ServerStatusActive},
+			expectedStatus: "active",
+		},
+		{
+			name:          "fails fast on error",
+			status:        "ERROR",
+			targets:       []servermodels.ServerStatus{"VERIFY_RESIZE", servermodels.ServerStatusShutoff},
+			expectedError: "server entered ERROR state while waiting for VERIFY_RESIZE or SHUTOFF",
+		},
+		{
+			name:           "error as target",
+			status:         "ERROR",
+			targets:        []servermodels.ServerStatus{servermodels.ServerStatusActive, servermodels.ServerStatusError},
+			expectedStatus: servermodels.ServerStatusError,
+		},
+		{
+			name:          "timeout",
+			status:        "BUILD",
+			targets:       []servermodels.ServerStatus{servermodels.ServerStatusActive},
+			expectedError: "waiting for ACTIVE, last status BUILD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Only the timeout case should wait; the others return on the first read.
+			timeout := time.Minute
+			if tt.name == "timeout" {
+				timeout = 50 * time.Millisecond
+			}
+
+			projectClient := newTestProjectClient(t, "/servers/srv-1", `{"id":"srv-1","status":"`+tt.status+`"}`)
+			start := time.Now()
+			serverRes, err := WaitForServerStatuses(context.Background(), projectClient.VPS().Servers(), "srv-1", tt.targets, timeout)
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("expected the wait to return promptly, took %s", elapsed)
+			}
+
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got: %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if serverRes.Server.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, serverRes.Server.Status)
+			}
+		})
+	}
+}
+
+// statusServerGetter returns a server with the given status, or err.
+type statusServerGetter struct {
+	status servermodels.ServerStatus
+	err    error
+}
+
+func (f statusServerGetter) Get(ctx context.Context, serverID string) (*serversdk.ServerResource, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &serversdk.ServerResource{Server: &servermodels.Server{ID: serverID, Status: f.status}}, nil
+}
+
+func TestWaitForServerDeleted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		client        statusServerGetter
+		expectedError string
+	}{
+		{name: "soft deleted", client: statusServerGetter{status: "SOFT_DELETED"}},
+		{name: "deleted", client: statusServerGetter{status: servermodels.ServerStatusDeleted}},
+		{name: "case insensitive", client: statusServerGetter{status: "soft_deleted"}},
+		{name: "not found", client: statusServerGetter{err: cloudsdk.NewSDKError(404, 0, "server not found", nil, nil)}},
+		{
+			name:          "still active",
+			client:        statusServerGetter{status: servermodels.ServerStatusActive},
+			expectedError: "waiting for server to be deleted, last status ACTIVE",
+		},
+		{
+			name:          "read failure is not a deletion",
+			client:        statusServerGetter{err: cloudsdk.NewSDKError(503, 0, "service unavailable", nil, nil)},
+			expectedError: "last status unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := WaitForServerDeleted(context.Background(), tt.client, "srv-1", 50*time.Millisecond)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got: %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWaitForServerActive_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		server   string
+		expected []string
+	}{
+		{
+			name:   "stuck in build",
+			server: `{"id":"srv-1","status":"BUILD","status_reason":"waiting for a host with a free GPU"}`,
+			expected: []string{
+				"server srv-1 did not become active within 50ms",
+				"last status BUILD after",
+				"fault: waiting for a host with a free GPU",
+				"precheck_quota",
+			},
+		},
+		{
+			name:   "error",
+			server: `{"id":"srv-1","status":"ERROR","status_reason":"No valid host was found"}`,
+			expected: []string{
+				"server srv-1 entered ERROR status (last status ERROR after 0s, fault: No valid host was found)",
+				"must be recreated",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectClient := newTestProjectClient(t, "/servers/srv-1", tt.server)
+			_, err := WaitForServerActive(context.Background(), projectClient.VPS().Servers(), "srv-1", 50*time.Millisecond)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got: %s", want, err)
+				}
+			}
+		})
+	}
+}
+
+// fakeNICAdder fails its first len(errs) calls with errs, in order.
+type fakeNICAdder struct {
+	errs  []error
+	calls int
+}
+
+func (c *fakeNICAdder) Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return &servermodels.ServerNIC{ID: "nic-1", NetworkID: req.NetworkID}, nil
+}
+
+func TestAddNICWithRetry(t *testing.T) {
+	t.Parallel()
+
+	allocation := errors.New("(neutron)IP address 10.0.0.5 already allocated in subnet")
+	config := RetryConfig{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		timeout     time.Duration
+		canceled    bool
+		expectErr   bool
+		expectCalls int
+	}{
+		{name: "first call succeeds", timeout: time.Minute, expectCalls: 1},
+		{name: "allocation error then success", errs: []error{allocation, allocation}, timeout: time.Minute, expectCalls: 3},
+		{name: "attempts exhausted", errs: []error{allocation, allocation, allocation}, timeout: time.Minute, expectErr: true, expectCalls: 3},
+		{name: "not an allocation error", errs: []error{errors.New("quota exceeded")}, timeout: time.Minute, expectErr: true, expectCalls: 1},
+		{name: "timeout too short to wait", errs: []error{allocation}, timeout: 0, expectErr: true, expectCalls: 1},
+		{name: "context canceled", errs: []error{allocation}, timeout: time.Minute, canceled: true, expectErr: true, expectCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			client := &fakeNICAdder{errs: tt.errs}
+			err := addNICWithRetry(ctx, config, client, &servermodels.ServerNICCreateRequest{NetworkID: "net-1"}, tt.timeout)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+			if client.calls != tt.expectCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectCalls, client.calls)
+			}
+		})
+	}
+}
+
+// fakeNICReattacher records NIC operations and fails adds of the fixed IPs
+// in errs.
+type fakeNICReattacher struct {
+	errs  map[string]error
+	calls []string
+}
+
+func (c *fakeNICReattacher) Add(ctx context.Context, req *servermodels.ServerNICCreateRequest) (*servermodels.ServerNIC, error) {
+	c.calls = append(c.calls, "add "+req.FixedIP)
+	if err := c.errs[req.FixedIP]; err != nil {
+		return nil, err
+	}
+	return &servermodels.ServerNIC{ID: "nic-2", NetworkID: req.NetworkID}, nil
+}
+
+func (c *fakeNICReattacher) Delete(ctx context.Context, nicID string) error {
+	c.calls = append(c.calls, "delete "+nicID)
+	return nil
+}
+
+func TestReattachNIC(t *testing.T) {
+	t.Parallel()
+
+	allocation := errors.New("(neutron)IP address 10.0.0.20 already allocated in subnet")
+	config := RetryConfig{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+	req := servermodels.ServerNICCreateRequest{NetworkID: "net-1", SGIDs: []string{"sg-2"}, FixedIP: "10.0.0.20"}
+	restore := servermodels.ServerNICCreateRequest{NetworkID: "net-1", SGIDs: []string{"sg-1"}, FixedIP: "10.0.0.5"}
+
+	tests := []struct {
+		name          string
+		errs          map[string]error
+		expectErr     string
+		expectedCalls []string
+	}{
+		{
+			name:          "reattached",
+			expectedCalls: []string{"delete nic-1", "add 10.0.0.20"},
+		},
+		{
+			name:          "new address taken, previous one restored",
+			errs:          map[string]error{"10.0.0.20": allocation},
+			expectErr:     "was restored with its previous IP 10.0.0.5",
+			expectedCalls: []string{"delete nic-1", "add 10.0.0.20", "add 10.0.0.20", "add 10.0.0.5"},
+		},
+		{
+			name:          "restore fails too",
+			errs:          map[string]error{"10.0.0.20": errors.New("quota exceeded"), "10.0.0.5": errors.New("quota exceeded")},
+			expectErr:     "nor restored with its previous IP 10.0.0.5",
+			expectedCalls: []string{"delete nic-1", "add 10.0.0.20", "add 10.0.0.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeNICReattacher{errs: tt.errs}
+			err := reattachNIC(context.Background(), config, client, "nic-1", &req, &restore, time.Minute)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if !reflect.DeepEqual(client.calls, tt.expectedCalls) {
+				t.Errorf("expected calls %v, got %v", tt.expectedCalls, client.calls)
+			}
+		})
+	}
+}
+
+// fakeNICLister returns the NIC addresses in addresses, one entry per call;
+// the last entry repeats.
+type fakeNICLister struct {
+	addresses [][]string
+	calls     int
+}
+
+func (f *fakeNICLister) List(ctx context.Context) ([]*servermodels.ServerNIC, error) {
+	i := min(f.calls, len(f.addresses)-1)
+	f.calls++
+	return []*servermodels.ServerNIC{
+		{ID: "nic-0", NetworkID: "net-0", Addresses: []string{"10.9.0.5"}},
+		{ID: "nic-1", NetworkID: "net-1", Addresses: f.addresses[i]},
+	}, nil
+}
+
+func TestWaitForNICAddress(t *testing.T) {
+	oldInterval := nicAddressPollInterval
+	nicAddressPollInterval = time.Millisecond
+	t.Cleanup(func() { nicAddressPollInterval = oldInterval })
+
+	tests := []struct {
+		name        string
+		addresses   [][]string
+		expectErr   string
+		expectCalls int
+	}{
+		{name: "kept", addresses: [][]string{{"10.0.0.5"}}, expectCalls: 1},
+		{name: "reported after a while", addresses: [][]string{nil, {"10.0.0.5"}}, expectCalls: 2},
+		{name: "replaced by dhcp", addresses: [][]string{{"10.0.0.77"}}, expectErr: "reports address 10.0.0.77 instead of its fixed IP 10.0.0.5", expectCalls: 1},
+		{name: "never reported", addresses: [][]string{nil}, expectErr: "did not report its fixed IP 10.0.0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nics := &fakeNICLister{addresses: tt.addresses}
+			err := WaitForNICAddress(context.Background(), nics, "net-1", "10.0.0.5", 20*time.Millisecond)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if tt.expectCalls > 0 && nics.calls != tt.expectCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectCalls, nics.calls)
+			}
+		})
+	}
+}
+
+type fakeFloatingIPGetter struct {
+	deviceIDs []string
+	calls     int
+}
+
+func (f *fakeFloatingIPGetter) Get(_ context.Context, id string) (*floatingipmodels.FloatingIP, error) {
+	i := f.calls
+	f.calls++
+	if i >= len(f.deviceIDs) {
+		i = len(f.deviceIDs) - 1
+	}
+	if f.deviceIDs[i] == "error" {
+		return nil, errors.New("HTTP 502")
+	}
+	return &floatingipmodels.FloatingIP{ID: id, DeviceID: f.deviceIDs[i]}, nil
+}
+
+func TestWaitForFloatingIPAssociated(t *testing.T) {
+	oldInterval := floatingIPPollInterval
+	floatingIPPollInterval = time.Millisecond
+	t.Cleanup(func() { floatingIPPollInterval = oldInterval })
+
+	t.Run("associated", func(t *testing.T) {
+		fips := &fakeFloatingIPGetter{deviceIDs: []string{"", "error", "srv-1"}}
+		if err := WaitForFloatingIPAssociated(context.Background(), fips, "fip-1", "srv-1", time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fips.calls != 3 {
+			t.Errorf("expected 3 calls, got %d", fips.calls)
+		}
+	})
+
+	t.Run("times out on another device", func(t *testing.T) {
+		fips := &fakeFloatingIPGetter{deviceIDs: []string{"srv-2"}}
+		err := WaitForFloatingIPAssociated(context.Background(), fips, "fip-1", "srv-1", 5*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timeout") {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	})
+}
+
+func TestWaitForFloatingIPDisassociated(t *testing.T) {
+	oldInterval := floatingIPPollInterval
+	floatingIPPollInterval = time.Millisecond
+	t.Cleanup(func() { floatingIPPollInterval = oldInterval })
+
+	t.Run("disassociated", func(t *testing.T) {
+		fips := &fakeFloatingIPGetter{deviceIDs: []string{"srv-1", "error", ""}}
+		if err := WaitForFloatingIPDisassociated(context.Background(), fips, "fip-1", time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fips.calls != 3 {
+			t.Errorf("expected 3 calls, got %d", fips.calls)
+		}
+	})
+
+	t.Run("times out while still associated", func(t *testing.T) {
+		fips := &fakeFloatingIPGetter{deviceIDs: []string{"srv-1"}}
+		err := WaitForFloatingIPDisassociated(context.Background(), fips, "fip-1", 5*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timeout") {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	})
+}
+
+func TestFloatingIPChanges(t *testing.T) {
+	t.Parallel()
+
+	attachment := func(networkID, floatingIPID string) resourcemodels.NetworkAttachmentModel {
+		att := resourcemodels.NetworkAttachmentModel{NetworkID: types.StringValue(networkID), FloatingIPID: types.StringNull()}
+		if floatingIPID != "" {
+			att.FloatingIPID = types.StringValue(floatingIPID)
+		}
+		return att
+	}
+
+	tests := []struct {
+		name                  string
+		plan                  []resourcemodels.NetworkAttachmentModel
+		state                 []resourcemodels.NetworkAttachmentModel
+		reattached            map[string]bool
+		expectedDisassociate  []string
+		expectedAssociateFIPs []string
+	}{
+		{
+			name:                  "unchanged",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			expectedDisassociate:  []string{},
+			expectedAssociateFIPs: []string{},
+		},
+		{
+			name:                  "swap on the same network",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-2")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			expectedDisassociate:  []string{"fip-1"},
+			expectedAssociateFIPs: []string{"fip-2"},
+		},
+		{
+			name:                  "added and removed",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", ""), attachment("net-2", "fip-2")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+			expectedDisassociate:  []string{"fip-1"},
+			expectedAssociateFIPs: []string{"fip-2"},
+		},
+		{
+			name:                  "moved to another network",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", ""), attachment("net-2", "fip-1")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+			expectedDisassociate:  []string{"fip-1"},
+			expectedAssociateFIPs: []string{"fip-1"},
+		},
+		{
+			name:                  "network removed",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "fip-3"), attachment("net-3", "fip-2")},
+			expectedDisassociate:  []string{"fip-2", "fip-3"},
+			expectedAssociateFIPs: []string{},
+		},
+		{
+			name:                  "reattached NIC",
+			plan:                  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			state:                 []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			reattached:            map[string]bool{"net-1": true},
+			expectedDisassociate:  []string{},
+			expectedAssociateFIPs: []string{"fip-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			toDisassociate, toAssociate := FloatingIPChanges(tt.plan, tt.state, tt.reattached)
+			if !reflect.DeepEqual(toDisassociate, tt.expectedDisassociate) {
+				t.Errorf("expected to disassociate %v, got %v", tt.expectedDisassociate, toDisassociate)
+			}
+			associateFIPs := []string{}
+			for _, att := range toAssociate {
+				associateFIPs = append(associateFIPs, att.FloatingIPID.ValueString())
+			}
+			if !reflect.DeepEqual(associateFIPs, tt.expectedAssociateFIPs) {
+				t.Errorf("expected to associate %v, got %v", tt.expectedAssociateFIPs, associateFIPs)
+			}
+		})
+	}
+}
+
+func TestFloatingIPMoves(t *testing.T) {
+	t.Parallel()
+
+	attachment := func(networkID, floatingIPID string) resourcemodels.NetworkAttachmentModel {
+		att := resourcemodels.NetworkAttachmentModel{NetworkID: types.StringValue(networkID), FloatingIPID: types.StringNull()}
+		if floatingIPID != "" {
+			att.FloatingIPID = types.StringValue(floatingIPID)
+		}
+		return att
+	}
+
+	tests := []struct {
+		name     string
+		plan     []resourcemodels.NetworkAttachmentModel
+		state    []resourcemodels.NetworkAttachmentModel
+		expected []resourcemodels.FloatingIPMove
+	}{
+		{
+			name:  "unchanged",
+			plan:  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+			state: []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+		},
+		{
+			name:     "moved",
+			plan:     []resourcemodels.NetworkAttachmentModel{attachment("net-1", ""), attachment("net-2", "fip-1")},
+			state:    []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+			expected: []resourcemodels.FloatingIPMove{{FloatingIPID: "fip-1", FromNetworkID: "net-1", ToNetworkID: "net-2"}},
+		},
+		{
+			name:  "exchanged",
+			plan:  []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-2"), attachment("net-2", "fip-1")},
+			state: []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "fip-2")},
+			expected: []resourcemodels.FloatingIPMove{
+				{FloatingIPID: "fip-1", FromNetworkID: "net-1", ToNetworkID: "net-2"},
+				{FloatingIPID: "fip-2", FromNetworkID: "net-2", ToNetworkID: "net-1"},
+			},
+		},
+		{
+			name:     "moved to a new network",
+			plan:     []resourcemodels.NetworkAttachmentModel{attachment("net-1", ""), attachment("net-3", "fip-1")},
+			state:    []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1")},
+			expected: []resourcemodels.FloatingIPMove{{FloatingIPID: "fip-1", FromNetworkID: "net-1", ToNetworkID: "net-3"}},
+		},
+		{
+			name:  "replaced by another floating IP",
+			plan:  []resourcemodels.NetworkAttachmentModel{attachment("net-1", ""), attachment("net-2", "fip-2")},
+			state: []resourcemodels.NetworkAttachmentModel{attachment("net-1", "fip-1"), attachment("net-2", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if moves := FloatingIPMoves(tt.plan, tt.state); !reflect.DeepEqual(moves, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, moves)
+			}
+		})
+	}
+}
+
+func TestAssociateFloatingIPsForServer(t *testing.T) {
+	t.Parallel()
+
+	// nic-2 rejects every association; nic-1 accepts them.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[{"id":"nic-1","network_id":"net-1"},{"id":"nic-2","network_id":"net-2"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/nics/nic-2/floatingip"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"floating ip in use"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"srv-1"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+	serverRes, err := projectClient.VPS().Servers().Get(context.Background(), "srv-1")
+	if err != nil {
+		t.Fatalf("failed to get server: %v", err)
+	}
+
+	attachments := []resourcemodels.NetworkAttachmentModel{
+		{NetworkID: types.StringValue("net-1"), FloatingIPID: types.StringValue("fip-1")},
+		{NetworkID: types.StringValue("net-2"), FloatingIPID: types.StringValue("fip-2")},
+		{NetworkID: types.StringValue("net-3"), FloatingIPID: types.StringValue("fip-3")},
+		{NetworkID: types.StringValue("net-1"), FloatingIPID: types.StringNull()},
+	}
+
+	tests := []struct {
+		mode     string
+		errors   int
+		warnings int
+	}{
+		{mode: FloatingIPAssociationStrict, errors: 2},
+		{mode: FloatingIPAssociationBestEffort, warnings: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Parallel()
+
+			diags := AssociateFloatingIPsForServer(context.Background(), serverRes, nil, attachments, tt.mode, 0)
+			if diags.ErrorsCount() != tt.errors || diags.WarningsCount() != tt.warnings {
+				t.Fatalf("expected %d errors and %d warnings, got %v", tt.errors, tt.warnings, diags)
+			}
+			if tt.mode == FloatingIPAssociationBestEffort {
+				summary := diags[len(diags)-1].Detail()
+				if !strings.Contains(summary, "Associated 1 of 3") || !strings.Contains(summary, "fip-2") || !strings.Contains(summary, "fip-3") {
+					t.Errorf("unexpected summary: %s", summary)
+				}
+			}
+		})
+	}
+}
+
+// newTestProjectClient returns an SDK project client backed by a fake API that
+// answers requests whose path ends in suffix with body, and everything else
+// (such as the IAM project lookup) with an empty object.
+func newTestProjectClient(t *testing.T, suffix, body string) *cloudsdk.ProjectClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, suffix) {
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+	return projectClient
+}
+
+func TestDescriptionValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		description string
+		prior       types.String
+		expected    types.String
+	}{
+		{name: "set", description: "web tier", prior: types.StringNull(), expected: types.StringValue("web tier")},
+		{name: "empty", description: "", prior: types.StringNull(), expected: types.StringNull()},
+		{name: "empty while planned", description: "", prior: types.StringUnknown(), expected: types.StringNull()},
+		{name: "empty configured as empty", description: "", prior: types.StringValue(""), expected: types.StringValue("")},
+		{name: "removed out of band", description: "", prior: types.StringValue("web tier"), expected: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DescriptionValue(tt.description, tt.prior); !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestServerUserData(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "cloud-init.yaml")
+	if err := os.WriteFile(file, []byte("#cloud-config\n"), 0o600); err != nil {
+		t.Fatalf("failed to write user data file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		model       resourcemodels.ServerResourceModel
+		expected    types.String
+		expectError bool
+	}{
+		{
+			name:     "neither set",
+			model:    resourcemodels.ServerResourceModel{UserData: types.StringNull(), UserDataFile: types.StringNull()},
+			expected: types.StringNull(),
+		},
+		{
+			name:     "inline",
+			model:    resourcemodels.ServerResourceModel{UserData: types.StringValue("#cloud-config\n"), UserDataFile: types.StringNull()},
+			expected: types.StringValue("#cloud-config\n"),
+		},
+		{
+			name:     "from file",
+			model:    resourcemodels.ServerResourceModel{UserData: types.StringNull(), UserDataFile: types.StringValue(file)},
+			expected: types.StringValue("#cloud-config\n"),
+		},
+		{
+			name:     "file unknown",
+			model:    resourcemodels.ServerResourceModel{UserData: types.StringNull(), UserDataFile: types.StringUnknown()},
+			expected: types.StringUnknown(),
+		},
+		{
+			name:        "file missing",
+			model:       resourcemodels.ServerResourceModel{UserData: types.StringNull(), UserDataFile: types.StringValue(file + ".missing")},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userData, err := ServerUserData(tt.model)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !userData.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, userData)
+			}
+		})
+	}
+}
+
+func TestServerRevision(t *testing.T) {
+	t.Parallel()
+
+	image := types.StringValue("11111111-1111-1111-1111-111111111111")
+	flavor := types.StringValue("22222222-2222-2222-2222-222222222222")
+	keypair := types.StringValue("deploy")
+	userData := types.StringValue("I2Nsb3VkLWNvbmZpZw==")
+	base := ServerRevision(image, flavor, keypair, userData)
+
+	if base.IsUnknown() || base.ValueString() == "" {
+		t.Fatalf("expected a known revision, got %s", base)
+	}
+	if strings.Contains(base.ValueString(), userData.ValueString()) {
+		t.Errorf("expected the revision not to contain user_data, got %s", base)
+	}
+	if again := ServerRevision(image, flavor, keypair, userData); !again.Equal(base) {
+		t.Errorf("expected the same inputs to give %s, got %s", base, again)
+	}
+
+	tests := []struct {
+		name     string
+		revision types.String
+	}{
+		{name: "image changed", revision: ServerRevision(types.StringValue("33333333-3333-3333-3333-333333333333"), flavor, keypair, userData)},
+		{name: "flavor changed", revision: ServerRevision(image, types.StringValue("44444444-4444-4444-4444-444444444444"), keypair, userData)},
+		{name: "keypair changed", revision: ServerRevision(image, flavor, types.StringValue("ops"), userData)},
+		{name: "keypair removed", revision: ServerRevision(image, flavor, types.StringNull(), userData)},
+		{name: "user_data changed", revision: ServerRevision(image, flavor, keypair, types.StringValue("I2Nsb3VkLWNvbmZpZwo="))},
+		{name: "user_data removed", revision: ServerRevision(image, flavor, keypair, types.StringNull())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.revision.IsUnknown() || tt.revision.Equal(base) {
+				t.Errorf("expected a new revision, got %s", tt.revision)
+			}
+		})
+	}
+
+	t.Run("unknown input", func(t *testing.T) {
+		t.Parallel()
+
+		if revision := ServerRevision(types.StringUnknown(), flavor, keypair, userData); !revision.IsUnknown() {
+			t.Errorf("expected an unknown revision, got %s", revision)
+		}
+	})
+}
+
+func TestMapServerVolumes(t *testing.T) {
+	t.Parallel()
+
+	volumes := []*servermodels.ServerVolume{
+		{VolumeID: "vol-data-2", Device: "/dev/vdc", Volume: &volumemodels.Volume{ID: "vol-data-2", Size: 50}},
+		{VolumeID: "vol-root", Device: "/dev/vda", System: true, Volume: &volumemodels.Volume{ID: "vol-root", Size: 20}},
+		nil,
+		{VolumeID: "vol-data-1", Device: "/dev/vdb"},
+	}
+
+	got := MapServerVolumes(volumes)
+
+	expected := []resourcemodels.ServerVolumeModel{
+		{ID: types.StringValue("vol-root"), Device: types.StringValue("/dev/vda"), SizeGB: types.Int64Value(20), BootIndex: types.Int64Value(0)},
+		{ID: types.StringValue("vol-data-1"), Device: types.StringValue("/dev/vdb"), SizeGB: types.Int64Null(), BootIndex: types.Int64Null()},
+		{ID: types.StringValue("vol-data-2"), Device: types.StringValue("/dev/vdc"), SizeGB: types.Int64Value(50), BootIndex: types.Int64Null()},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateWaitTargets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		waitUntilStatus types.String
+		waitForActive   types.Bool
+		expected        []servermodels.ServerStatus
+	}{
+		{
+			name:            "default",
+			waitUntilStatus: types.StringNull(),
+			waitForActive:   types.BoolNull(),
+			expected:        []servermodels.ServerStatus{servermodels.ServerStatusActive},
+		},
+		{
+			name:            "no wait",
+			waitUntilStatus: types.StringNull(),
+			waitForActive:   types.BoolValue(false),
+			expected:        nil,
+		},
+		{
+			name:            "shutoff overrides wait_for_active",
+			waitUntilStatus: types.StringValue("shutoff"),
+			waitForActive:   types.BoolValue(false),
+			expected:        []servermodels.ServerStatus{servermodels.ServerStatusShutoff, servermodels.ServerStatusActive},
+		},
+		{
+			name:            "building",
+			waitUntilStatus: types.StringValue("building"),
+			waitForActive:   types.BoolValue(true),
+			expected:        []servermodels.ServerStatus{servermodels.ServerStatusBuild, servermodels.ServerStatusActive},
+		},
+		{
+			name:            "active",
+			waitUntilStatus: types.StringValue("active"),
+			waitForActive:   types.BoolValue(false),
+			expected:        []servermodels.ServerStatus{servermodels.ServerStatusActive},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CreateWaitTargets(tt.waitUntilStatus, tt.waitForActive); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
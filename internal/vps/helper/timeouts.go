@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DeleteTimeoutsAttrTypes are the attribute types of the timeouts block of
+// resources that only wait on delete.
+var DeleteTimeoutsAttrTypes = map[string]attr.Type{
+	"delete": types.StringType,
+}
+
+// ResolveTimeout returns the timeout a timeouts block sets for op ("create",
+// "update" or "delete"), or fallback when the block or the attribute is not
+// set. A value that is not a Go duration is reported as an error on the
+// attribute instead of silently falling back.
+func ResolveTimeout(ctx context.Context, timeouts types.Object, op string, fallback time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return fallback, diags
+	}
+
+	value, ok := timeouts.Attributes()[op].(types.String)
+	if !ok {
+		diags.AddError(
+			"Unsupported Timeout",
+			fmt.Sprintf("The timeouts block has no %q timeout. Please report this issue to the provider developers.", op),
+		)
+		return fallback, diags
+	}
+	if value.IsNull() || value.IsUnknown() {
+		return fallback, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(op),
+			"Invalid Timeout",
+			fmt.Sprintf("The %s timeout %q is not a valid Go duration such as \"15m\" or \"1h\": %s", op, value.ValueString(), err),
+		)
+		return fallback, diags
+	}
+	return timeout, diags
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveTimeout(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+	block := func(create, update, del types.String) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"create": create,
+			"update": update,
+			"delete": del,
+		})
+	}
+	const fallback = 7 * time.Minute
+
+	tests := []struct {
+		name        string
+		timeouts    types.Object
+		op          string
+		expected    time.Duration
+		expectError bool
+	}{
+		{
+			name:     "missing block",
+			timeouts: types.ObjectNull(attrTypes),
+			op:       "create",
+			expected: fallback,
+		},
+		{
+			name:     "set",
+			timeouts: block(types.StringValue("15m"), types.StringNull(), types.StringNull()),
+			op:       "create",
+			expected: 15 * time.Minute,
+		},
+		{
+			name:     "partial block",
+			timeouts: block(types.StringValue("15m"), types.StringNull(), types.StringNull()),
+			op:       "update",
+			expected: fallback,
+		},
+		{
+			name:     "delete",
+			timeouts: block(types.StringNull(), types.StringNull(), types.StringValue("1h30m")),
+			op:       "delete",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:        "invalid duration",
+			timeouts:    block(types.StringNull(), types.StringValue("bogus"), types.StringNull()),
+			op:          "update",
+			expected:    fallback,
+			expectError: true,
+		},
+		{
+			name:        "duration without unit",
+			timeouts:    block(types.StringValue("10"), types.StringNull(), types.StringNull()),
+			op:          "create",
+			expected:    fallback,
+			expectError: true,
+		},
+		{
+			name:        "unsupported operation",
+			timeouts:    block(types.StringValue("15m"), types.StringNull(), types.StringNull()),
+			op:          "read",
+			expected:    fallback,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			timeout, diags := ResolveTimeout(context.Background(), tt.timeouts, tt.op, fallback)
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, diags)
+			}
+			if timeout != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, timeout)
+			}
+		})
+	}
+}
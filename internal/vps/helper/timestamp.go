@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TimestampValue returns an API timestamp formatted as RFC3339 in UTC. Some
+// responses (e.g. those of update calls) omit timestamps, so an empty value
+// keeps prior instead of flipping the attribute to null. Values that do not
+// parse are stored as returned.
+func TimestampValue(raw string, prior types.String) types.String {
+	if raw == "" {
+		if prior.IsUnknown() {
+			return types.StringNull()
+		}
+		return prior
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return types.StringValue(raw)
+	}
+	return types.StringValue(parsed.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimestampValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		raw      string
+		prior    types.String
+		expected types.String
+	}{
+		{
+			name:     "rfc3339",
+			raw:      "2025-11-10T15:30:00Z",
+			prior:    types.StringNull(),
+			expected: types.StringValue("2025-11-10T15:30:00Z"),
+		},
+		{
+			name:     "fractional seconds and offset",
+			raw:      "2025-11-10T23:30:45.123+08:00",
+			prior:    types.StringNull(),
+			expected: types.StringValue("2025-11-10T15:30:45Z"),
+		},
+		{
+			name:     "empty keeps prior",
+			prior:    types.StringValue("2025-11-10T15:30:00Z"),
+			expected: types.StringValue("2025-11-10T15:30:00Z"),
+		},
+		{
+			name:     "empty without prior",
+			prior:    types.StringUnknown(),
+			expected: types.StringNull(),
+		},
+		{
+			name:     "unparseable",
+			raw:      "2025-11-10 15:30:00",
+			prior:    types.StringNull(),
+			expected: types.StringValue("2025-11-10 15:30:00"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := TimestampValue(tt.raw, tt.prior); !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
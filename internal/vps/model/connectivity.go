@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ConnectivityDataSourceModel describes the zillaforge_connectivity data source.
+type ConnectivityDataSourceModel struct {
+	Reachable types.Bool   `tfsdk:"reachable"`
+	LatencyMS types.Int64  `tfsdk:"latency_ms"`
+	ProjectID types.String `tfsdk:"project_id"`
+	Error     types.String `tfsdk:"error"` // Null when reachable
+}
@@ -9,9 +9,13 @@ import (
 
 // FlavorDataSourceModel describes the data source data model.
 type FlavorDataSourceModel struct {
-	Name   types.String `tfsdk:"name"`
-	VCPUs  types.Int64  `tfsdk:"vcpus"`
-	Memory types.Int64  `tfsdk:"memory"`
+	Name        types.String `tfsdk:"name"`
+	NamePattern types.String `tfsdk:"name_pattern"`
+	VCPUs       types.Int64  `tfsdk:"vcpus"`
+	Memory      types.Int64  `tfsdk:"memory"`
+	MinVCPUs    types.Int64  `tfsdk:"min_vcpus"`
+	MinMemoryMB types.Int64  `tfsdk:"min_memory_mb"`
+	SortBy      types.String `tfsdk:"sort_by"`
 
 	Flavors []FlavorModel `tfsdk:"flavors"`
 }
@@ -12,12 +12,16 @@ type FloatingIPResourceModel struct {
 	// Optional user-provided attributes
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
+	// BandwidthMbps is recorded in state only; the API has no bandwidth cap.
+	BandwidthMbps types.Int64 `tfsdk:"bandwidth_mbps"`
 
 	// Computed attributes (read-only)
 	ID        types.String `tfsdk:"id"`
 	IPAddress types.String `tfsdk:"ip_address"`
 	Status    types.String `tfsdk:"status"`
 	DeviceID  types.String `tfsdk:"device_id"`
+	// AssociatedServerName is the name of the server DeviceID points at.
+	AssociatedServerName types.String `tfsdk:"associated_server_name"`
 }
 
 // FloatingIPDataSourceModel describes the data source config and results.
@@ -15,13 +15,20 @@ type KeypairResourceModel struct {
 	PublicKey   types.String `tfsdk:"public_key"`
 	PrivateKey  types.String `tfsdk:"private_key"` // Sensitive
 	Fingerprint types.String `tfsdk:"fingerprint"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+
+	RegenerateTrigger types.String `tfsdk:"regenerate_trigger"` // Not sent to the API
+
+	Timeouts types.Object `tfsdk:"timeouts"` // delete only
 }
 
 // KeypairDataSourceModel describes the data source config and filters.
 type KeypairDataSourceModel struct {
-	ID       types.String   `tfsdk:"id"`       // Optional filter
-	Name     types.String   `tfsdk:"name"`     // Optional filter
-	Keypairs []KeypairModel `tfsdk:"keypairs"` // Computed results
+	ID          types.String   `tfsdk:"id"`          // Optional filter
+	Name        types.String   `tfsdk:"name"`        // Optional filter
+	Fingerprint types.String   `tfsdk:"fingerprint"` // Optional filter
+	Keypairs    []KeypairModel `tfsdk:"keypairs"`    // Computed results
 }
 
 // KeypairModel represents a single keypair in the results list.
@@ -20,3 +20,33 @@ type NetworkModel struct {
 	Status      types.String `tfsdk:"status"`
 	Description types.String `tfsdk:"description"`
 }
+
+// NetworkLookupModel describes the zillaforge_network data source.
+type NetworkLookupModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Status      types.String `tfsdk:"status"`
+	CIDR        types.String `tfsdk:"cidr"`
+	GatewayIP   types.String `tfsdk:"gateway_ip"`
+	EnableDHCP  types.Bool   `tfsdk:"enable_dhcp"`
+	SubnetID    types.String `tfsdk:"subnet_id"`
+}
+
+type SubnetDataSourceModel struct {
+	NetworkID types.String  `tfsdk:"network_id"`
+	Subnets   []SubnetModel `tfsdk:"subnets"`
+}
+
+type SubnetModel struct {
+	ID              types.String          `tfsdk:"id"`
+	CIDR            types.String          `tfsdk:"cidr"`
+	GatewayIP       types.String          `tfsdk:"gateway_ip"`
+	AllocationPools []AllocationPoolModel `tfsdk:"allocation_pools"`
+	DNSNameservers  []types.String        `tfsdk:"dns_nameservers"`
+}
+
+type AllocationPoolModel struct {
+	Start types.String `tfsdk:"start"`
+	End   types.String `tfsdk:"end"`
+}
@@ -12,10 +12,51 @@ type SecurityGroupResourceModel struct {
 	Description types.String `tfsdk:"description"`
 	IngressRule types.List   `tfsdk:"ingress_rule"`
 	EgressRule  types.List   `tfsdk:"egress_rule"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+
+	CreateDefaultRules types.Bool `tfsdk:"create_default_rules"`
+	DefaultRules       types.List `tfsdk:"default_rules"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+	RulesAsSet         types.Bool `tfsdk:"rules_as_set"`
+	RenameInPlace      types.Bool `tfsdk:"rename_in_place"`
+
+	Timeouts types.Object `tfsdk:"timeouts"` // delete only
 }
 
-// SecurityRuleModel represents a firewall rule in the schema.
+// DefaultSecurityGroupResourceModel describes the resource that manages the
+// rules of the project's platform-provided default security group.
+type DefaultSecurityGroupResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	IngressRule types.List   `tfsdk:"ingress_rule"`
+	EgressRule  types.List   `tfsdk:"egress_rule"`
+}
+
+// DefaultRuleModel describes a rule seeded by create_default_rules.
+type DefaultRuleModel struct {
+	Direction types.String `tfsdk:"direction"`
+	Protocol  types.String `tfsdk:"protocol"`
+	PortRange types.String `tfsdk:"port_range"`
+	CIDR      types.String `tfsdk:"cidr"`
+}
+
+// SecurityRuleModel represents a firewall rule of the security group resource.
+// A rule sets either the singular CIDR of its direction or the list, which
+// the provider expands into one API rule per CIDR.
 type SecurityRuleModel struct {
+	Protocol         types.String `tfsdk:"protocol"`
+	PortRange        types.String `tfsdk:"port_range"`
+	SourceCIDR       types.String `tfsdk:"source_cidr"`       // For ingress only
+	SourceCIDRs      types.List   `tfsdk:"source_cidrs"`      // For ingress only
+	DestinationCIDR  types.String `tfsdk:"destination_cidr"`  // For egress only
+	DestinationCIDRs types.List   `tfsdk:"destination_cidrs"` // For egress only
+}
+
+// SecurityRuleDataModel represents a firewall rule in the data source, one
+// per API rule.
+type SecurityRuleDataModel struct {
 	Protocol        types.String `tfsdk:"protocol"`
 	PortRange       types.String `tfsdk:"port_range"`
 	SourceCIDR      types.String `tfsdk:"source_cidr"`      // For ingress only
@@ -31,9 +72,9 @@ type SecurityGroupsDataSourceModel struct {
 
 // SecurityGroupDataModel represents a single security group in the results.
 type SecurityGroupDataModel struct {
-	ID          types.String        `tfsdk:"id"`
-	Name        types.String        `tfsdk:"name"`
-	Description types.String        `tfsdk:"description"`
-	IngressRule []SecurityRuleModel `tfsdk:"ingress_rule"`
-	EgressRule  []SecurityRuleModel `tfsdk:"egress_rule"`
+	ID          types.String            `tfsdk:"id"`
+	Name        types.String            `tfsdk:"name"`
+	Description types.String            `tfsdk:"description"`
+	IngressRule []SecurityRuleDataModel `tfsdk:"ingress_rule"`
+	EgressRule  []SecurityRuleDataModel `tfsdk:"egress_rule"`
 }
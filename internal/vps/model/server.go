@@ -17,18 +17,35 @@ type ServerResourceModel struct {
 	NetworkAttachment types.List   `tfsdk:"network_attachment"` // List of NetworkAttachmentModel
 
 	// Optional user-provided attributes
-	Description    types.String `tfsdk:"description"`
-	Keypair        types.String `tfsdk:"keypair"`
-	Password       types.String `tfsdk:"password"`
-	UserData       types.String `tfsdk:"user_data"`
-	WaitForActive  types.Bool   `tfsdk:"wait_for_active"`
-	WaitForDeleted types.Bool   `tfsdk:"wait_for_deleted"`
+	Description     types.String `tfsdk:"description"`
+	Keypair         types.String `tfsdk:"keypair"`
+	Password        types.String `tfsdk:"password"`
+	UserData        types.String `tfsdk:"user_data"`
+	UserDataFile    types.String `tfsdk:"user_data_file"` // read at plan and create time
+	WaitForActive   types.Bool   `tfsdk:"wait_for_active"`
+	WaitUntilStatus types.String `tfsdk:"wait_until_status"` // overrides WaitForActive when set
+	WaitForDeleted  types.Bool   `tfsdk:"wait_for_deleted"`
+	// DeletionProtection makes Delete refuse without calling the API.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	// FloatingIPAssociation is "strict" or "best_effort".
+	FloatingIPAssociation types.String `tfsdk:"floating_ip_association"`
+	RebootTrigger         types.String `tfsdk:"reboot_trigger"`
+	RootDiskGB            types.Int64  `tfsdk:"root_disk_gb"`
+	// WindowsPasswordPrivateKey decrypts WindowsPassword; it is never logged.
+	WindowsPasswordPrivateKey types.String `tfsdk:"windows_password_private_key"`
 
 	// Computed attributes (read-only)
-	ID          types.String `tfsdk:"id"`
-	Status      types.String `tfsdk:"status"`
-	IPAddresses types.List   `tfsdk:"ip_addresses"` // List of types.String
-	CreatedAt   types.String `tfsdk:"created_at"`
+	ID            types.String `tfsdk:"id"`
+	Status        types.String `tfsdk:"status"`
+	IPAddresses   types.List   `tfsdk:"ip_addresses"`   // List of types.String
+	IPv4Addresses types.List   `tfsdk:"ipv4_addresses"` // List of types.String
+	IPv6Addresses types.List   `tfsdk:"ipv6_addresses"` // List of types.String
+	NICIDs        types.Map    `tfsdk:"nic_ids"`        // network_id -> NIC ID
+	CreatedAt     types.String `tfsdk:"created_at"`
+	// WindowsPassword is only fetched for Windows images with a private key.
+	WindowsPassword types.String `tfsdk:"windows_password"`
+	// Revision only changes when an input that forces recreation changes.
+	Revision types.String `tfsdk:"revision"`
 
 	// Timeouts configuration
 	Timeouts types.Object `tfsdk:"timeouts"` // TimeoutsModel
@@ -42,6 +59,19 @@ type NetworkAttachmentModel struct {
 	SecurityGroupIDs types.List   `tfsdk:"security_group_ids"` // List of types.String
 	FloatingIPID     types.String `tfsdk:"floating_ip_id"`     // Optional: UUID of floating IP to associate
 	FloatingIP       types.String `tfsdk:"floating_ip"`        // Computed: Actual IP address of associated floating IP
+	PublicIP         types.String `tfsdk:"public_ip"`          // Computed: same as FloatingIP, named for contrast with the private IPAddress
+
+	SecurityGroupNames types.List `tfsdk:"security_group_names"` // Optional: resolved to IDs at apply time, kept in state as configured
+	DNSNameservers     types.List `tfsdk:"dns_nameservers"`      // Optional: not exposed by the NIC API, kept in state only
+	HostRoutes         types.List `tfsdk:"host_routes"`          // Optional: list of HostRouteModel, kept in state only
+
+	FixedIPStrategy types.String `tfsdk:"fixed_ip_strategy"` // Optional: used when the NIC is created, kept in state as configured
+}
+
+// HostRouteModel is a static route of a network_attachment.
+type HostRouteModel struct {
+	Destination types.String `tfsdk:"destination"`
+	Nexthop     types.String `tfsdk:"nexthop"`
 }
 
 // TimeoutsModel for configurable operation timeouts.
@@ -54,8 +84,45 @@ type TimeoutsModel struct {
 // UpdateContext contains server update request and network changes.
 type UpdateContext struct {
 	ServerUpdate     *servermodels.ServerUpdateRequest
+	ClearDescription bool // description removed; must be sent explicitly as ""
 	NetworkChanges   map[string]servermodels.ServerNICUpdateRequest
 	NetworksToDelete []string
 	NetworksToCreate []servermodels.ServerNICCreateRequest
-	HasChanges       bool
+	// NetworksToReattach holds NICs that stay on their network but need a new
+	// fixed IP. The NIC API cannot change addresses, so these are detached and
+	// added again.
+	NetworksToReattach []servermodels.ServerNICCreateRequest
+	// NICRestores maps the network of each reattached NIC to the request that
+	// adds it back with its address and security groups from state, used when
+	// the reattach fails.
+	NICRestores map[string]servermodels.ServerNICCreateRequest
+	// FloatingIPMoves holds floating IPs that stay on the server but move to
+	// the NIC of another network.
+	FloatingIPMoves []FloatingIPMove
+	Reboot          bool // reboot_trigger changed to a new non-null value
+	RootDiskGB      int  // new root_disk_gb to extend to; 0 when unchanged
+	HasChanges      bool
+}
+
+// FloatingIPMove is a floating IP moved from the network attachment of one
+// network to that of another on the same server.
+type FloatingIPMove struct {
+	FloatingIPID  string
+	FromNetworkID string
+	ToNetworkID   string
+}
+
+// ServerVolumesDataSourceModel describes the zillaforge_server_volumes data
+// source.
+type ServerVolumesDataSourceModel struct {
+	ServerID types.String        `tfsdk:"server_id"`
+	Volumes  []ServerVolumeModel `tfsdk:"volumes"`
+}
+
+// ServerVolumeModel is a volume attached to a server.
+type ServerVolumeModel struct {
+	ID        types.String `tfsdk:"id"`
+	Device    types.String `tfsdk:"device"`
+	SizeGB    types.Int64  `tfsdk:"size_gb"`    // Null when the API omits the volume details
+	BootIndex types.Int64  `tfsdk:"boot_index"` // 0 for the system volume, null otherwise
 }
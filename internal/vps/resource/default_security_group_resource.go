@@ -0,0 +1,386 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithImportState    = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithValidateConfig = &DefaultSecurityGroupResource{}
+)
+
+// NewDefaultSecurityGroupResource creates a new instance of the default
+// security group resource.
+func NewDefaultSecurityGroupResource() resource.Resource {
+	return &DefaultSecurityGroupResource{}
+}
+
+// DefaultSecurityGroupResource manages the rules of the project's
+// platform-provided default security group. It adopts the group rather than
+// creating it, and never deletes it.
+type DefaultSecurityGroupResource struct {
+	client  *cloudsdk.ProjectClient
+	project string
+
+	emitOperationEvents bool
+}
+
+func (r *DefaultSecurityGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_security_group"
+}
+
+func (r *DefaultSecurityGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the rules of the `default` security group the platform creates in every project, so that traffic allowed on network interfaces using that group is declared in configuration instead of drifting. " +
+			"**This resource adopts the existing group rather than creating one:** creating it replaces the group's rules with the `ingress_rule` and `egress_rule` blocks, and destroying it removes all of the group's rules but keeps the group itself. " +
+			"Declare it at most once per project; two of these resources would keep overwriting each other's rules. Rule changes are applied in place, adding new rules before deleting removed ones. " +
+			"The API does not report which group is the platform's default, so the group is found by its name `default`: creating the resource fails once that group is renamed, and a group later created with the name `default` is adopted in place of the platform's one. When several groups are named `default`, import the platform's one by ID.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project's default security group (UUID format), found by its name `default` when the resource is created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the security group, always `default`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the security group as set by the platform.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"ingress_rule": ingressRuleBlock(),
+			"egress_rule":  egressRuleBlock(),
+		},
+	}
+}
+
+func (r *DefaultSecurityGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*helper.ProviderData)
+	if ok {
+		r.client = providerData.Client
+		r.project = providerData.Project
+		r.emitOperationEvents = providerData.EmitOperationEvents
+	}
+}
+
+// ValidateConfig rejects port-scoped ranges on icmp, icmpv6 and any rules at
+// plan time, as for zillaforge_security_group.
+func (r *DefaultSecurityGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config resourcemodels.DefaultSecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(helper.ValidateRulePortRanges(ctx, defaultGroupRules(config))...)
+}
+
+func (r *DefaultSecurityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_default_security_group", "create", time.Now(), &resp.State, &resp.Diagnostics)
+
+	var plan resourcemodels.DefaultSecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := helper.BuildSecurityGroupRules(ctx, defaultGroupRules(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	securityGroupResource, err := helper.FindDefaultSecurityGroup(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Default Security Group Not Found",
+			fmt.Sprintf("Unable to find the default security group of project '%s': %s", r.client.VPS().ProjectID(), err),
+		)
+		return
+	}
+
+	securityGroup := securityGroupResource.SecurityGroup
+	tflog.Info(ctx, "Adopting default security group", map[string]interface{}{
+		"id":             securityGroup.ID,
+		"existing_rules": len(securityGroup.Rules),
+	})
+
+	replaced, err := helper.ReplaceSecurityGroupRules(ctx, securityGroupResource.Rules(), securityGroup.Rules, rules)
+	if err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Replace Default Security Group Rules", fmt.Sprintf("replace rules of default security group '%s'", securityGroup.ID), err))
+		return
+	}
+	resp.Diagnostics.Append(deferredRulesWarning(securityGroup.ID, replaced.Deferred)...)
+
+	state, found, diags := r.readState(ctx, securityGroup.ID, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Default Security Group Not Found",
+			fmt.Sprintf("Default security group '%s' disappeared while its rules were being replaced.", securityGroup.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DefaultSecurityGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_default_security_group", "read", time.Now(), &req.State, &resp.Diagnostics)
+
+	var state resourcemodels.DefaultSecurityGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, found, diags := r.readState(ctx, state.ID.ValueString(), state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, "Default security group not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *DefaultSecurityGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_default_security_group", "update", time.Now(), &req.State, &resp.Diagnostics)
+
+	var plan, state resourcemodels.DefaultSecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the new rules before touching the existing ones, so an invalid
+	// plan never leaves the group with its rules deleted
+	rules, diags := helper.BuildSecurityGroupRules(ctx, defaultGroupRules(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	securityGroupResource, err := r.client.VPS().SecurityGroups().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Read Default Security Group for Update", "read current default security group state", err))
+		return
+	}
+
+	replaced, err := helper.ReplaceSecurityGroupRules(ctx, securityGroupResource.Rules(), securityGroupResource.SecurityGroup.Rules, rules)
+	if err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Replace Default Security Group Rules", fmt.Sprintf("replace rules of default security group '%s'", state.ID.ValueString()), err))
+		return
+	}
+	resp.Diagnostics.Append(deferredRulesWarning(state.ID.ValueString(), replaced.Deferred)...)
+
+	newState, found, diags := r.readState(ctx, state.ID.ValueString(), plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Default Security Group Not Found",
+			fmt.Sprintf("Default security group '%s' disappeared while its rules were being replaced.", state.ID.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// Delete removes every rule of the default security group. The group itself
+// belongs to the platform and is kept.
+func (r *DefaultSecurityGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_default_security_group", "delete", time.Now(), &req.State, &resp.Diagnostics)
+
+	var state resourcemodels.DefaultSecurityGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	securityGroupResource, err := r.client.VPS().SecurityGroups().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		if helper.IsNotFound(err) {
+			tflog.Warn(ctx, "Default security group already deleted", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Read Default Security Group", fmt.Sprintf("read default security group '%s'", state.ID.ValueString()), err))
+		return
+	}
+
+	tflog.Debug(ctx, "Clearing default security group rules", map[string]interface{}{
+		"id":    state.ID.ValueString(),
+		"rules": len(securityGroupResource.SecurityGroup.Rules),
+	})
+
+	if _, err := helper.ReplaceSecurityGroupRules(ctx, securityGroupResource.Rules(), securityGroupResource.SecurityGroup.Rules, nil); err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Clear Default Security Group Rules", fmt.Sprintf("delete rules of default security group '%s'", state.ID.ValueString()), err))
+		return
+	}
+}
+
+// ImportState imports the default security group by its ID, optionally
+// prefixed with its project as for zillaforge_security_group.
+func (r *DefaultSecurityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, importID, err := helper.SplitProjectImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Import ID must be the default security group's UUID, optionally prefixed with its project as '<project>/<uuid>': %s", err),
+		)
+		return
+	}
+	if project != "" && project != r.project && project != r.client.VPS().ProjectID() {
+		resp.Diagnostics.AddError(
+			"Import Project Mismatch",
+			fmt.Sprintf("Import ID '%s' names project '%s', but the provider of this resource is configured for project '%s'. "+
+				"Set the resource's provider argument to a provider configured for project '%s', then import again.", req.ID, project, r.project, project),
+		)
+		return
+	}
+
+	uuidRegex := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	if !uuidRegex.MatchString(importID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Import ID must be a valid UUID format (e.g., '12345678-1234-1234-1234-123456789abc'). Got: '%s'", importID),
+		)
+		return
+	}
+
+	state := resourcemodels.DefaultSecurityGroupResourceModel{
+		IngressRule: types.ListNull(types.ObjectType{AttrTypes: helper.SecurityRuleAttrTypes}),
+		EgressRule:  types.ListNull(types.ObjectType{AttrTypes: helper.SecurityRuleAttrTypes}),
+	}
+	state, found, diags := r.readState(ctx, importID, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("Security group '%s' not found. Verify it exists in project '%s' and you have permission to access it.", importID, r.client.VPS().ProjectID()),
+		)
+		return
+	}
+	if state.Name.ValueString() != helper.DefaultSecurityGroupName {
+		resp.Diagnostics.AddError(
+			"Not the Default Security Group",
+			fmt.Sprintf("Security group '%s' is named %q, not %q, so it is not the project's default security group. Import it as a zillaforge_security_group instead.",
+				importID, state.Name.ValueString(), helper.DefaultSecurityGroupName),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Info(ctx, "Imported default security group", map[string]interface{}{
+		"id": importID,
+	})
+}
+
+// readState reads the group and maps it onto prior, keeping prior's rule
+// order where possible. found is false when the group does not exist.
+func (r *DefaultSecurityGroupResource) readState(ctx context.Context, id string, prior resourcemodels.DefaultSecurityGroupResourceModel) (resourcemodels.DefaultSecurityGroupResourceModel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	securityGroupResource, err := r.client.VPS().SecurityGroups().Get(ctx, id)
+	if err != nil {
+		if helper.IsNotFound(err) {
+			return prior, false, diags
+		}
+		diags.Append(helper.APIErrorDiagnostic("Failed to Read Default Security Group", fmt.Sprintf("read default security group '%s'", id), err))
+		return prior, true, diags
+	}
+
+	securityGroup := securityGroupResource.SecurityGroup
+	apiIngressRules, apiEgressRules, d := helper.MapSDKRulesToTerraform(ctx, securityGroup.Rules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return prior, true, diags
+	}
+
+	state := prior
+	state.ID = types.StringValue(securityGroup.ID)
+	state.Name = types.StringValue(securityGroup.Name)
+	state.Description = types.StringValue(securityGroup.Description)
+	state.IngressRule = reconcileRules(ctx, types.BoolValue(false), prior.IngressRule, apiIngressRules)
+	state.EgressRule = reconcileRules(ctx, types.BoolValue(false), prior.EgressRule, apiEgressRules)
+	return state, true, diags
+}
+
+// defaultGroupRules wraps the rule blocks of m for the rule helpers shared
+// with zillaforge_security_group.
+func defaultGroupRules(m resourcemodels.DefaultSecurityGroupResourceModel) resourcemodels.SecurityGroupResourceModel {
+	return resourcemodels.SecurityGroupResourceModel{
+		IngressRule: m.IngressRule,
+		EgressRule:  m.EgressRule,
+	}
+}
+
+// deferredRulesWarning reports rules that were added only after the old rules
+// were deleted, leaving servers briefly without them.
+func deferredRulesWarning(id string, deferred int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if deferred > 0 {
+		diags.AddWarning(
+			"Security Group Rules Replaced Non-Atomically",
+			fmt.Sprintf("The API rejected a new rule of security group %s as a duplicate of an existing rule, so %d of the new rules were added only after the old rules were deleted. "+
+				"Servers using the group were briefly without those rules.", id, deferred),
+		)
+	}
+	return diags
+}
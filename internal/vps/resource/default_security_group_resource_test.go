@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeDefaultGroupAPI serves a project whose security groups are held in
+// memory, recording every request that changes them.
+type fakeDefaultGroupAPI struct {
+	mu     sync.Mutex
+	groups []sgmodels.SecurityGroup
+	nextID int
+	writes []string
+}
+
+func (f *fakeDefaultGroupAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, rest, found := strings.Cut(r.URL.Path, "/security_groups")
+	if !found {
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+
+	if r.Method != http.MethodGet {
+		f.writes = append(f.writes, r.Method+" "+rest)
+	}
+
+	if parts[0] == "" {
+		_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroupListResponse{SecurityGroups: f.groups})
+		return
+	}
+
+	group := f.group(parts[0])
+	if group == nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"security group not found"}`))
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(group)
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		var req sgmodels.SecurityGroupRuleCreateRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.nextID++
+		rule := sgmodels.SecurityGroupRule{
+			ID:         fmt.Sprintf("rule-%d", f.nextID),
+			Direction:  req.Direction,
+			Protocol:   req.Protocol,
+			RemoteCIDR: req.RemoteCIDR,
+		}
+		if req.PortMin != nil {
+			rule.PortMin = *req.PortMin
+		}
+		if req.PortMax != nil {
+			rule.PortMax = *req.PortMax
+		}
+		group.Rules = append(group.Rules, rule)
+		_ = json.NewEncoder(w).Encode(rule)
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		for i, rule := range group.Rules {
+			if rule.ID == parts[2] {
+				group.Rules = append(group.Rules[:i], group.Rules[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"rule not found"}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeDefaultGroupAPI) group(id string) *sgmodels.SecurityGroup {
+	for i := range f.groups {
+		if f.groups[i].ID == id {
+			return &f.groups[i]
+		}
+	}
+	return nil
+}
+
+func newDefaultSecurityGroupTestResource(t *testing.T, api *fakeDefaultGroupAPI) (resource.Resource, resource.SchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewDefaultSecurityGroupResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient, Project: "test-project"},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	return r, schemaResp
+}
+
+const (
+	testDefaultGroupID = "00000000-0000-0000-0000-000000000001"
+	testOtherGroupID   = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestDefaultSecurityGroupCreate_AdoptsDefaultGroup(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	api := &fakeDefaultGroupAPI{groups: []sgmodels.SecurityGroup{
+		{ID: testOtherGroupID, Name: "default-web"},
+		{ID: testDefaultGroupID, Name: "default", Description: "Default security group", Rules: []sgmodels.SecurityGroupRule{
+			{ID: "platform-ingress", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "0.0.0.0/0"},
+		}},
+	}}
+	r, schemaResp := newDefaultSecurityGroupTestResource(t, api)
+
+	ingress, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: helper.SecurityRuleAttrTypes}, []resourcemodels.SecurityRuleModel{{
+		Protocol:         types.StringValue("tcp"),
+		PortRange:        types.StringValue("22"),
+		SourceCIDR:       types.StringValue("10.0.0.0/8"),
+		SourceCIDRs:      types.ListNull(types.StringType),
+		DestinationCIDR:  types.StringNull(),
+		DestinationCIDRs: types.ListNull(types.StringType),
+	}})
+	if diags.HasError() {
+		t.Fatalf("failed to build rules: %v", diags.Errors())
+	}
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for attr, value := range map[string]interface{}{
+		"id":           types.StringUnknown(),
+		"name":         types.StringUnknown(),
+		"description":  types.StringUnknown(),
+		"ingress_rule": ingress,
+		"egress_rule":  types.ListNull(types.ObjectType{AttrTypes: helper.SecurityRuleAttrTypes}),
+	} {
+		if diags := plan.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags.Errors())
+		}
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var state resourcemodels.DefaultSecurityGroupResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags.Errors())
+	}
+	if state.ID.ValueString() != testDefaultGroupID || state.Name.ValueString() != "default" {
+		t.Errorf("expected the default group to be adopted, got %s (%s)", state.ID, state.Name)
+	}
+	if len(state.IngressRule.Elements()) != 1 {
+		t.Errorf("expected 1 ingress rule in state, got %d", len(state.IngressRule.Elements()))
+	}
+
+	expectedWrites := []string{
+		"POST /" + testDefaultGroupID + "/rules",
+		"DELETE /" + testDefaultGroupID + "/rules/platform-ingress",
+	}
+	if strings.Join(api.writes, ", ") != strings.Join(expectedWrites, ", ") {
+		t.Errorf("expected writes %v, got %v", expectedWrites, api.writes)
+	}
+}
+
+func TestDefaultSecurityGroupDelete_KeepsGroup(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	api := &fakeDefaultGroupAPI{groups: []sgmodels.SecurityGroup{
+		{ID: testDefaultGroupID, Name: "default", Rules: []sgmodels.SecurityGroupRule{
+			{ID: "rule-a", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+			{ID: "rule-b", Direction: sgmodels.DirectionEgress, Protocol: sgmodels.ProtocolAny, RemoteCIDR: "0.0.0.0/0"},
+		}},
+	}}
+	r, schemaResp := newDefaultSecurityGroupTestResource(t, api)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), testDefaultGroupID); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags.Errors())
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	expectedWrites := []string{
+		"DELETE /" + testDefaultGroupID + "/rules/rule-a",
+		"DELETE /" + testDefaultGroupID + "/rules/rule-b",
+	}
+	if strings.Join(api.writes, ", ") != strings.Join(expectedWrites, ", ") {
+		t.Errorf("expected only the rules to be deleted, got writes %v", api.writes)
+	}
+	if group := api.group(testDefaultGroupID); group == nil || len(group.Rules) != 0 {
+		t.Errorf("expected the group to remain without rules, got %+v", group)
+	}
+}
+
+func TestDefaultSecurityGroupImport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	api := &fakeDefaultGroupAPI{groups: []sgmodels.SecurityGroup{
+		{ID: testDefaultGroupID, Name: "default", Rules: []sgmodels.SecurityGroupRule{
+			{ID: "rule-a", Direction: sgmodels.DirectionIngress, Protocol: sgmodels.ProtocolTCP, PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		}},
+		{ID: testOtherGroupID, Name: "web"},
+	}}
+	r, schemaResp := newDefaultSecurityGroupTestResource(t, api)
+
+	tests := []struct {
+		name      string
+		importID  string
+		expectErr string
+	}{
+		{name: "default group", importID: testDefaultGroupID},
+		{name: "default group with project", importID: "test-project/" + testDefaultGroupID},
+		{name: "other group", importID: testOtherGroupID, expectErr: "Not the Default Security Group"},
+		{name: "unknown group", importID: "00000000-0000-0000-0000-000000000003", expectErr: "Import Error"},
+		{name: "not a UUID", importID: "default", expectErr: "Invalid Import ID Format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.expectErr != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectErr {
+					t.Fatalf("expected error %q, got %v", tt.expectErr, resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var state resourcemodels.DefaultSecurityGroupResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags.Errors())
+			}
+			if state.ID.ValueString() != testDefaultGroupID {
+				t.Errorf("expected ID %s, got %s", testDefaultGroupID, state.ID)
+			}
+			if len(state.IngressRule.Elements()) != 1 {
+				t.Errorf("expected 1 ingress rule in state, got %d", len(state.IngressRule.Elements()))
+			}
+		})
+	}
+}
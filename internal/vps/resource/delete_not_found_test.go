@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newNotFoundProjectClient returns a project client whose IAM project lookup
+// succeeds and whose VPS calls all answer 404, as if every object had been
+// removed out-of-band.
+func newNotFoundProjectClient(t *testing.T) *cloudsdk.ProjectClient {
+	t.Helper()
+	return newErrorProjectClient(t, http.StatusNotFound, "resource not found")
+}
+
+// newErrorProjectClient returns a project client whose IAM project lookup
+// succeeds and whose VPS calls all fail with status and message.
+func newErrorProjectClient(t *testing.T, status int, message string) *cloudsdk.ProjectClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/iam/") {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"errorCode":%d,"message":%q}`, status, message)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+	return projectClient
+}
+
+func TestDelete_NotFoundIsSuccess(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource resource.Resource
+	}{
+		{name: "server", resource: NewServerResource()},
+		{name: "keypair", resource: NewKeypairResource()},
+		{name: "security_group", resource: NewSecurityGroupResource()},
+		{name: "floating_ip", resource: NewFloatingIPResource()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			configureResp := &resource.ConfigureResponse{}
+			tt.resource.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+				ProviderData: &helper.ProviderData{Client: newNotFoundProjectClient(t)},
+			}, configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := state.SetAttribute(ctx, path.Root("id"), "00000000-0000-0000-0000-000000000000"); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+			if diags := state.SetAttribute(ctx, path.Root("name"), "deleted-out-of-band"); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+
+			resp := &resource.DeleteResponse{State: state}
+			tt.resource.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected 404 on delete to succeed, got: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
@@ -6,15 +6,18 @@ package resource
 import (
 	"context"
 	"fmt"
+	"time"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -32,6 +35,8 @@ func NewFloatingIPResource() resource.Resource {
 // FloatingIPResource defines the floating IP resource implementation.
 type FloatingIPResource struct {
 	client *cloudsdk.ProjectClient
+
+	emitOperationEvents bool
 }
 
 func (r *FloatingIPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,13 +56,20 @@ func (r *FloatingIPResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Human-readable name for the floating IP. Optional but recommended for identification in large deployments. Can be updated in-place without releasing the IP address.",
+				MarkdownDescription: "Human-readable name for the floating IP. Optional but recommended for identification in large deployments. Can be updated in-place without releasing the IP address; removing it clears the name.",
 				Optional:            true,
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Optional description providing context about the floating IP's purpose or usage. Can be updated in-place without releasing the IP address.",
+				MarkdownDescription: "Optional description providing context about the floating IP's purpose or usage. Can be updated in-place without releasing the IP address; removing it clears the description.",
 				Optional:            true,
 			},
+			"bandwidth_mbps": schema.Int64Attribute{
+				MarkdownDescription: "Maximum bandwidth of the floating IP in Mbps. Must be positive. **Not yet supported by the platform API:** setting it produces a warning, and the value is recorded in state without limiting traffic. It is not populated on import.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"ip_address": schema.StringAttribute{
 				MarkdownDescription: "The allocated IPv4 address in dotted-decimal notation (e.g., 203.0.113.42). Assigned automatically from the pool during creation and cannot be changed.",
 				Computed:            true,
@@ -73,6 +85,13 @@ func (r *FloatingIPResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "ID of the VPS instance this floating IP is associated with. Null when unassociated. This field is read-only; association management is out of scope for this resource.",
 				Computed:            true,
 			},
+			"associated_server_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the VPS instance in `device_id`, refreshed on every read. Null when unassociated or when the instance cannot be looked up.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -83,13 +102,16 @@ func (r *FloatingIPResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	projectClient, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	providerData, ok := req.ProviderData.(*helper.ProviderData)
 	if ok {
-		r.client = projectClient
+		r.client = providerData.Client
+		r.emitOperationEvents = providerData.EmitOperationEvents
 	}
 }
 
 func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_floating_ip", "create", time.Now(), &resp.State, &resp.Diagnostics)
+
 	var plan model.FloatingIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -107,16 +129,16 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 	vpsClient := r.client.VPS()
 	floatingIP, err := vpsClient.FloatingIPs().Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Create Error",
-			fmt.Sprintf("Unable to create floating IP: %s", err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Create Error", "create floating IP", err))
 		return
 	}
 
 	// Map response to state using model helper
 	var state model.FloatingIPResourceModel
 	helper.MapFloatingIPToResourceModel(ctx, floatingIP, &state)
+	state.AssociatedServerName = helper.AssociatedServerName(ctx, vpsClient.Servers(), floatingIP)
+	state.BandwidthMbps = plan.BandwidthMbps
+	resp.Diagnostics.Append(helper.BandwidthNotAppliedWarning(plan.BandwidthMbps)...)
 
 	tflog.Debug(ctx, "Created floating IP", map[string]interface{}{
 		"id":         state.ID.ValueString(),
@@ -128,6 +150,8 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_floating_ip", "read", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state model.FloatingIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -142,15 +166,13 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 	vpsClient := r.client.VPS()
 	floatingIP, err := vpsClient.FloatingIPs().Get(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Read Error",
-			fmt.Sprintf("Unable to read floating IP %s: %s", state.ID.ValueString(), err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Read Error", fmt.Sprintf("read floating IP %s", state.ID.ValueString()), err))
 		return
 	}
 
 	// Map response to state using model helper
 	helper.MapFloatingIPToResourceModel(ctx, floatingIP, &state)
+	state.AssociatedServerName = helper.AssociatedServerName(ctx, vpsClient.Servers(), floatingIP)
 
 	tflog.Debug(ctx, "Read floating IP", map[string]interface{}{
 		"id":         state.ID.ValueString(),
@@ -162,8 +184,11 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan model.FloatingIPResourceModel
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_floating_ip", "update", time.Now(), &req.State, &resp.Diagnostics)
+
+	var plan, prior model.FloatingIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -175,20 +200,32 @@ func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequ
 	// Build update request using model helper
 	updateReq := helper.BuildUpdateRequest(&plan)
 
+	// Removed attributes are omitted by the SDK, so mark them to be sent
+	// as empty strings
+	updateReqCtx := ctx
+	if plan.Name.IsNull() && !prior.Name.IsNull() {
+		updateReqCtx = helper.WithClearedFields(updateReqCtx, "name")
+	}
+	if plan.Description.IsNull() && !prior.Description.IsNull() {
+		updateReqCtx = helper.WithClearedFields(updateReqCtx, "description")
+	}
+
 	// Call API
 	vpsClient := r.client.VPS()
-	floatingIP, err := vpsClient.FloatingIPs().Update(ctx, plan.ID.ValueString(), updateReq)
+	floatingIP, err := vpsClient.FloatingIPs().Update(updateReqCtx, plan.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Update Error",
-			fmt.Sprintf("Unable to update floating IP %s: %s", plan.ID.ValueString(), err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("update floating IP %s", plan.ID.ValueString()), err))
 		return
 	}
 
 	// Map response to state using model helper
 	var state model.FloatingIPResourceModel
 	helper.MapFloatingIPToResourceModel(ctx, floatingIP, &state)
+	state.AssociatedServerName = helper.AssociatedServerName(ctx, vpsClient.Servers(), floatingIP)
+	state.BandwidthMbps = plan.BandwidthMbps
+	if !plan.BandwidthMbps.Equal(prior.BandwidthMbps) {
+		resp.Diagnostics.Append(helper.BandwidthNotAppliedWarning(plan.BandwidthMbps)...)
+	}
 
 	tflog.Debug(ctx, "Updated floating IP", map[string]interface{}{
 		"id":   state.ID.ValueString(),
@@ -199,6 +236,8 @@ func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_floating_ip", "delete", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state model.FloatingIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -213,10 +252,15 @@ func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequ
 	vpsClient := r.client.VPS()
 	err := vpsClient.FloatingIPs().Delete(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Delete Error",
-			fmt.Sprintf("Unable to delete floating IP %s: %s", state.ID.ValueString(), err),
-		)
+		// Check for 404 (already deleted)
+		if helper.IsNotFound(err) {
+			tflog.Warn(ctx, "Floating IP already deleted", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Delete Error", fmt.Sprintf("delete floating IP %s", state.ID.ValueString()), err))
 		return
 	}
 
@@ -4,6 +4,8 @@
 package resource_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
@@ -28,6 +30,7 @@ func TestAccFloatingIPResource_Basic(t *testing.T) {
 					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_basic", "name"),
 					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_basic", "description"),
 					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_basic", "device_id"),
+					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_basic", "associated_server_name"),
 				),
 			},
 			// T023: Import test step
@@ -98,6 +101,14 @@ func TestAccFloatingIPResource_Update(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zillaforge_floating_ip.test_update", "id"),
 				),
 			},
+			// Removing name and description clears them without drift
+			{
+				Config: testAccFloatingIPResourceConfig_cleared,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_update", "name"),
+					resource.TestCheckNoResourceAttr("zillaforge_floating_ip.test_update", "description"),
+				),
+			},
 		},
 	})
 }
@@ -116,6 +127,10 @@ resource "zillaforge_floating_ip" "test_update" {
 }
 `
 
+const testAccFloatingIPResourceConfig_cleared = `
+resource "zillaforge_floating_ip" "test_update" {}
+`
+
 // Additional test: Verify all status values are handled correctly.
 func TestAccFloatingIPResource_StatusHandling(t *testing.T) {
 	t.Parallel()
@@ -167,3 +182,43 @@ resource "zillaforge_floating_ip" "test_import" {
   description = "Test import with attributes"
 }
 `
+
+// Acceptance test - bandwidth_mbps is validated, kept in state and updated in place.
+func TestAccFloatingIPResource_BandwidthMbps(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccFloatingIPResourceConfig_bandwidth, 0),
+				ExpectError: regexp.MustCompile(`(?i)must be at least 1`),
+			},
+			{
+				Config: fmt.Sprintf(testAccFloatingIPResourceConfig_bandwidth, 100),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_floating_ip.test_bandwidth", "bandwidth_mbps", "100"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccFloatingIPResourceConfig_bandwidth, 200),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_floating_ip.test_bandwidth", "bandwidth_mbps", "200"),
+				),
+			},
+			{
+				ResourceName:            "zillaforge_floating_ip.test_bandwidth",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bandwidth_mbps"},
+			},
+		},
+	})
+}
+
+const testAccFloatingIPResourceConfig_bandwidth = `
+resource "zillaforge_floating_ip" "test_bandwidth" {
+  name           = "test-fip-bandwidth"
+  bandwidth_mbps = %d
+}
+`
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// clearedFieldsRecorder records the fields marked with
+// helper.WithClearedFields on each non-GET request, as the provider's
+// transport would see them.
+type clearedFieldsRecorder struct {
+	base    http.RoundTripper
+	cleared map[string][]string
+}
+
+func (t *clearedFieldsRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.cleared[req.Method+" "+req.URL.Path] = helper.ClearedFields(req.Context())
+	}
+	return t.base.RoundTrip(req)
+}
+
+func TestFloatingIPUpdate_ClearsRemovedFields(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const fipID = "00000000-0000-0000-0000-000000000001"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/floatingips/"+fipID) {
+			_, _ = w.Write([]byte(`{"id":"` + fipID + `","description":"kept","address":"203.0.113.10","status":"ACTIVE"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := &clearedFieldsRecorder{base: srv.Client().Transport, cleared: map[string][]string{}}
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature", cloudsdk.WithHTTPClient(&http.Client{Transport: recorder, Timeout: 10 * time.Second}))
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewFloatingIPResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	newValue := func(attrs map[string]interface{}) tftypes.Value {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		for attr, value := range attrs {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build value: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	// The name is removed from the configuration, the description is kept
+	prior := newValue(map[string]interface{}{"id": fipID, "name": "web", "description": "kept"})
+	plan := newValue(map[string]interface{}{"id": fipID, "description": "kept"})
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior}}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var updates []string
+	for request, fields := range recorder.cleared {
+		if strings.HasPrefix(request, http.MethodPut+" ") {
+			updates = append(updates, request)
+			if !reflect.DeepEqual(fields, []string{"name"}) {
+				t.Errorf("expected only name to be cleared, got %v", fields)
+			}
+		}
+	}
+	if len(updates) != 1 {
+		t.Errorf("expected a single update request, got %v", recorder.cleared)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRead_ForbiddenReportsPermission(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		action   string
+	}{
+		{name: "server", resource: NewServerResource(), action: "read server"},
+		{name: "keypair", resource: NewKeypairResource(), action: "read keypair"},
+		{name: "security_group", resource: NewSecurityGroupResource(), action: "read security group"},
+		{name: "floating_ip", resource: NewFloatingIPResource(), action: "read floating IP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			configureResp := &resource.ConfigureResponse{}
+			tt.resource.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+				ProviderData: &helper.ProviderData{Client: newErrorProjectClient(t, http.StatusForbidden, "forbidden")},
+			}, configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := state.SetAttribute(ctx, path.Root("id"), "00000000-0000-0000-0000-000000000000"); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+
+			resp := &resource.ReadResponse{State: state}
+			tt.resource.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected a 403 on read to fail")
+			}
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.HasPrefix(detail, "Permission denied: your API key lacks permission to "+tt.action) {
+				t.Errorf("expected a permission diagnostic, got: %s", detail)
+			}
+			if resp.State.Raw.IsNull() {
+				t.Error("expected the resource to stay in state")
+			}
+		})
+	}
+}
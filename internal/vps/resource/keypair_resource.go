@@ -6,15 +6,22 @@ package resource
 import (
 	"context"
 	"fmt"
+	"time"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	keypairsmodels "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/modifiers"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/validators"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -33,6 +40,8 @@ func NewKeypairResource() resource.Resource {
 // KeypairResource defines the keypair resource implementation.
 type KeypairResource struct {
 	client *cloudsdk.ProjectClient
+
+	emitOperationEvents bool
 }
 
 func (r *KeypairResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,11 +61,14 @@ func (r *KeypairResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Human-readable name for the keypair. Must be unique within the project. **Immutable** - changing this value forces resource replacement.",
+				MarkdownDescription: "Human-readable name for the keypair. Must be unique within the project and between 1-255 characters. **Immutable** - changing this value forces resource replacement.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Optional description providing context about the keypair's purpose or usage. This is the only updatable attribute.",
@@ -64,10 +76,12 @@ func (r *KeypairResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:            true,
 			},
 			"public_key": schema.StringAttribute{
-				MarkdownDescription: "SSH public key in OpenSSH format (ssh-rsa, ecdsa-sha2-*, ssh-ed25519). If omitted, the system generates a keypair automatically and returns both public and private keys. **Immutable** - changing this value forces resource replacement.",
+				MarkdownDescription: "SSH public key in OpenSSH format (ssh-rsa, ecdsa-sha2-*, ssh-ed25519). If omitted, the system generates a keypair automatically and returns both public and private keys. Surrounding whitespace and trailing newlines are removed before upload, and differences only in whitespace or the trailing comment are not treated as changes, so keys read with `file()` match the canonical form stored by the API. **Immutable** - changing the key material forces resource replacement.",
 				Optional:            true,
 				Computed:            true, // Computed if user doesn't provide (system-generated)
 				PlanModifiers: []planmodifier.String{
+					// Must run before RequiresReplace so a reformatted key is not replaced
+					modifiers.EquivalentValuePlanModifier(helper.PublicKeysEquivalent, "in whitespace or the comment of the public key"),
 					stringplanmodifier.RequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -87,8 +101,75 @@ func (r *KeypairResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the keypair was created, in RFC3339 format (e.g., `2023-10-15T14:30:00Z`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the last change to the keypair, in RFC3339 format.",
+				Computed:            true,
+			},
+			"regenerate_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value whose change rotates a system-generated keypair. When `public_key` is not set and the value changes to a new non-null value, the keypair is destroyed and recreated under the same name, producing a new `public_key` and `private_key`. " +
+					"**The old private key stops working for new logins**, and servers that reference this keypair keep the old public key until they are rebuilt or updated. " +
+					"Has no effect (other than a plan warning) when `public_key` is set, and removing it does not regenerate.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						regenerateTriggerRequiresReplace,
+						"Regenerates the system-generated keypair when the value changes and public_key is not set.",
+						"Regenerates the system-generated keypair when the value changes and `public_key` is not set.",
+					),
+				},
+			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Configurable timeout for the delete operation.",
+				Attributes: map[string]schema.Attribute{
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "How long to keep retrying the deletion while the API reports the keypair in use, typically by a server that is being destroyed in the same apply. Default is `5m` (5 minutes). Use Go duration syntax (e.g., `15m`, `1h`); invalid, zero and negative durations are rejected at plan time.",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.PositiveDuration(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// regenerateTriggerRequiresReplace forces replacement when regenerate_trigger
+// changes to a new non-null value on a system-generated keypair. A
+// user-provided public_key would simply be re-uploaded, so the change is
+// stored in place and the user is warned instead.
+func regenerateTriggerRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsNull() {
+		return
+	}
+
+	var publicKey types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("public_key"), &publicKey)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	if publicKey.IsNull() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Regenerate Trigger Ignored",
+		"regenerate_trigger only rotates system-generated keypairs. This keypair uses a user-provided public_key, "+
+			"so the new trigger value is recorded without replacing the keypair. Change public_key to rotate it.",
+	)
 }
 
 func (r *KeypairResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -97,13 +178,16 @@ func (r *KeypairResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	projectClient, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	providerData, ok := req.ProviderData.(*helper.ProviderData)
 	if ok {
-		r.client = projectClient
+		r.client = providerData.Client
+		r.emitOperationEvents = providerData.EmitOperationEvents
 	}
 }
 
 func (r *KeypairResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_keypair", "create", time.Now(), &resp.State, &resp.Diagnostics)
+
 	var plan model.KeypairResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -124,17 +208,15 @@ func (r *KeypairResource) Create(ctx context.Context, req resource.CreateRequest
 		createReq.Description = plan.Description.ValueString()
 	}
 	if !plan.PublicKey.IsNull() {
-		createReq.PublicKey = plan.PublicKey.ValueString()
+		// Invalid keys are sent as-is (trimmed) so the API reports them
+		createReq.PublicKey = helper.NormalizePublicKey(plan.PublicKey.ValueString())
 	}
 
 	// Call API
 	vpsClient := r.client.VPS()
 	keypair, err := vpsClient.Keypairs().Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Create Error",
-			fmt.Sprintf("Unable to create keypair: %s", err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Create Error", "create keypair", err))
 		return
 	}
 
@@ -146,8 +228,10 @@ func (r *KeypairResource) Create(ctx context.Context, req resource.CreateRequest
 	} else {
 		plan.Description = types.StringNull()
 	}
-	plan.PublicKey = types.StringValue(keypair.PublicKey)
+	plan.PublicKey = helper.PublicKeyStateValue(plan.PublicKey, keypair.PublicKey)
 	plan.Fingerprint = types.StringValue(keypair.Fingerprint)
+	plan.CreatedAt = helper.TimestampValue(keypair.CreatedAt, types.StringNull())
+	plan.UpdatedAt = helper.TimestampValue(keypair.UpdatedAt, types.StringNull())
 
 	// Private key only available for system-generated keypairs
 	if keypair.PrivateKey != "" {
@@ -163,6 +247,8 @@ func (r *KeypairResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *KeypairResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_keypair", "read", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state model.KeypairResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -177,10 +263,7 @@ func (r *KeypairResource) Read(ctx context.Context, req resource.ReadRequest, re
 	vpsClient := r.client.VPS()
 	keypair, err := vpsClient.Keypairs().Get(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Read Error",
-			fmt.Sprintf("Unable to read keypair: %s", err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Read Error", "read keypair", err))
 		return
 	}
 
@@ -193,12 +276,16 @@ func (r *KeypairResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 	state.PublicKey = types.StringValue(keypair.PublicKey)
 	state.Fingerprint = types.StringValue(keypair.Fingerprint)
+	state.CreatedAt = helper.TimestampValue(keypair.CreatedAt, state.CreatedAt)
+	state.UpdatedAt = helper.TimestampValue(keypair.UpdatedAt, state.UpdatedAt)
 	// Note: PrivateKey is never returned by Get (security), so preserve existing state
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *KeypairResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_keypair", "update", time.Now(), &req.State, &resp.Diagnostics)
+
 	var plan model.KeypairResourceModel
 	var state model.KeypairResourceModel
 
@@ -222,10 +309,7 @@ func (r *KeypairResource) Update(ctx context.Context, req resource.UpdateRequest
 	vpsClient := r.client.VPS()
 	keypair, err := vpsClient.Keypairs().Update(ctx, state.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Update Error",
-			fmt.Sprintf("Unable to update keypair: %s", err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "update keypair", err))
 		return
 	}
 
@@ -236,9 +320,13 @@ func (r *KeypairResource) Update(ctx context.Context, req resource.UpdateRequest
 	} else {
 		state.Description = types.StringNull()
 	}
-	state.PublicKey = types.StringValue(keypair.PublicKey)
+	state.PublicKey = helper.PublicKeyStateValue(plan.PublicKey, keypair.PublicKey)
 	state.Fingerprint = types.StringValue(keypair.Fingerprint)
+	state.CreatedAt = helper.TimestampValue(keypair.CreatedAt, state.CreatedAt)
+	state.UpdatedAt = helper.TimestampValue(keypair.UpdatedAt, state.UpdatedAt)
 	// Preserve PrivateKey from state (not returned by Update)
+	state.RegenerateTrigger = plan.RegenerateTrigger
+	state.Timeouts = plan.Timeouts
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	tflog.Info(ctx, "Updated keypair", map[string]interface{}{
@@ -247,12 +335,20 @@ func (r *KeypairResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *KeypairResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_keypair", "delete", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state model.KeypairResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	timeout, diags := helper.ResolveTimeout(ctx, state.Timeouts, "delete", helper.DefaultInUseDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Warn(ctx, "Deleting keypair that may be in use by VPS instances", map[string]interface{}{
 		"keypair_id":   state.ID.ValueString(),
 		"keypair_name": state.Name.ValueString(),
@@ -260,12 +356,19 @@ func (r *KeypairResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Call API
 	vpsClient := r.client.VPS()
-	err := vpsClient.Keypairs().Delete(ctx, state.ID.ValueString())
+	err := helper.DeleteWithInUseRetry(ctx, timeout, func(ctx context.Context) error {
+		return vpsClient.Keypairs().Delete(ctx, state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Delete Error",
-			fmt.Sprintf("Unable to delete keypair: %s", err),
-		)
+		// Check for 404 (already deleted)
+		if helper.IsNotFound(err) {
+			tflog.Warn(ctx, "Keypair already deleted", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Delete Error", "delete keypair", err))
 		return
 	}
 
@@ -286,10 +389,7 @@ func (r *KeypairResource) ImportState(ctx context.Context, req resource.ImportSt
 	vpsClient := r.client.VPS()
 	keypair, err := vpsClient.Keypairs().Get(ctx, keypairID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Import Error",
-			fmt.Sprintf("Unable to read keypair %s: %s", keypairID, err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Import Error", fmt.Sprintf("read keypair %s", keypairID), err))
 		return
 	}
 
@@ -304,8 +404,11 @@ func (r *KeypairResource) ImportState(ctx context.Context, req resource.ImportSt
 	}
 	state.PublicKey = types.StringValue(keypair.PublicKey)
 	state.Fingerprint = types.StringValue(keypair.Fingerprint)
+	state.CreatedAt = helper.TimestampValue(keypair.CreatedAt, state.CreatedAt)
+	state.UpdatedAt = helper.TimestampValue(keypair.UpdatedAt, state.UpdatedAt)
 	// PrivateKey is never available after creation (security), so set to null
 	state.PrivateKey = types.StringNull()
+	state.Timeouts = types.ObjectNull(helper.DeleteTimeoutsAttrTypes)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	tflog.Info(ctx, "Imported keypair", map[string]interface{}{
@@ -4,11 +4,14 @@
 package resource_test
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 // T008: Acceptance test - Create keypair with user-provided public key.
@@ -26,6 +29,8 @@ func TestAccKeypairResource_UserProvidedKey(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "id"),
 					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "public_key"),
 					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "fingerprint"),
+					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "created_at"),
+					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "updated_at"),
 					// Verify private_key is null for user-provided key
 					resource.TestCheckNoResourceAttr("zillaforge_keypair.test", "private_key"),
 				),
@@ -353,3 +358,115 @@ resource "zillaforge_keypair" "test" {
   # No public_key - system generates
 }
 `
+
+// Acceptance test - Over-long name is rejected at plan time.
+func TestAccKeypairResource_NameLengthPlanTimeReject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "zillaforge_keypair" "test" {
+  name = "` + strings.Repeat("n", 256) + `"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)Attribute name string length must be between 1 and 255`),
+			},
+		},
+	})
+}
+
+// Acceptance test - Changing regenerate_trigger rotates a system-generated
+// keypair, but only updates state for a user-provided public key.
+func TestAccKeypairResource_RegenerateTrigger(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccKeypairResourceConfig_regenerate, "rotation-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_keypair.generated", "regenerate_trigger", "rotation-1"),
+					resource.TestCheckResourceAttrSet("zillaforge_keypair.generated", "private_key"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccKeypairResourceConfig_regenerate, "rotation-2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("zillaforge_keypair.generated", plancheck.ResourceActionReplace),
+						plancheck.ExpectResourceAction("zillaforge_keypair.provided", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_keypair.generated", "name", "test-regenerate-key"),
+					resource.TestCheckResourceAttr("zillaforge_keypair.generated", "regenerate_trigger", "rotation-2"),
+					resource.TestCheckResourceAttrSet("zillaforge_keypair.generated", "private_key"),
+					resource.TestCheckResourceAttr("zillaforge_keypair.provided", "regenerate_trigger", "rotation-2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKeypairResourceConfig_regenerate = `
+resource "zillaforge_keypair" "generated" {
+  name               = "test-regenerate-key"
+  regenerate_trigger = %[1]q
+}
+
+resource "zillaforge_keypair" "provided" {
+  name               = "test-regenerate-provided"
+  public_key         = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl test@example.com"
+  regenerate_trigger = %[1]q
+}
+`
+
+// Acceptance test - A public key with a trailing newline and comment (as read
+// with file()) produces no drift after refresh or import.
+func TestAccKeypairResource_PublicKeyNormalization(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeypairResourceConfig_trailingNewline,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("zillaforge_keypair.test", "fingerprint"),
+				),
+			},
+			// Pick up the canonical form stored by the API
+			{
+				RefreshState: true,
+			},
+			{
+				ResourceName:            "zillaforge_keypair.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+			{
+				Config: testAccKeypairResourceConfig_trailingNewline,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+const testAccKeypairResourceConfig_trailingNewline = `
+resource "zillaforge_keypair" "test" {
+  name       = "test-normalized-key"
+  public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl test@example.com  \n"
+}
+`
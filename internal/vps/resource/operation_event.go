@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// operationEventMessage is the log message of every operation event. Its
+// fields are documented on the provider's emit_operation_events option and
+// are kept stable so that pipelines can parse them.
+const operationEventMessage = "zillaforge operation"
+
+// logOperation writes the operation event for one CRUD call when the
+// provider's emit_operation_events option is set. It is meant to be
+// deferred at the top of the call, with start evaluated there:
+//
+//	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_keypair", "read", time.Now(), &req.State, &resp.Diagnostics)
+//
+// The ID is read from state once the call returns, so Create passes the
+// response state and the other calls pass the prior state.
+func logOperation(ctx context.Context, enabled bool, resourceType, action string, start time.Time, state *tfsdk.State, diags *diag.Diagnostics) {
+	if !enabled {
+		return
+	}
+
+	id := ""
+	if state != nil && !state.Raw.IsNull() {
+		var value types.String
+		if d := state.GetAttribute(ctx, path.Root("id"), &value); !d.HasError() {
+			id = value.ValueString()
+		}
+	}
+
+	result := "success"
+	if diags.HasError() {
+		result = "error"
+	}
+
+	tflog.Info(ctx, operationEventMessage, map[string]interface{}{
+		"resource_type": resourceType,
+		"action":        action,
+		"id":            id,
+		"duration_ms":   time.Since(start).Milliseconds(),
+		"result":        result,
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogOperation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewKeypairResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	const keypairID = "11111111-1111-1111-1111-111111111111"
+	stored := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := stored.SetAttribute(ctx, path.Root("id"), keypairID); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags.Errors())
+	}
+	empty := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	failed := diag.Diagnostics{}
+	failed.AddError("Failed to create keypair", "quota exceeded")
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		action   string
+		state    tfsdk.State
+		diags    diag.Diagnostics
+		expected map[string]interface{}
+	}{
+		{
+			name:    "disabled",
+			enabled: false,
+			action:  "read",
+			state:   stored,
+		},
+		{
+			name:    "success",
+			enabled: true,
+			action:  "read",
+			state:   stored,
+			expected: map[string]interface{}{
+				"resource_type": "zillaforge_keypair",
+				"action":        "read",
+				"id":            keypairID,
+				"result":        "success",
+			},
+		},
+		{
+			name:    "create failed before an ID was known",
+			enabled: true,
+			action:  "create",
+			state:   empty,
+			diags:   failed,
+			expected: map[string]interface{}{
+				"resource_type": "zillaforge_keypair",
+				"action":        "create",
+				"id":            "",
+				"result":        "error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var logs bytes.Buffer
+			logCtx := tflogtest.RootLogger(ctx, &logs)
+
+			logOperation(logCtx, tt.enabled, "zillaforge_keypair", tt.action, time.Now().Add(-1500*time.Millisecond), &tt.state, &tt.diags)
+
+			entries, err := tflogtest.MultilineJSONDecode(&logs)
+			if err != nil {
+				t.Fatalf("failed to decode logs: %v", err)
+			}
+			if tt.expected == nil {
+				if len(entries) != 0 {
+					t.Errorf("expected no events, got %v", entries)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("expected a single event, got %v", entries)
+			}
+
+			event := entries[0]
+			if event["@message"] != operationEventMessage || event["@level"] != "info" {
+				t.Errorf("expected an info %q entry, got %v", operationEventMessage, event)
+			}
+			for key, want := range tt.expected {
+				if event[key] != want {
+					t.Errorf("expected %s %v, got %v", key, want, event[key])
+				}
+			}
+			// JSON numbers decode as float64.
+			if duration, ok := event["duration_ms"].(float64); !ok || duration < 1500 {
+				t.Errorf("expected duration_ms of at least 1500, got %v", event["duration_ms"])
+			}
+		})
+	}
+}
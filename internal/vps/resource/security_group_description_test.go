@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecurityGroupRead_EmptyDescription(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const sgID = "00000000-0000-0000-0000-000000000001"
+
+	// The API reports a group without a description as "".
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/security_groups/"+sgID) {
+			_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroup{ID: sgID, Name: "web"})
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewSecurityGroupResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name     string
+		prior    types.String
+		expected types.String
+	}{
+		{name: "not set", prior: types.StringNull(), expected: types.StringNull()},
+		{name: "configured as empty", prior: types.StringValue(""), expected: types.StringValue("")},
+		{name: "removed out of band", prior: types.StringValue("web tier"), expected: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			for attr, value := range map[string]interface{}{"id": sgID, "name": "web", "description": tt.prior} {
+				if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+					t.Fatalf("failed to build state: %v", diags.Errors())
+				}
+			}
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var description types.String
+			if diags := resp.State.GetAttribute(ctx, path.Root("description"), &description); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags.Errors())
+			}
+			if !description.Equal(tt.expected) {
+				t.Errorf("expected description %s, got %s", tt.expected, description)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupUpdate_ClearsRemovedDescription(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const sgID = "00000000-0000-0000-0000-000000000001"
+
+	// The group reads back without a description once it is cleared.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/security_groups/"+sgID) {
+			_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroup{ID: sgID, Name: "web"})
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := &clearedFieldsRecorder{base: srv.Client().Transport, cleared: map[string][]string{}}
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature", cloudsdk.WithHTTPClient(&http.Client{Transport: recorder, Timeout: 10 * time.Second}))
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewSecurityGroupResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	newValue := func(attrs map[string]interface{}) tftypes.Value {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		for attr, value := range attrs {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build value: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	prior := newValue(map[string]interface{}{"id": sgID, "name": "web", "description": "web tier"})
+	plan := newValue(map[string]interface{}{"id": sgID, "name": "web"})
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior}}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var updates int
+	for request, fields := range recorder.cleared {
+		if strings.HasPrefix(request, http.MethodPut+" ") {
+			updates++
+			if !reflect.DeepEqual(fields, []string{"description"}) {
+				t.Errorf("expected description to be cleared, got %v", fields)
+			}
+		}
+	}
+	if updates != 1 {
+		t.Errorf("expected a single update request, got %v", recorder.cleared)
+	}
+
+	var description types.String
+	if diags := resp.State.GetAttribute(ctx, path.Root("description"), &description); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags.Errors())
+	}
+	if !description.IsNull() {
+		t.Errorf("expected description to be null, got %s", description)
+	}
+}
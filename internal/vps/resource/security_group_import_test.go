@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecurityGroupImportState_ProjectPrefix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const sgID = "00000000-0000-0000-0000-000000000001"
+
+	var reads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/security_groups/"+sgID) {
+			reads.Add(1)
+			_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroup{ID: sgID, Name: "web"})
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewSecurityGroupResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient, Project: "test-code"},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name          string
+		importID      string
+		expectedError string
+	}{
+		{name: "plain uuid", importID: sgID},
+		{name: "project id prefix", importID: "test-project/" + sgID},
+		{name: "configured project prefix", importID: "test-code/" + sgID},
+		{name: "other project", importID: "other-project/" + sgID, expectedError: "Import Project Mismatch"},
+		{name: "too many parts", importID: "test-project/" + sgID + "/extra", expectedError: "Invalid Import ID Format"},
+		{name: "invalid uuid", importID: "test-project/web", expectedError: "Invalid Import ID Format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := reads.Load()
+			resp := &resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.expectedError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectedError {
+					t.Fatalf("expected error %q, got %v", tt.expectedError, resp.Diagnostics)
+				}
+				if reads.Load() != before {
+					t.Error("expected the security group not to be read")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if id.ValueString() != sgID {
+				t.Errorf("expected ID %s in state, got %s", sgID, id)
+			}
+		})
+	}
+}
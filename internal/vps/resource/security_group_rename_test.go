@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRenameRequiresReplace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewSecurityGroupResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name           string
+		renameInPlace  bool
+		expectReplace  bool
+		expectWarnings int
+	}{
+		{name: "replace by default", expectReplace: true, expectWarnings: 1},
+		{name: "rename in place", renameInPlace: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.SetAttribute(ctx, path.Root("rename_in_place"), tt.renameInPlace); diags.HasError() {
+				t.Fatalf("failed to build plan: %v", diags.Errors())
+			}
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("name"),
+				Plan:       plan,
+				StateValue: types.StringValue("web"),
+				PlanValue:  types.StringValue("web-renamed"),
+			}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			renameRequiresReplace(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.expectReplace {
+				t.Errorf("expected RequiresReplace %t, got %t", tt.expectReplace, resp.RequiresReplace)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tt.expectWarnings {
+				t.Errorf("expected %d warnings, got %v", tt.expectWarnings, resp.Diagnostics)
+			}
+		})
+	}
+}
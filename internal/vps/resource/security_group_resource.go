@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
@@ -15,8 +16,15 @@ import (
 	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -25,8 +33,15 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &SecurityGroupResource{}
-var _ resource.ResourceWithImportState = &SecurityGroupResource{}
+var (
+	_ resource.Resource                   = &SecurityGroupResource{}
+	_ resource.ResourceWithImportState    = &SecurityGroupResource{}
+	_ resource.ResourceWithValidateConfig = &SecurityGroupResource{}
+)
+
+// cancelledReadTimeout bounds the read that records partial state after an
+// update was cancelled.
+const cancelledReadTimeout = 30 * time.Second
 
 // NewSecurityGroupResource creates a new instance of the security group resource.
 func NewSecurityGroupResource() resource.Resource {
@@ -35,7 +50,10 @@ func NewSecurityGroupResource() resource.Resource {
 
 // SecurityGroupResource defines the security group resource implementation.
 type SecurityGroupResource struct {
-	client *cloudsdk.ProjectClient
+	client  *cloudsdk.ProjectClient
+	project string
+
+	emitOperationEvents bool
 }
 
 func (r *SecurityGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,7 +62,7 @@ func (r *SecurityGroupResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *SecurityGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages security groups for VPS instances in ZillaForge. Security groups act as stateful virtual firewalls that control inbound and outbound traffic using protocol, port, and CIDR-based rules. Multiple security groups can be attached to a single instance, with rules evaluated using union logic (most permissive wins).",
+		MarkdownDescription: "Manages security groups for VPS instances in ZillaForge. Security groups act as stateful virtual firewalls that control inbound and outbound traffic using protocol, port, and CIDR-based rules. Multiple security groups can be attached to a single instance, with rules evaluated using union logic (most permissive wins). Rules are always stateful: reply traffic for an allowed connection is permitted automatically, and the VPS API has no option for stateless rules. Rule changes are applied in place, adding new rules before deleting removed ones so attached servers are never left without rules.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -55,84 +73,221 @@ func (r *SecurityGroupResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Human-readable name for the security group. Must be unique within the project. **Immutable** - changing this value forces resource replacement.",
-				Required:            true,
+				MarkdownDescription: "Human-readable name for the security group. Must be unique within the project and between 1-255 characters. " +
+					"Changing this value forces resource replacement unless `rename_in_place` is `true`; the replacement is created with the full rule set, but servers keep referencing the old group until their `security_group_ids` are updated, and the old group cannot be destroyed while attached unless `force_destroy` is set.",
+				Required: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						renameRequiresReplace,
+						"Requires replacement unless rename_in_place is true.",
+						"Requires replacement unless `rename_in_place` is `true`.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
 				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Optional description providing context about the security group's purpose. This attribute can be updated in-place without recreating the resource.",
+				MarkdownDescription: "Optional description providing context about the security group's purpose. Maximum 1000 characters. This attribute can be updated in-place without recreating the resource; removing it clears the description.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(1000),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the security group was created, in RFC3339 format (e.g., `2023-10-15T14:30:00Z`).",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-		},
-
-		Blocks: map[string]schema.Block{
-			"ingress_rule": schema.ListNestedBlock{
-				MarkdownDescription: "Inbound firewall rules that control traffic TO instances attached to this security group. Rules specify allowed source traffic by protocol, port range, and source CIDR block. Empty list denies all inbound traffic (secure by default).",
-				NestedObject: schema.NestedBlockObject{
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the last change to the security group, in RFC3339 format.",
+				Computed:            true,
+			},
+			"create_default_rules": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, seeds the security group at creation with a sensible baseline in addition to any `ingress_rule`/`egress_rule` blocks: allow all egress (`any` protocol to `0.0.0.0/0` and `::/0`) and allow inbound ICMP from `0.0.0.0/0`. " +
+					"Seeded rules are listed in `default_rules` rather than in the rule blocks, and are kept when the rule blocks change. A default that is also declared in a rule block is created once and managed by the block. " +
+					"Defaults to `false`. Changing this value forces resource replacement.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, destroying the security group first detaches it from every server NIC that uses it, instead of failing because the group is in use. " +
+					"**Use with care:** the affected servers immediately lose the traffic this group allowed, a NIC whose only group this was is left with none, and the `security_group_ids` of the affected `zillaforge_server` resources drift until they are next applied. " +
+					"Only takes effect once applied to state, so set it in a separate apply before destroying. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"rename_in_place": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, changing `name` renames the security group in place: its ID, rules and server attachments are kept and no traffic is interrupted. " +
+					"Defaults to `false`, which replaces the group on rename.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"rules_as_set": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, `ingress_rule` and `egress_rule` are compared with the API by membership only: rule order and repeated rules never produce a diff, and reordering the blocks does not recreate any rule. " +
+					"Use it when the API returns rules in a different order or collapses duplicates. Defaults to `false`, which compares rules in order.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"default_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Rules seeded by `create_default_rules`. Empty when `create_default_rules` is `false`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "Traffic direction: `ingress` or `egress`.",
+							Computed:            true,
+						},
 						"protocol": schema.StringAttribute{
-							MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `any`. Case-insensitive.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.Protocol(),
-							},
+							MarkdownDescription: "Network protocol of the rule.",
+							Computed:            true,
 						},
 						"port_range": schema.StringAttribute{
-							MarkdownDescription: "Port specification. Valid formats: single port (`22`), port range (`8000-8100`), or `all` (equivalent to `1-65535` for TCP/UDP). For ICMP protocol, must be `all`.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.PortRange(),
-							},
-						},
-						"source_cidr": schema.StringAttribute{
-							MarkdownDescription: "Source CIDR block for allowed inbound traffic. Examples: `0.0.0.0/0` (all IPv4), `192.168.1.0/24` (subnet), `::/0` (all IPv6). Both IPv4 and IPv6 are supported.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.CIDR(),
-							},
+							MarkdownDescription: "Port specification of the rule.",
+							Computed:            true,
 						},
-						"destination_cidr": schema.StringAttribute{
-							MarkdownDescription: "Not used for ingress rules. Must be null or empty.",
+						"cidr": schema.StringAttribute{
+							MarkdownDescription: "Source CIDR for ingress rules, destination CIDR for egress rules.",
 							Computed:            true,
 						},
 					},
 				},
 			},
-			"egress_rule": schema.ListNestedBlock{
-				MarkdownDescription: "Outbound firewall rules that control traffic FROM instances attached to this security group. Rules specify allowed destination traffic by protocol, port range, and destination CIDR block. Empty list denies all outbound traffic.",
-				NestedObject: schema.NestedBlockObject{
-					Attributes: map[string]schema.Attribute{
-						"protocol": schema.StringAttribute{
-							MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `any`. Case-insensitive.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.Protocol(),
-							},
-						},
-						"port_range": schema.StringAttribute{
-							MarkdownDescription: "Port specification. Valid formats: single port (`22`), port range (`8000-8100`), or `all` (equivalent to `1-65535` for TCP/UDP). For ICMP protocol, must be `all`.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.PortRange(),
-							},
-						},
-						"source_cidr": schema.StringAttribute{
-							MarkdownDescription: "Not used for egress rules. Must be null or empty.",
-							Computed:            true,
-						},
-						"destination_cidr": schema.StringAttribute{
-							MarkdownDescription: "Destination CIDR block for allowed outbound traffic. Examples: `0.0.0.0/0` (all IPv4), `10.0.0.0/8` (private network), `::/0` (all IPv6). Both IPv4 and IPv6 are supported.",
-							Required:            true,
-							Validators: []validator.String{
-								validators.CIDR(),
-							},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Configurable timeout for the delete operation.",
+				Attributes: map[string]schema.Attribute{
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "How long to keep retrying the deletion while the API reports the security group in use, typically by a server that is being destroyed in the same apply. Default is `5m` (5 minutes). Use Go duration syntax (e.g., `15m`, `1h`); invalid, zero and negative durations are rejected at plan time.",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.PositiveDuration(),
 						},
 					},
 				},
 			},
+			"ingress_rule": ingressRuleBlock(),
+			"egress_rule":  egressRuleBlock(),
+		},
+	}
+}
+
+// ingressRuleBlock is the ingress_rule block schema shared by the security
+// group resources.
+func ingressRuleBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Inbound firewall rules that control traffic TO instances attached to this security group. Rules specify allowed source traffic by protocol, port range, and source CIDR block. Empty list denies all inbound traffic (secure by default).",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"protocol": schema.StringAttribute{
+					MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `icmpv6`, `any`. Case-insensitive. `icmpv6` (ICMP over IPv6, needed for neighbor discovery) requires an IPv6 CIDR.",
+					Required:            true,
+					Validators: []validator.String{
+						validators.Protocol(),
+					},
+				},
+				"port_range": schema.StringAttribute{
+					MarkdownDescription: "Port specification. Valid formats: single port (`22`), port range (`8000-8100`), or `all` (equivalent to `1-65535` for TCP/UDP). For `icmp`, `icmpv6` and `any`, must be `all`; other values are rejected at plan time, and these rules always read back as `all` whatever ICMP type or code the API reports.",
+					Required:            true,
+					Validators: []validator.String{
+						validators.PortRange(),
+					},
+				},
+				"source_cidr": schema.StringAttribute{
+					MarkdownDescription: "Source CIDR block for allowed inbound traffic. Examples: `0.0.0.0/0` (all IPv4), `192.168.1.0/24` (subnet), `::/0` (all IPv6). Both IPv4 and IPv6 are supported. Exactly one of `source_cidr` or `source_cidrs` must be set.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.CIDR(),
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_cidrs")),
+					},
+				},
+				"source_cidrs": schema.ListAttribute{
+					MarkdownDescription: "Source CIDR blocks for allowed inbound traffic, as an alternative to `source_cidr`. The provider creates one API rule per CIDR and reads them back into this list.",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.UniqueValues(),
+						listvalidator.ValueStringsAre(validators.CIDR()),
+					},
+				},
+				"destination_cidr": schema.StringAttribute{
+					MarkdownDescription: "Not used for ingress rules. Must be null or empty.",
+					Computed:            true,
+				},
+				"destination_cidrs": schema.ListAttribute{
+					MarkdownDescription: "Not used for ingress rules. Always null.",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// egressRuleBlock is the egress_rule block schema shared by the security
+// group resources.
+func egressRuleBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Outbound firewall rules that control traffic FROM instances attached to this security group. Rules specify allowed destination traffic by protocol, port range, and destination CIDR block. Empty list denies all outbound traffic.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"protocol": schema.StringAttribute{
+					MarkdownDescription: "Network protocol for this rule. Valid values: `tcp`, `udp`, `icmp`, `icmpv6`, `any`. Case-insensitive. `icmpv6` (ICMP over IPv6, needed for neighbor discovery) requires an IPv6 CIDR.",
+					Required:            true,
+					Validators: []validator.String{
+						validators.Protocol(),
+					},
+				},
+				"port_range": schema.StringAttribute{
+					MarkdownDescription: "Port specification. Valid formats: single port (`22`), port range (`8000-8100`), or `all` (equivalent to `1-65535` for TCP/UDP). For `icmp`, `icmpv6` and `any`, must be `all`; other values are rejected at plan time, and these rules always read back as `all` whatever ICMP type or code the API reports.",
+					Required:            true,
+					Validators: []validator.String{
+						validators.PortRange(),
+					},
+				},
+				"source_cidr": schema.StringAttribute{
+					MarkdownDescription: "Not used for egress rules. Must be null or empty.",
+					Computed:            true,
+				},
+				"source_cidrs": schema.ListAttribute{
+					MarkdownDescription: "Not used for egress rules. Always null.",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+				"destination_cidr": schema.StringAttribute{
+					MarkdownDescription: "Destination CIDR block for allowed outbound traffic. Examples: `0.0.0.0/0` (all IPv4), `10.0.0.0/8` (private network), `::/0` (all IPv6). Both IPv4 and IPv6 are supported. Exactly one of `destination_cidr` or `destination_cidrs` must be set.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.CIDR(),
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("destination_cidrs")),
+					},
+				},
+				"destination_cidrs": schema.ListAttribute{
+					MarkdownDescription: "Destination CIDR blocks for allowed outbound traffic, as an alternative to `destination_cidr`. The provider creates one API rule per CIDR and reads them back into this list.",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.UniqueValues(),
+						listvalidator.ValueStringsAre(validators.CIDR()),
+					},
+				},
+			},
 		},
 	}
 }
@@ -143,13 +298,30 @@ func (r *SecurityGroupResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	projectClient, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	providerData, ok := req.ProviderData.(*helper.ProviderData)
 	if ok {
-		r.client = projectClient
+		r.client = providerData.Client
+		r.project = providerData.Project
+		r.emitOperationEvents = providerData.EmitOperationEvents
+	}
+}
+
+// ValidateConfig rejects port-scoped ranges on icmp, icmpv6 and any rules at
+// plan time. The API would ignore the ports and the rule would read back as
+// "all", leaving a diff on every plan.
+func (r *SecurityGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config resourcemodels.SecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	resp.Diagnostics.Append(helper.ValidateRulePortRanges(ctx, config)...)
 }
 
 func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_security_group", "create", time.Now(), &resp.State, &resp.Diagnostics)
+
 	var plan resourcemodels.SecurityGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -167,6 +339,14 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	if plan.RulesAsSet.ValueBool() {
+		rules = helper.DedupeRules(rules)
+	}
+	userRules := rules
+	if plan.CreateDefaultRules.ValueBool() {
+		rules = helper.AppendDefaultRules(rules)
+	}
+
 	// Build create request
 	createReq := sgmodels.SecurityGroupCreateRequest{
 		Name:        plan.Name.ValueString(),
@@ -177,10 +357,7 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 	vpsClient := r.client.VPS()
 	securityGroupResource, err := vpsClient.SecurityGroups().Create(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to Create Security Group",
-			fmt.Sprintf("Unable to create security group '%s': %s", plan.Name.ValueString(), err.Error()),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Create Security Group", fmt.Sprintf("create security group '%s'", plan.Name.ValueString()), err))
 		return
 	}
 
@@ -189,22 +366,31 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	// Map response to state
 	plan.ID = types.StringValue(securityGroup.ID)
-	if securityGroup.Description != "" {
-		plan.Description = types.StringValue(securityGroup.Description)
-	} else {
-		plan.Description = types.StringValue("")
+	plan.CreatedAt = helper.TimestampValue(securityGroup.CreatedAt, types.StringNull())
+	plan.UpdatedAt = helper.TimestampValue(securityGroup.UpdatedAt, types.StringNull())
+	plan.Description = helper.DescriptionValue(securityGroup.Description, plan.Description)
+
+	// Map rules from API response back to state, keeping seeded defaults
+	// out of the rule blocks
+	sdkRules := securityGroup.Rules
+	if plan.CreateDefaultRules.ValueBool() {
+		sdkRules = helper.WithoutDefaultRules(sdkRules, userRules)
+	}
+	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, sdkRules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Map rules from API response back to state
-	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, securityGroup.Rules)
+	plan.DefaultRules, diags = helper.DefaultRulesValue(ctx, plan.CreateDefaultRules.ValueBool())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Reorder API rules to match plan order
-	plan.IngressRule = helper.ReorderRulesToMatchPlan(ctx, plan.IngressRule, apiIngressRules)
-	plan.EgressRule = helper.ReorderRulesToMatchPlan(ctx, plan.EgressRule, apiEgressRules)
+	plan.IngressRule = reconcileRules(ctx, plan.RulesAsSet, plan.IngressRule, apiIngressRules)
+	plan.EgressRule = reconcileRules(ctx, plan.RulesAsSet, plan.EgressRule, apiEgressRules)
 
 	tflog.Debug(ctx, "Created security group", map[string]interface{}{
 		"id":   securityGroup.ID,
@@ -215,6 +401,8 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_security_group", "read", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state resourcemodels.SecurityGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -225,7 +413,7 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 	securityGroupResource, err := vpsClient.SecurityGroups().Get(ctx, state.ID.ValueString())
 	if err != nil {
 		// Check for 404
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if helper.IsNotFound(err) {
 			tflog.Warn(ctx, "Security group not found, removing from state", map[string]interface{}{
 				"id": state.ID.ValueString(),
 			})
@@ -233,10 +421,7 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Failed to Read Security Group",
-			fmt.Sprintf("Unable to read security group '%s': %s", state.ID.ValueString(), err.Error()),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Read Security Group", fmt.Sprintf("read security group '%s'", state.ID.ValueString()), err))
 		return
 	}
 
@@ -245,27 +430,56 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 
 	// Update state from API response
 	state.Name = types.StringValue(securityGroup.Name)
-	if securityGroup.Description != "" {
-		state.Description = types.StringValue(securityGroup.Description)
-	} else {
-		state.Description = types.StringNull()
+	state.CreatedAt = helper.TimestampValue(securityGroup.CreatedAt, state.CreatedAt)
+	state.UpdatedAt = helper.TimestampValue(securityGroup.UpdatedAt, state.UpdatedAt)
+	state.Description = helper.DescriptionValue(securityGroup.Description, state.Description)
+
+	// State written before create_default_rules existed has no value for it
+	if state.CreateDefaultRules.IsNull() {
+		state.CreateDefaultRules = types.BoolValue(false)
 	}
 
 	// Map rules from API response
-	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, securityGroup.Rules)
+	sdkRules := securityGroup.Rules
+	if state.CreateDefaultRules.ValueBool() {
+		userRules, diags := helper.BuildSecurityGroupRules(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sdkRules = helper.WithoutDefaultRules(sdkRules, userRules)
+	}
+	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, sdkRules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DefaultRules, diags = helper.DefaultRulesValue(ctx, state.CreateDefaultRules.ValueBool())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+	if state.RulesAsSet.IsNull() {
+		state.RulesAsSet = types.BoolValue(false)
+	}
+	if state.RenameInPlace.IsNull() {
+		state.RenameInPlace = types.BoolValue(false)
+	}
 
 	// Reorder API rules to match current state order to prevent phantom changes
-	state.IngressRule = helper.ReorderRulesToMatchPlan(ctx, state.IngressRule, apiIngressRules)
-	state.EgressRule = helper.ReorderRulesToMatchPlan(ctx, state.EgressRule, apiEgressRules)
+	state.IngressRule = reconcileRules(ctx, state.RulesAsSet, state.IngressRule, apiIngressRules)
+	state.EgressRule = reconcileRules(ctx, state.RulesAsSet, state.EgressRule, apiEgressRules)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_security_group", "update", time.Now(), &req.State, &resp.Diagnostics)
+
 	var plan resourcemodels.SecurityGroupResourceModel
 	var state resourcemodels.SecurityGroupResourceModel
 
@@ -279,76 +493,140 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 		"id": state.ID.ValueString(),
 	})
 
+	// force_destroy and rules_as_set only live in state; toggling them, or
+	// reordering rules under rules_as_set, must not touch the rules
+	rulesEqual := helper.RulesEqual
+	if plan.RulesAsSet.ValueBool() {
+		rulesEqual = helper.RulesEqualAsSet
+	}
+	rulesUnchanged := rulesEqual(ctx, plan.IngressRule, state.IngressRule) &&
+		rulesEqual(ctx, plan.EgressRule, state.EgressRule)
+	if plan.Name.Equal(state.Name) && plan.Description.Equal(state.Description) && rulesUnchanged {
+		state.ForceDestroy = plan.ForceDestroy
+		state.RulesAsSet = plan.RulesAsSet
+		state.RenameInPlace = plan.RenameInPlace
+		state.Timeouts = plan.Timeouts
+		state.IngressRule = helper.ReconcileRulesAsSet(ctx, plan.IngressRule, state.IngressRule)
+		state.EgressRule = helper.ReconcileRulesAsSet(ctx, plan.EgressRule, state.EgressRule)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	vpsClient := r.client.VPS()
 
-	// Update description if changed
-	if !plan.Description.Equal(state.Description) {
-		updateReq := sgmodels.SecurityGroupUpdateRequest{
-			Description: plan.Description.ValueStringPointer(),
+	// Update name (rename_in_place only; otherwise it forces replacement)
+	// and description if changed
+	if !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description) {
+		updateReq := sgmodels.SecurityGroupUpdateRequest{}
+		if !plan.Name.Equal(state.Name) {
+			updateReq.Name = plan.Name.ValueStringPointer()
+		}
+		// A removed description is omitted by the SDK, so mark it to be sent
+		// as an empty string
+		updateReqCtx := ctx
+		if !plan.Description.Equal(state.Description) {
+			updateReq.Description = plan.Description.ValueStringPointer()
+			if plan.Description.IsNull() {
+				updateReqCtx = helper.WithClearedFields(ctx, "description")
+			}
 		}
 
-		_, err := vpsClient.SecurityGroups().Update(ctx, state.ID.ValueString(), updateReq)
+		_, err := vpsClient.SecurityGroups().Update(updateReqCtx, state.ID.ValueString(), updateReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Failed to Update Security Group Description",
-				fmt.Sprintf("Unable to update security group description: %s", err.Error()),
-			)
+			resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Update Security Group", "update security group name or description", err))
 			return
 		}
 	}
 
-	// Full rule replacement strategy (delete all, add new)
-	// This is simpler than diff-based updates and ensures consistency
+	// Build the new rules before touching the existing ones, so an invalid
+	// plan never leaves the group with its rules deleted
+	rules, diags := helper.BuildSecurityGroupRules(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.RulesAsSet.ValueBool() {
+		rules = helper.DedupeRules(rules)
+	}
+	userRules := rules
+	if plan.CreateDefaultRules.ValueBool() {
+		rules = helper.AppendDefaultRules(rules)
+	}
+
+	// A rename or new description alone leaves the rules untouched
+	if rulesUnchanged {
+		newState, diags := r.readUpdatedState(ctx, plan, state, userRules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		return
+	}
+
 	securityGroupResource, err := vpsClient.SecurityGroups().Get(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to Read Security Group for Update",
-			fmt.Sprintf("Unable to read current security group state: %s", err.Error()),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Read Security Group for Update", "read current security group state", err))
 		return
 	}
 
-	securityGroup := securityGroupResource.SecurityGroup
-
-	// Delete all existing rules using the Rules() client
-	rulesClient := securityGroupResource.Rules()
-	for _, rule := range securityGroup.Rules {
-		err := rulesClient.Delete(ctx, rule.ID)
-		if err != nil {
-			tflog.Warn(ctx, "Failed to delete rule during update", map[string]interface{}{
-				"rule_id": rule.ID,
-				"error":   err.Error(),
-			})
-			// Continue with other deletions
-		}
+	// Replace the rules create-before-delete, so servers using the group are
+	// never left without rules mid-update. Rules the group already has are
+	// kept as they are.
+	replaced, err := helper.ReplaceSecurityGroupRules(ctx, securityGroupResource.Rules(), securityGroupResource.SecurityGroup.Rules, rules)
+	resp.Diagnostics.Append(deferredRulesWarning(state.ID.ValueString(), replaced.Deferred)...)
+	if ctx.Err() != nil {
+		r.updateCancelled(ctx, plan, state, userRules, replaced.Completed, replaced.Total, resp)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Replace Security Group Rules", fmt.Sprintf("replace rules of security group '%s'", state.ID.ValueString()), err))
+		return
 	}
 
-	// Add new rules
-	rules, diags := helper.BuildSecurityGroupRules(ctx, plan)
+	newState, diags := r.readUpdatedState(ctx, plan, state, userRules)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	for _, rule := range rules {
-		_, err := rulesClient.Create(ctx, rule)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Failed to Create Security Group Rule",
-				fmt.Sprintf("Unable to create security group rule: %s", err.Error()),
-			)
-			return
-		}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// renameRequiresReplace replaces the group on a name change unless
+// rename_in_place is set in the plan. Replacement is warned about because the
+// group's server attachments do not move to the new group.
+func renameRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var renameInPlace types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rename_in_place"), &renameInPlace)...)
+	if resp.Diagnostics.HasError() || renameInPlace.ValueBool() {
+		return
 	}
 
-	// Read back final state
-	updatedGroupResource, err := vpsClient.SecurityGroups().Get(ctx, state.ID.ValueString())
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Security Group Will Be Replaced",
+		fmt.Sprintf("Renaming security group %q to %q creates a new group with the same rules and destroys the old one. "+
+			"Servers attached to the old group keep it until their security_group_ids reference the new ID, and lose its rules once it is destroyed; "+
+			"destroying it fails while it is attached unless force_destroy is true. "+
+			"Set rename_in_place = true to rename the group without replacing it.",
+			req.StateValue.ValueString(), req.PlanValue.ValueString()),
+	)
+}
+
+// readUpdatedState reads the group back after Update changed its rules and
+// maps it onto plan, keeping the plan's rule order where possible.
+func (r *SecurityGroupResource) readUpdatedState(ctx context.Context, plan, state resourcemodels.SecurityGroupResourceModel, userRules []sgmodels.SecurityGroupRuleCreateRequest) (resourcemodels.SecurityGroupResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	updatedGroupResource, err := r.client.VPS().SecurityGroups().Get(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Failed to Read Updated Security Group",
 			fmt.Sprintf("Security group updated but unable to read final state: %s", err.Error()),
 		)
-		return
+		return plan, diags
 	}
 
 	updatedGroup := updatedGroupResource.SecurityGroup
@@ -356,28 +634,71 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 	// Map updated state
 	plan.ID = types.StringValue(updatedGroup.ID)
 	plan.Name = types.StringValue(updatedGroup.Name)
-	if updatedGroup.Description != "" {
-		plan.Description = types.StringValue(updatedGroup.Description)
-	} else {
-		plan.Description = types.StringValue("")
-	}
+	plan.CreatedAt = helper.TimestampValue(updatedGroup.CreatedAt, state.CreatedAt)
+	plan.UpdatedAt = helper.TimestampValue(updatedGroup.UpdatedAt, state.UpdatedAt)
+	plan.Description = helper.DescriptionValue(updatedGroup.Description, plan.Description)
 
 	// We need to map the rules from the API response but maintain the order from the plan
 	// Get rules from API
-	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, updatedGroup.Rules)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	sdkRules := updatedGroup.Rules
+	if plan.CreateDefaultRules.ValueBool() {
+		sdkRules = helper.WithoutDefaultRules(sdkRules, userRules)
+	}
+	apiIngressRules, apiEgressRules, d := helper.MapSDKRulesToTerraform(ctx, sdkRules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	plan.DefaultRules, d = helper.DefaultRulesValue(ctx, plan.CreateDefaultRules.ValueBool())
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
 	}
 
 	// Reorder API rules to match plan order if possible
-	plan.IngressRule = helper.ReorderRulesToMatchPlan(ctx, plan.IngressRule, apiIngressRules)
-	plan.EgressRule = helper.ReorderRulesToMatchPlan(ctx, plan.EgressRule, apiEgressRules)
+	plan.IngressRule = reconcileRules(ctx, plan.RulesAsSet, plan.IngressRule, apiIngressRules)
+	plan.EgressRule = reconcileRules(ctx, plan.RulesAsSet, plan.EgressRule, apiEgressRules)
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return plan, diags
+}
+
+// updateCancelled reports an Update stopped between rule operations because
+// the apply was cancelled or its deadline passed, and saves the rules the
+// group has at that point so the next plan shows what is still missing.
+func (r *SecurityGroupResource) updateCancelled(ctx context.Context, plan, state resourcemodels.SecurityGroupResourceModel, userRules []sgmodels.SecurityGroupRuleCreateRequest, completed, total int, resp *resource.UpdateResponse) {
+	tflog.Warn(ctx, "Security group update cancelled", map[string]interface{}{
+		"id":        state.ID.ValueString(),
+		"completed": completed,
+		"total":     total,
+	})
+
+	resp.Diagnostics.AddError(
+		"Operation Cancelled",
+		fmt.Sprintf("Updating the rules of security group %s stopped after %d of %d rule operations: %s. "+
+			"The rules applied so far are recorded in state; apply again to finish the update.",
+			state.ID.ValueString(), completed, total, ctx.Err()),
+	)
+
+	// The request context is done, so the read-back needs its own deadline
+	readCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelledReadTimeout)
+	defer cancel()
+
+	newState, diags := r.readUpdatedState(readCtx, plan, state, userRules)
+	if diags.HasError() {
+		resp.Diagnostics.AddWarning(
+			"Security Group State Not Refreshed",
+			fmt.Sprintf("Unable to read security group %s after the update was cancelled, so state still shows the previous rules. The next refresh corrects it.\n\n%s",
+				state.ID.ValueString(), diags.Errors()[0].Detail()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(readCtx, &newState)...)
 }
 
 func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_security_group", "delete", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state resourcemodels.SecurityGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -389,19 +710,47 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		"name": state.Name.ValueString(),
 	})
 
+	timeout, diags := helper.ResolveTimeout(ctx, state.Timeouts, "delete", helper.DefaultInUseDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	vpsClient := r.client.VPS()
-	err := vpsClient.SecurityGroups().Delete(ctx, state.ID.ValueString())
+	if state.ForceDestroy.ValueBool() {
+		detached, err := helper.DetachSecurityGroupFromServers(ctx, vpsClient.Servers(), state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Detach Security Group",
+				fmt.Sprintf("Unable to detach security group '%s' from its servers before deletion: %s\n\n"+
+					"Servers detached so far: %s", state.Name.ValueString(), err.Error(), strings.Join(detached, ", ")),
+			)
+			return
+		}
+		if len(detached) > 0 {
+			tflog.Info(ctx, "Detached security group before deletion", map[string]interface{}{
+				"id":      state.ID.ValueString(),
+				"servers": detached,
+			})
+		}
+	}
+
+	// A server destroyed in the same apply may still hold the group for a
+	// moment after its own delete returns
+	err := helper.DeleteWithInUseRetry(ctx, timeout, func(ctx context.Context) error {
+		return vpsClient.SecurityGroups().Delete(ctx, state.ID.ValueString())
+	})
 	if err != nil {
 		// Check for 404 (already deleted)
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if helper.IsNotFound(err) {
 			tflog.Warn(ctx, "Security group already deleted", map[string]interface{}{
 				"id": state.ID.ValueString(),
 			})
 			return
 		}
 
-		// Check for 409 (in use by instances)
-		if strings.Contains(err.Error(), "409") || strings.Contains(err.Error(), "in use") {
+		// Still in use by instances after retrying for the delete timeout
+		if helper.IsInUse(err) {
 			// Parse security group ID from error message if available
 			sgID := state.ID.ValueString()
 			errorMsg := err.Error()
@@ -414,19 +763,16 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 
 			resp.Diagnostics.AddError(
 				"Security Group In Use",
-				fmt.Sprintf("Cannot delete security group '%s' (ID: %s): it is currently in use by one or more instances.\n\n"+
-					"Please detach the security group from all instances before deletion.\n\n"+
+				fmt.Sprintf("Cannot delete security group '%s' (ID: %s): it is still in use by one or more instances after retrying for %s.\n\n"+
+					"Please detach the security group from all instances before deletion, or set force_destroy = true and apply before destroying.\n\n"+
 					"To find instances using this security group, check the ZillaForge console or use the CLI:\n"+
 					"  zillaforge instances list --security-group %s",
-					state.Name.ValueString(), sgID, sgID),
+					state.Name.ValueString(), sgID, timeout, sgID),
 			)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Failed to Delete Security Group",
-			fmt.Sprintf("Unable to delete security group '%s': %s", state.Name.ValueString(), err.Error()),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Failed to Delete Security Group", fmt.Sprintf("delete security group '%s'", state.Name.ValueString()), err))
 		return
 	}
 
@@ -435,11 +781,40 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 	})
 }
 
+// reconcileRules aligns the API's rules with the planned (or prior) list,
+// by membership under rules_as_set and by order otherwise, after folding
+// rules back into the list's CIDR lists.
+func reconcileRules(ctx context.Context, asSet types.Bool, planList, apiList types.List) types.List {
+	apiList = helper.CollapseRulesToMatch(ctx, planList, apiList)
+	if asSet.ValueBool() {
+		return helper.ReconcileRulesAsSet(ctx, planList, apiList)
+	}
+	return helper.ReorderRulesToMatchPlan(ctx, planList, apiList)
+}
+
 // ImportState imports a security group by its ID.
 // T059-T062: Import implementation with UUID validation and error handling.
 func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// An optional "<project>/" prefix guards against importing with the
+	// provider of another project
+	project, importID, err := helper.SplitProjectImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Import ID must be a security group UUID, optionally prefixed with its project as '<project>/<uuid>': %s", err),
+		)
+		return
+	}
+	if project != "" && project != r.project && project != r.client.VPS().ProjectID() {
+		resp.Diagnostics.AddError(
+			"Import Project Mismatch",
+			fmt.Sprintf("Import ID '%s' names project '%s', but the provider of this resource is configured for project '%s'. "+
+				"Set the resource's provider argument to a provider configured for project '%s', then import again.", req.ID, project, r.project, project),
+		)
+		return
+	}
+
 	// T060: Validate import ID is valid UUID format
-	importID := req.ID
 	uuidRegex := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 	if !uuidRegex.MatchString(importID) {
 		resp.Diagnostics.AddError(
@@ -459,7 +834,8 @@ func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.Im
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Import Error",
-			fmt.Sprintf("Unable to read security group '%s': %s\n\nVerify the security group exists and you have permission to access it.", importID, err),
+			fmt.Sprintf("Unable to read security group '%s': %s\n\nVerify the security group exists in project '%s' and you have permission to access it. "+
+				"A security group of another project must be imported with a provider configured for that project.", importID, err, r.client.VPS().ProjectID()),
 		)
 		return
 	}
@@ -471,11 +847,9 @@ func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.Im
 	var state resourcemodels.SecurityGroupResourceModel
 	state.ID = types.StringValue(securityGroup.ID)
 	state.Name = types.StringValue(securityGroup.Name)
-	if securityGroup.Description != "" {
-		state.Description = types.StringValue(securityGroup.Description)
-	} else {
-		state.Description = types.StringNull()
-	}
+	state.CreatedAt = helper.TimestampValue(securityGroup.CreatedAt, types.StringNull())
+	state.UpdatedAt = helper.TimestampValue(securityGroup.UpdatedAt, types.StringNull())
+	state.Description = helper.DescriptionValue(securityGroup.Description, state.Description)
 
 	// Map rules from API
 	apiIngressRules, apiEgressRules, diags := helper.MapSDKRulesToTerraform(ctx, securityGroup.Rules)
@@ -487,6 +861,18 @@ func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.Im
 	state.IngressRule = apiIngressRules
 	state.EgressRule = apiEgressRules
 
+	// Imported groups have no seeded defaults; every rule belongs to the blocks
+	state.CreateDefaultRules = types.BoolValue(false)
+	state.ForceDestroy = types.BoolValue(false)
+	state.RulesAsSet = types.BoolValue(false)
+	state.RenameInPlace = types.BoolValue(false)
+	state.Timeouts = types.ObjectNull(helper.DeleteTimeoutsAttrTypes)
+	state.DefaultRules, diags = helper.DefaultRulesValue(ctx, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	tflog.Info(ctx, "Imported security group", map[string]interface{}{
@@ -4,13 +4,17 @@
 package resource_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 // T010: Acceptance test - Create security group with ingress rules.
@@ -24,6 +28,8 @@ func TestAccSecurityGroup_CreateIngress(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("zillaforge_security_group.web", "name", "test-ingress-sg"),
 					resource.TestCheckResourceAttrSet("zillaforge_security_group.web", "id"),
+					resource.TestCheckResourceAttrSet("zillaforge_security_group.web", "created_at"),
+					resource.TestCheckResourceAttrSet("zillaforge_security_group.web", "updated_at"),
 					resource.TestCheckResourceAttr("zillaforge_security_group.web", "ingress_rule.0.protocol", "tcp"),
 					resource.TestCheckResourceAttr("zillaforge_security_group.web", "ingress_rule.0.port_range", "80"),
 					resource.TestCheckResourceAttr("zillaforge_security_group.web", "ingress_rule.0.source_cidr", "0.0.0.0/0"),
@@ -100,6 +106,13 @@ func TestAccSecurityGroup_UpdateDescription(t *testing.T) {
 					resource.TestCheckResourceAttr("zillaforge_security_group.update", "description", "Updated description"),
 				),
 			},
+			{
+				Config: testAccSecurityGroupConfig_updateDescRemoved,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.update", "name", "test-update-sg"),
+					resource.TestCheckNoResourceAttr("zillaforge_security_group.update", "description"),
+				),
+			},
 		},
 	})
 }
@@ -118,6 +131,12 @@ resource "zillaforge_security_group" "update" {
 }
 `
 
+const testAccSecurityGroupConfig_updateDescRemoved = `
+resource "zillaforge_security_group" "update" {
+  name = "test-update-sg"
+}
+`
+
 // T013: Acceptance test - Add rules to existing security group.
 func TestAccSecurityGroup_AddRules(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -328,6 +347,60 @@ func TestAccSecurityGroup_DeleteBlockedWhenAttached(t *testing.T) {
 	})
 }
 
+// Acceptance test - force_destroy detaches the group from a server before deleting it.
+func TestAccSecurityGroup_ForceDestroyWhenAttached(t *testing.T) {
+	name := fmt.Sprintf("test-force-destroy-%d", time.Now().UnixNano()%100000)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSecurityGroupConfig_forceDestroy, name, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "force_destroy", "true"),
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.0.security_group_ids.0", "zillaforge_security_group.test", "id"),
+				),
+			},
+			// Removing the group while the server still uses it succeeds
+			{
+				Config: fmt.Sprintf(testAccSecurityGroupConfig_forceDestroyServerOnly, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+// Acceptance test - rules_as_set keeps duplicate and reordered rules free of diffs.
+func TestAccSecurityGroup_RulesAsSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A repeated rule is created once but kept in state as written;
+			// the framework's post-apply plan check fails on any diff
+			{
+				Config: testAccSecurityGroupConfig_rulesAsSet,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "rules_as_set", "true"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.#", "3"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.0.port_range", "22"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.2.port_range", "22"),
+				),
+			},
+			// Reordering the blocks only rewrites state
+			{
+				Config: testAccSecurityGroupConfig_rulesAsSetReordered,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.#", "2"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.0.port_range", "443"),
+				),
+			},
+		},
+	})
+}
+
 // T018: Acceptance test - ForceNew on name change.
 func TestAccSecurityGroup_RequiresReplaceOnNameChange(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -364,6 +437,61 @@ resource "zillaforge_security_group" "rename" {
 }
 `
 
+// Acceptance test - rename_in_place renames without replacing the group or
+// touching its rules.
+func TestAccSecurityGroup_RenameInPlace(t *testing.T) {
+	var groupID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSecurityGroupConfig_renameInPlace, "test-rename-in-place-sg"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.rename", "ingress_rule.#", "1"),
+					resource.TestCheckResourceAttrWith("zillaforge_security_group.rename", "id", func(value string) error {
+						groupID = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccSecurityGroupConfig_renameInPlace, "test-renamed-in-place-sg"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("zillaforge_security_group.rename", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.rename", "name", "test-renamed-in-place-sg"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.rename", "ingress_rule.#", "1"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.rename", "ingress_rule.0.port_range", "22"),
+					resource.TestCheckResourceAttrWith("zillaforge_security_group.rename", "id", func(value string) error {
+						if value != groupID {
+							return fmt.Errorf("expected security group %s to be renamed in place, got new ID %s", groupID, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupConfig_renameInPlace = `
+resource "zillaforge_security_group" "rename" {
+  name            = "%s"
+  rename_in_place = true
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "0.0.0.0/0"
+  }
+}
+`
+
 // T055: Acceptance test - Import security group by ID.
 func TestAccSecurityGroup_ImportByID(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -400,6 +528,42 @@ resource "zillaforge_security_group" "test" {
 }
 `
 
+func TestAccSecurityGroup_ICMPv6(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupConfig_icmpv6,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.#", "1"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.0.protocol", "icmpv6"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.0.port_range", "all"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.test", "ingress_rule.0.source_cidr", "::/0"),
+				),
+			},
+			{
+				ResourceName:      "zillaforge_security_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupConfig_icmpv6 = `
+resource "zillaforge_security_group" "test" {
+  name        = "test-icmpv6-sg"
+  description = "ICMPv6 test"
+
+  ingress_rule {
+    protocol    = "icmpv6"
+    port_range  = "all"
+    source_cidr = "::/0"
+  }
+}
+`
+
 // T056: Acceptance test - Plan after import shows no changes (matching config).
 func TestAccSecurityGroup_ImportNoChanges(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -607,3 +771,271 @@ resource "zillaforge_security_group" "test" {
   description = "Security group for delete blocked test"
 }
 `
+
+// Acceptance test - Over-long name and description are rejected at plan time.
+func TestAccSecurityGroup_NameDescriptionLengthPlanTimeReject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccSecurityGroupConfig_lengthCheck, strings.Repeat("n", 256), "ok"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)Attribute name string length must be between 1 and 255`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccSecurityGroupConfig_lengthCheck, "test-sg-length", strings.Repeat("d", 1001)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)Attribute description string length must be at most 1000`),
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupConfig_lengthCheck = `
+resource "zillaforge_security_group" "test" {
+  name        = "%s"
+  description = "%s"
+}
+`
+
+// Acceptance test - create_default_rules seeds egress and ICMP rules in
+// default_rules, alongside the user's rule blocks, without drift.
+func TestAccSecurityGroup_CreateDefaultRules(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupConfig_defaultRules,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.defaults", "create_default_rules", "true"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.defaults", "default_rules.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("zillaforge_security_group.defaults", "default_rules.*", map[string]string{
+						"direction":  "egress",
+						"protocol":   "any",
+						"port_range": "all",
+						"cidr":       "0.0.0.0/0",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("zillaforge_security_group.defaults", "default_rules.*", map[string]string{
+						"direction":  "egress",
+						"protocol":   "any",
+						"port_range": "all",
+						"cidr":       "::/0",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("zillaforge_security_group.defaults", "default_rules.*", map[string]string{
+						"direction":  "ingress",
+						"protocol":   "icmp",
+						"port_range": "all",
+						"cidr":       "0.0.0.0/0",
+					}),
+					// User rules are unaffected by the seeded defaults
+					resource.TestCheckResourceAttr("zillaforge_security_group.defaults", "ingress_rule.#", "1"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.defaults", "ingress_rule.0.port_range", "22"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.defaults", "egress_rule.#", "0"),
+				),
+			},
+			{
+				Config:   testAccSecurityGroupConfig_defaultRules,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupConfig_defaultRules = `
+resource "zillaforge_security_group" "defaults" {
+  name                 = "test-default-rules-sg"
+  create_default_rules = true
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "0.0.0.0/0"
+  }
+}
+`
+
+const testAccSecurityGroupConfig_forceDestroy = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_security_group" "test" {
+  name          = "%s-sg"
+  force_destroy = true
+}
+
+resource "zillaforge_server" "test" {
+  name      = "%s-server"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id         = data.zillaforge_networks.test.networks[0].id
+    security_group_ids = [zillaforge_security_group.test.id]
+  }
+
+  # Keep the server from dropping the group itself, so the delete has to
+  # detach it
+  lifecycle {
+    ignore_changes = [network_attachment]
+  }
+}
+`
+
+const testAccSecurityGroupConfig_forceDestroyServerOnly = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name      = "%s-server"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+  }
+
+  lifecycle {
+    ignore_changes = [network_attachment]
+  }
+}
+`
+
+const testAccSecurityGroupConfig_rulesAsSet = `
+resource "zillaforge_security_group" "test" {
+  name         = "test-rules-as-set"
+  rules_as_set = true
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "0.0.0.0/0"
+  }
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "443"
+    source_cidr = "0.0.0.0/0"
+  }
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "0.0.0.0/0"
+  }
+}
+`
+
+const testAccSecurityGroupConfig_rulesAsSetReordered = `
+resource "zillaforge_security_group" "test" {
+  name         = "test-rules-as-set"
+  rules_as_set = true
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "443"
+    source_cidr = "0.0.0.0/0"
+  }
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "0.0.0.0/0"
+  }
+}
+`
+
+// Acceptance test - A rule added outside Terraform is read back after the
+// configured rules, which keep their positions, so the plan only removes the
+// added rule.
+func TestAccSecurityGroup_RuleAddedOutOfBand(t *testing.T) {
+	var groupID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupConfig_outOfBand,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.#", "2"),
+					resource.TestCheckResourceAttrWith("zillaforge_security_group.drift", "id", func(value string) error {
+						groupID = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					group, err := provider.TestAccProjectClient(t).VPS().SecurityGroups().Get(ctx, groupID)
+					if err != nil {
+						t.Fatalf("failed to read security group: %v", err)
+					}
+					port := 8080
+					if _, err := group.Rules().Create(ctx, sgmodels.SecurityGroupRuleCreateRequest{
+						Direction:  sgmodels.DirectionIngress,
+						Protocol:   sgmodels.ProtocolTCP,
+						PortMin:    &port,
+						PortMax:    &port,
+						RemoteCIDR: "10.0.0.0/8",
+					}); err != nil {
+						t.Fatalf("failed to add rule: %v", err)
+					}
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.#", "3"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.0.port_range", "443"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.1.port_range", "22"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.2.port_range", "8080"),
+				),
+			},
+			{
+				Config: testAccSecurityGroupConfig_outOfBand,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("zillaforge_security_group.drift", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.#", "2"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.0.port_range", "443"),
+					resource.TestCheckResourceAttr("zillaforge_security_group.drift", "ingress_rule.1.port_range", "22"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupConfig_outOfBand = `
+resource "zillaforge_security_group" "drift" {
+  name = "test-rule-drift-sg"
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "443"
+    source_cidr = "0.0.0.0/0"
+  }
+
+  ingress_rule {
+    protocol    = "tcp"
+    port_range  = "22"
+    source_cidr = "203.0.113.0/24"
+  }
+}
+`
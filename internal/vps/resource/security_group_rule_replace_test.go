@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecurityGroupUpdate_RuleReplacementOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// rejectDuplicates makes the first create of port 443 fail with 409
+		// while the old rule on port 443 still exists.
+		rejectDuplicates bool
+		expectedOps      []string
+		expectWarning    bool
+	}{
+		{
+			name:        "create before delete",
+			expectedOps: []string{"create 443", "delete rule-80"},
+		},
+		{
+			name:             "duplicate rejected",
+			rejectDuplicates: true,
+			expectedOps:      []string{"create 443", "delete rule-80", "delete rule-443", "create 443"},
+			expectWarning:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			const sgID = "00000000-0000-0000-0000-000000000002"
+			ctx := context.Background()
+
+			// The group allows 22 and 80 (and, for the duplicate case, a
+			// 443 rule the API considers equivalent to the planned one);
+			// the plan keeps 22 and moves 80 to 443.
+			var mu sync.Mutex
+			rules := []sgmodels.SecurityGroupRule{
+				{ID: "rule-22", Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+				{ID: "rule-80", Direction: "ingress", Protocol: "tcp", PortMin: 80, PortMax: 80, RemoteCIDR: "0.0.0.0/0"},
+			}
+			if tt.rejectDuplicates {
+				rules = append(rules, sgmodels.SecurityGroupRule{ID: "rule-443", Direction: "ingress", Protocol: "tcp", PortMin: 443, PortMax: 443, RemoteCIDR: "10.0.0.0/8"})
+			}
+			var ops []string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/rules"):
+					var req sgmodels.SecurityGroupRuleCreateRequest
+					_ = json.NewDecoder(r.Body).Decode(&req)
+					ops = append(ops, "create 443")
+					for _, rule := range rules {
+						if rule.ID == "rule-443" {
+							w.WriteHeader(http.StatusConflict)
+							_, _ = w.Write([]byte(`{"message":"security group rule already exists"}`))
+							return
+						}
+					}
+					_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroupRule{ID: "rule-new", Direction: req.Direction, Protocol: req.Protocol})
+				case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/rules/"):
+					ruleID := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+					ops = append(ops, "delete "+ruleID)
+					for i, rule := range rules {
+						if rule.ID == ruleID {
+							rules = append(rules[:i], rules[i+1:]...)
+							break
+						}
+					}
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(r.URL.Path, "/security_groups/"+sgID):
+					_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroup{ID: sgID, Name: "web", Rules: rules})
+				default:
+					_, _ = w.Write([]byte(`{}`))
+				}
+			}))
+			t.Cleanup(srv.Close)
+
+			client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+			if err != nil {
+				t.Fatalf("failed to create SDK client: %v", err)
+			}
+			projectClient, err := client.Project(ctx, "test-project")
+			if err != nil {
+				t.Fatalf("failed to create project client: %v", err)
+			}
+
+			r := NewSecurityGroupResource()
+			configureResp := &resource.ConfigureResponse{}
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+				ProviderData: &helper.ProviderData{Client: projectClient},
+			}, configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			newState := func(ports ...string) tfsdk.State {
+				state := tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				}
+				for attr, value := range map[string]string{"id": sgID, "name": "web", "description": ""} {
+					if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+						t.Fatalf("failed to build state: %v", diags.Errors())
+					}
+				}
+				ingress := make([]resourcemodels.SecurityRuleModel, 0, len(ports))
+				for _, port := range ports {
+					ingress = append(ingress, resourcemodels.SecurityRuleModel{
+						Protocol:         types.StringValue("tcp"),
+						PortRange:        types.StringValue(port),
+						SourceCIDR:       types.StringValue("0.0.0.0/0"),
+						SourceCIDRs:      types.ListNull(types.StringType),
+						DestinationCIDR:  types.StringNull(),
+						DestinationCIDRs: types.ListNull(types.StringType),
+					})
+				}
+				if diags := state.SetAttribute(ctx, path.Root("ingress_rule"), ingress); diags.HasError() {
+					t.Fatalf("failed to build state: %v", diags.Errors())
+				}
+				return state
+			}
+			prior := newState("22", "80")
+			planned := newState("22", "443")
+
+			resp := &resource.UpdateResponse{State: prior}
+			r.Update(ctx, resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+				State: prior,
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if !reflect.DeepEqual(ops, tt.expectedOps) {
+				t.Errorf("expected rule operations %v, got %v", tt.expectedOps, ops)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.expectWarning {
+				t.Errorf("expected warning %t, got %v", tt.expectWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
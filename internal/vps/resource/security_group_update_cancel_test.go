@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sgmodels "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecurityGroupUpdate_StopsWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	const sgID = "00000000-0000-0000-0000-000000000001"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The group starts with two rules; the apply is cancelled while the
+	// first one is being deleted.
+	var mu sync.Mutex
+	rules := []sgmodels.SecurityGroupRule{
+		{ID: "rule-1", Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteCIDR: "0.0.0.0/0"},
+		{ID: "rule-2", Direction: "ingress", Protocol: "tcp", PortMin: 443, PortMax: 443, RemoteCIDR: "0.0.0.0/0"},
+	}
+	deletes := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/rules/"):
+			deletes++
+			ruleID := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			for i, rule := range rules {
+				if rule.ID == ruleID {
+					rules = append(rules[:i], rules[i+1:]...)
+					break
+				}
+			}
+			cancel()
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/security_groups/"+sgID):
+			_ = json.NewEncoder(w).Encode(sgmodels.SecurityGroup{ID: sgID, Name: "web", Description: "new", Rules: rules})
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewSecurityGroupResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	newState := func(description string, ingress []resourcemodels.SecurityRuleModel) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		for attr, value := range map[string]string{"id": sgID, "name": "web", "description": description} {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+		}
+		if ingress != nil {
+			if diags := state.SetAttribute(ctx, path.Root("ingress_rule"), ingress); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+		}
+		return state
+	}
+	// Changing the rules makes Update replace them.
+	prior := newState("old", nil)
+	planned := newState("new", []resourcemodels.SecurityRuleModel{{
+		Protocol:         types.StringValue("tcp"),
+		PortRange:        types.StringValue("80"),
+		SourceCIDR:       types.StringValue("0.0.0.0/0"),
+		SourceCIDRs:      types.ListNull(types.StringType),
+		DestinationCIDR:  types.StringNull(),
+		DestinationCIDRs: types.ListNull(types.StringType),
+	}})
+
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		State: prior,
+	}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Operation Cancelled" {
+		t.Fatalf("expected an Operation Cancelled error, got %v", resp.Diagnostics)
+	}
+	if deletes != 1 {
+		t.Errorf("expected no rule operations after cancellation, got %d deletes", deletes)
+	}
+
+	var ingress types.List
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("ingress_rule"), &ingress); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags.Errors())
+	}
+	if len(ingress.Elements()) != 1 {
+		t.Errorf("expected state to record the one remaining rule, got %s", ingress)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestServerCreate_KeepsIDWhenAssociationFails(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const serverID = "11111111-1111-1111-1111-111111111111"
+	server := `{"id":"` + serverID + `","name":"web","status":"ACTIVE"}`
+
+	// The server is created and becomes active, but its NICs cannot be
+	// listed, so the floating IP association step fails.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":400,"message":"network service unavailable"}`))
+		case strings.HasSuffix(r.URL.Path, "/servers"), strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(server))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attachment := types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+		"network_id":           types.StringValue("22222222-2222-2222-2222-222222222222"),
+		"ip_address":           types.StringUnknown(),
+		"primary":              types.BoolValue(true),
+		"security_group_ids":   types.ListNull(types.StringType),
+		"floating_ip_id":       types.StringValue("33333333-3333-3333-3333-333333333333"),
+		"floating_ip":          types.StringUnknown(),
+		"public_ip":            types.StringUnknown(),
+		"security_group_names": types.ListNull(types.StringType),
+		"dns_nameservers":      types.ListNull(types.StringType),
+		"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+		"fixed_ip_strategy":    types.StringNull(),
+	})
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for attr, value := range map[string]interface{}{
+		"name":                    "web",
+		"flavor_id":               "44444444-4444-4444-4444-444444444444",
+		"image_id":                "55555555-5555-5555-5555-555555555555",
+		"network_attachment":      types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{attachment}),
+		"wait_for_active":         true,
+		"wait_for_deleted":        true,
+		"deletion_protection":     true,
+		"floating_ip_association": helper.FloatingIPAssociationStrict,
+	} {
+		if diags := plan.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags.Errors())
+		}
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the failed association to be reported")
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() != serverID {
+		t.Errorf("expected server ID %s in state, got %s", serverID, id)
+	}
+
+	var protected types.Bool
+	resp.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)
+	if !protected.ValueBool() {
+		t.Error("expected deletion_protection to be kept in state for Delete")
+	}
+}
+
+func TestServerCreate_AdoptsServerAfterTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const serverID = "11111111-1111-1111-1111-111111111111"
+	server := `{"id":"` + serverID + `","name":"web","status":"ACTIVE"}`
+
+	// The API creates the server but answers too late, so the create call
+	// times out on the client side.
+	var (
+		mu      sync.Mutex
+		creates int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers") && r.Method == http.MethodPost:
+			mu.Lock()
+			creates++
+			mu.Unlock()
+			time.Sleep(300 * time.Millisecond)
+			_, _ = w.Write([]byte(server))
+		case strings.HasSuffix(r.URL.Path, "/servers"):
+			mu.Lock()
+			created := creates > 0
+			mu.Unlock()
+			if created {
+				_, _ = w.Write([]byte(`{"servers":[` + server + `]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"servers":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(server))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature", cloudsdk.WithTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attachment := types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+		"network_id":           types.StringValue("22222222-2222-2222-2222-222222222222"),
+		"ip_address":           types.StringUnknown(),
+		"primary":              types.BoolValue(true),
+		"security_group_ids":   types.ListNull(types.StringType),
+		"floating_ip_id":       types.StringNull(),
+		"floating_ip":          types.StringUnknown(),
+		"public_ip":            types.StringUnknown(),
+		"security_group_names": types.ListNull(types.StringType),
+		"dns_nameservers":      types.ListNull(types.StringType),
+		"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+		"fixed_ip_strategy":    types.StringNull(),
+	})
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for attr, value := range map[string]interface{}{
+		"name":                    "web",
+		"flavor_id":               "44444444-4444-4444-4444-444444444444",
+		"image_id":                "55555555-5555-5555-5555-555555555555",
+		"network_attachment":      types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{attachment}),
+		"wait_for_active":         true,
+		"wait_for_deleted":        true,
+		"floating_ip_association": helper.FloatingIPAssociationStrict,
+	} {
+		if diags := plan.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags.Errors())
+		}
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a warning about the lost create response, got %v", resp.Diagnostics)
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() != serverID {
+		t.Errorf("expected the existing server %s to be adopted, got %s", serverID, id)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if creates != 1 {
+		t.Errorf("expected a single create request, got %d", creates)
+	}
+}
+
+func TestServerCreate_WaitUntilStatusShutoff(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const serverID = "11111111-1111-1111-1111-111111111111"
+
+	// The image powers the server off once it is provisioned, so it never
+	// reports ACTIVE.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers") && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"servers":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers"):
+			_, _ = w.Write([]byte(`{"id":"` + serverID + `","name":"web","status":"BUILD"}`))
+		case strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(`{"id":"` + serverID + `","name":"web","status":"SHUTOFF"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attachment := types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+		"network_id":           types.StringValue("22222222-2222-2222-2222-222222222222"),
+		"ip_address":           types.StringUnknown(),
+		"primary":              types.BoolValue(true),
+		"security_group_ids":   types.ListNull(types.StringType),
+		"floating_ip_id":       types.StringNull(),
+		"floating_ip":          types.StringUnknown(),
+		"public_ip":            types.StringUnknown(),
+		"security_group_names": types.ListNull(types.StringType),
+		"dns_nameservers":      types.ListNull(types.StringType),
+		"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+		"fixed_ip_strategy":    types.StringNull(),
+	})
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for attr, value := range map[string]interface{}{
+		"name":                    "web",
+		"flavor_id":               "44444444-4444-4444-4444-444444444444",
+		"image_id":                "55555555-5555-5555-5555-555555555555",
+		"network_attachment":      types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{attachment}),
+		"wait_for_active":         true,
+		"wait_until_status":       "shutoff",
+		"wait_for_deleted":        true,
+		"floating_ip_association": helper.FloatingIPAssociationStrict,
+	} {
+		if diags := plan.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags.Errors())
+		}
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var status, waitUntilStatus types.String
+	resp.State.GetAttribute(ctx, path.Root("status"), &status)
+	resp.State.GetAttribute(ctx, path.Root("wait_until_status"), &waitUntilStatus)
+	if status.ValueString() != "shutoff" {
+		t.Errorf("expected the create to return once the server is shutoff, got status %s", status)
+	}
+	if waitUntilStatus.ValueString() != "shutoff" {
+		t.Errorf("expected wait_until_status to be kept in state, got %s", waitUntilStatus)
+	}
+}
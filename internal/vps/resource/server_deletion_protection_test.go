@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestServerDelete_DeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		protected   bool
+		expectError bool
+	}{
+		{name: "protected", protected: true, expectError: true},
+		{name: "unprotected", protected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// Every VPS call answers 404, which Delete treats as already gone.
+			var vpsCalls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasPrefix(r.URL.Path, "/iam/") {
+					_, _ = w.Write([]byte(`{}`))
+					return
+				}
+				vpsCalls.Add(1)
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+			if err != nil {
+				t.Fatalf("failed to create SDK client: %v", err)
+			}
+			projectClient, err := client.Project(ctx, "test-project")
+			if err != nil {
+				t.Fatalf("failed to create project client: %v", err)
+			}
+
+			r := NewServerResource()
+			configureResp := &resource.ConfigureResponse{}
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+				ProviderData: &helper.ProviderData{Client: projectClient},
+			}, configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+			}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			for attr, value := range map[string]interface{}{
+				"id":                  "00000000-0000-0000-0000-000000000000",
+				"name":                "prod-db",
+				"deletion_protection": tt.protected,
+			} {
+				if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+					t.Fatalf("failed to build state: %v", diags.Errors())
+				}
+			}
+
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got: %v", tt.expectError, resp.Diagnostics.Errors())
+			}
+			if tt.protected {
+				if calls := vpsCalls.Load(); calls != 0 {
+					t.Errorf("expected no API calls while protected, got %d", calls)
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Server Deletion Protected" {
+					t.Errorf("unexpected diagnostic: %s", summary)
+				}
+			} else if vpsCalls.Load() == 0 {
+				t.Error("expected the server to be deleted through the API")
+			}
+		})
+	}
+}
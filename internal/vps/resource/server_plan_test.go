@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestServerModifyPlan_FloatingIPAlreadyAssociated(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		serverID = "11111111-1111-1111-1111-111111111111"
+		otherID  = "99999999-9999-9999-9999-999999999999"
+		freeFIP  = "33333333-3333-3333-3333-333333333333"
+		ownFIP   = "44444444-4444-4444-4444-444444444444"
+		takenFIP = "55555555-5555-5555-5555-555555555555"
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+freeFIP):
+			_, _ = w.Write([]byte(`{"id":"` + freeFIP + `","address":"203.0.113.10"}`))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+ownFIP):
+			_, _ = w.Write([]byte(`{"id":"` + ownFIP + `","address":"203.0.113.11","device_id":"` + serverID + `"}`))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+takenFIP):
+			_, _ = w.Write([]byte(`{"id":"` + takenFIP + `","address":"203.0.113.12","device_id":"` + otherID + `","device_name":"db"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// build returns the raw server config for a NIC with floatingIPID, and
+	// the ID of an existing server when id is set.
+	build := func(floatingIPID types.String, id string) tftypes.Value {
+		attachment := types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+			"network_id":           types.StringValue("22222222-2222-2222-2222-222222222222"),
+			"ip_address":           types.StringNull(),
+			"primary":              types.BoolValue(true),
+			"security_group_ids":   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("66666666-6666-6666-6666-666666666666")}),
+			"floating_ip_id":       floatingIPID,
+			"floating_ip":          types.StringNull(),
+			"public_ip":            types.StringNull(),
+			"security_group_names": types.ListNull(types.StringType),
+			"dns_nameservers":      types.ListNull(types.StringType),
+			"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+			"fixed_ip_strategy":    types.StringNull(),
+		})
+		values := map[string]interface{}{
+			"name":               "web",
+			"flavor_id":          "77777777-7777-7777-7777-777777777777",
+			"image_id":           "88888888-8888-8888-8888-888888888888",
+			"keypair":            "web-key",
+			"network_attachment": types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{attachment}),
+		}
+		if id != "" {
+			values["id"] = id
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for attr, value := range values {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build config: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	tests := []struct {
+		name        string
+		fipID       types.String
+		serverID    string
+		expectError bool
+	}{
+		{name: "unassociated", fipID: types.StringValue(freeFIP)},
+		{name: "associated with this server", fipID: types.StringValue(ownFIP), serverID: serverID},
+		{name: "associated with another server", fipID: types.StringValue(takenFIP), expectError: true},
+		{name: "associated with another server on update", fipID: types.StringValue(takenFIP), serverID: serverID, expectError: true},
+		{name: "unknown at plan time", fipID: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := build(tt.fipID, "")
+			stateRaw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+			if tt.serverID != "" {
+				stateRaw = build(tt.fipID, tt.serverID)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}}
+			r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: stateRaw},
+			}, resp)
+
+			if !tt.expectError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected the associated floating IP to be rejected")
+			}
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.Contains(detail, "already associated with db ("+otherID+")") {
+				t.Errorf("expected the error to name the other server, got: %s", detail)
+			}
+		})
+	}
+}
+
+func TestServerModifyPlan_UserDataFile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		flavorID = "77777777-7777-7777-7777-777777777777"
+		imageID  = "88888888-8888-8888-8888-888888888888"
+	)
+	original := "#cloud-config\npackages:\n  - nginx\n"
+
+	r := NewServerResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// build returns the raw server config for userDataFile, or the state of
+	// a server created from it when revision is set.
+	build := func(userDataFile string, revision types.String) tftypes.Value {
+		values := map[string]interface{}{
+			"name":           "web",
+			"flavor_id":      flavorID,
+			"image_id":       imageID,
+			"user_data_file": userDataFile,
+		}
+		if !revision.IsNull() {
+			values["id"] = "11111111-1111-1111-1111-111111111111"
+			values["revision"] = revision
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for attr, value := range values {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build config: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+	created := helper.ServerRevision(types.StringValue(imageID), types.StringValue(flavorID), types.StringNull(), types.StringValue(original))
+
+	tests := []struct {
+		name        string
+		content     string
+		update      bool
+		expectError bool
+	}{
+		{name: "create", content: original},
+		{name: "update with the same contents", content: original, update: true},
+		{name: "update with changed contents", content: original + "  - curl\n", update: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), "cloud-init.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write user data file: %v", err)
+			}
+
+			raw := build(file, types.StringNull())
+			stateRaw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+			if tt.update {
+				stateRaw = build(file, created)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}}
+			r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: stateRaw},
+			}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected the changed file contents to be rejected")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var revision types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("revision"), &revision)...)
+			if !revision.Equal(created) {
+				t.Errorf("expected the revision to hash the file contents as %s, got %s", created, revision)
+			}
+		})
+	}
+}
@@ -5,30 +5,34 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net"
 	"strings"
 	"time"
 
-	"sort"
-
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
 	servermodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	serversdk "github.com/Zillaforge/cloud-sdk/modules/vps/servers"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/modifiers"
@@ -38,12 +42,22 @@ import (
 // ServerResource defines the server resource implementation.
 type ServerResource struct {
 	client *cloudsdk.ProjectClient
+	api    *helper.APIClient
+
+	// precheckQuota and precheckNameUnique are the provider's precheck_quota
+	// and precheck_name_unique options.
+	precheckQuota      bool
+	precheckNameUnique bool
+
+	// emitOperationEvents is the provider's emit_operation_events option.
+	emitOperationEvents bool
 }
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
 	_ resource.Resource                = &ServerResource{}
 	_ resource.ResourceWithImportState = &ServerResource{}
+	_ resource.ResourceWithModifyPlan  = &ServerResource{}
 )
 
 // NewServerResource creates a new instance of the server resource.
@@ -61,9 +75,15 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 
 		Blocks: map[string]schema.Block{
 			"network_attachment": schema.ListNestedBlock{
-				MarkdownDescription: "Network interfaces to attach to the server. Each block defines a network connection. At least one network attachment is required, and at most one can be marked as `primary=true`.",
+				MarkdownDescription: "Network interfaces to attach to the server. Each block defines a network connection. At least one network attachment is required, and at most one can be marked as `primary=true`. " +
+					"When no attachment lists a security group in `security_group_ids` or `security_group_names` and neither `keypair` nor `password` is set, planning a new server warns that it may be unreachable; the warning does not block the apply.",
+				PlanModifiers: []planmodifier.List{
+					modifiers.DefaultPrimaryNetwork(),
+				},
 				Validators: []validator.List{
 					validators.NetworkAttachmentPrimaryConstraint(),
+					validators.NetworkAttachmentFixedIPStrategy(),
+					validators.NetworkAttachmentDuplicateIP(),
 					listvalidator.SizeAtLeast(1),
 				},
 				NestedObject: schema.NestedBlockObject{
@@ -76,7 +96,7 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							},
 						},
 						"ip_address": schema.StringAttribute{
-							MarkdownDescription: "Optional fixed IPv4 address to assign to this network interface. If not specified, an IP address will be automatically assigned via DHCP. Must be a valid IPv4 address within the network's CIDR range.",
+							MarkdownDescription: "Private IPv4 address of this network interface on the attached network; the public address is `public_ip`. Set it to assign a fixed address; if not specified, an IP address will be automatically assigned via DHCP. Must be a valid IPv4 address within the network's CIDR range, and not its gateway, network or broadcast address; this is checked at plan time when the network can be read. Requesting the same address on more than one `network_attachment` block is reported as a warning, since it is usually a mistake but can be legitimate on isolated networks with overlapping ranges. Changing the address of an existing interface detaches and reattaches that NIC on the same network, so the interface briefly loses connectivity and its floating IP is re-associated. If the new address cannot be attached, the NIC is re-added with its previous address and security groups and the apply fails. Moving the interface to another `network_id` always creates a new NIC; the address is only kept when it is set here and lies in the new network's CIDR, since each network has a single subnet.",
 							Optional:            true,
 							Computed:            true,
 							PlanModifiers: []planmodifier.String{
@@ -86,7 +106,7 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							},
 						},
 						"primary": schema.BoolAttribute{
-							MarkdownDescription: "Whether this is the primary network interface for the server. At most one network attachment can have `primary=true`. The primary interface is used for default routing.",
+							MarkdownDescription: "Whether this is the primary network interface for the server. At most one network attachment can have `primary=true`. The primary interface is used for default routing. When no attachment sets `primary = true`, the one with the lowest `network_id` is planned as primary and the others as `false`, which is also what is reported after import, since the API does not report a primary interface.",
 							Optional:            true,
 							Computed:            true,
 							PlanModifiers: []planmodifier.Bool{
@@ -94,12 +114,20 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							},
 						},
 						"security_group_ids": schema.ListAttribute{
-							MarkdownDescription: "List of security group IDs to apply to this network interface. Use the `zillaforge_security_groups` data source to list available security groups.",
+							MarkdownDescription: "List of security group IDs to apply to this network interface. Use the `zillaforge_security_groups` data source to list available security groups. The IDs are kept in state in the order written, so reordering them updates the interface. Groups attached outside Terraform are appended in sorted order, and the groups of an imported interface are sorted.",
 							Optional:            true,
 							ElementType:         types.StringType,
 						},
+						"security_group_names": schema.ListAttribute{
+							MarkdownDescription: "Names of security groups to apply to this network interface, in addition to `security_group_ids`. Each name is resolved to an ID at apply time and must match exactly one security group in the project; an unknown or ambiguous name is an error. The resolved IDs are not added to `security_group_ids` in state. If a named group is detached outside Terraform, it is dropped from this list on refresh and the next apply attaches it again. A name that is only in state, e.g. of a group deleted or renamed since the last apply, is not resolved strictly, so replacing it in configuration does not fail the apply.",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
 						"floating_ip_id": schema.StringAttribute{
-							MarkdownDescription: "UUID of the floating IP to associate with this network interface. When specified, the floating IP will be associated with this network attachment. Remove this attribute or set to null to disassociate the floating IP. Note: The floating IP must exist and not be associated with another server.",
+							MarkdownDescription: "UUID of the floating IP to associate with this network interface. When specified, the floating IP will be associated with this network attachment. Remove this attribute or set to null to disassociate the floating IP. Moving the same `floating_ip_id` to another `network_attachment` block of the server moves the address to that NIC in place: it is released from the old NIC before it is bound to the new one. The apply waits until the floating IP reports the server as its device, within the `create` or `update` timeout, so `floating_ip` is set without a separate refresh and resources referencing it need no `depends_on`. Note: The floating IP must exist and not be associated with another server; this is checked at plan time when the ID is known, and again just before the association.",
 							Optional:            true,
 							Validators: []validator.String{
 								validators.UUIDValidator(),
@@ -112,6 +140,56 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 								modifiers.FloatingIPPreserveState(),
 							},
 						},
+						"public_ip": schema.StringAttribute{
+							MarkdownDescription: "The public IPv4 address of this network interface, reachable from outside the network. Always equal to `floating_ip`, and null when no floating IP is associated. Use `ip_address` for the private address on the attached network.",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								modifiers.FloatingIPPreserveState(),
+							},
+						},
+						"dns_nameservers": schema.ListAttribute{
+							MarkdownDescription: "DNS servers for this network interface, overriding those of the subnet, e.g. `[\"1.1.1.1\", \"8.8.8.8\"]`. **Note:** the network interface API does not support per-interface DNS servers yet, so they are recorded in state only and setting them produces a warning.",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(validators.IPAddress()),
+							},
+						},
+						"host_routes": schema.ListNestedAttribute{
+							MarkdownDescription: "Static routes for this network interface, overriding those of the subnet. **Note:** the network interface API does not support per-interface routes yet, so they are recorded in state only and setting them produces a warning.",
+							Optional:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"destination": schema.StringAttribute{
+										MarkdownDescription: "Destination network of the route in CIDR notation, e.g. `192.168.50.0/24`.",
+										Required:            true,
+										Validators: []validator.String{
+											validators.CIDR(),
+										},
+									},
+									"nexthop": schema.StringAttribute{
+										MarkdownDescription: "IP address of the gateway for the route.",
+										Required:            true,
+										Validators: []validator.String{
+											validators.IPAddress(),
+										},
+									},
+								},
+							},
+						},
+						"fixed_ip_strategy": schema.StringAttribute{
+							MarkdownDescription: "How the private address of a new network interface is chosen. With `auto` (default), the platform allocates it, or `ip_address` is used when set; if the platform rejects the allocation while an interface is added on update, a few addresses at fixed offsets into the allocation pool are tried instead. " +
+								"With `first_free`, the provider requests the lowest address of the subnet's allocation pool that no port on the network uses; `ip_address` must not be set. With `specific`, `ip_address` is required and requested as is, without any fallback. " +
+								"Only used when the interface is created; changing it on an existing interface does not move its address.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(helper.FixedIPStrategyAuto, helper.FixedIPStrategyFirstFree, helper.FixedIPStrategySpecific),
+							},
+						},
 					},
 				},
 			},
@@ -119,31 +197,40 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Configurable timeouts for create, update, and delete operations.",
 				Attributes: map[string]schema.Attribute{
 					"create": schema.StringAttribute{
-						MarkdownDescription: "Maximum time to wait for server creation to complete. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`).",
+						MarkdownDescription: "Maximum time to wait for server creation to complete. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`); invalid, zero and negative durations are rejected at plan time.",
 						Optional:            true,
 						Computed:            true,
 						Default:             stringdefault.StaticString("10m"),
 						PlanModifiers: []planmodifier.String{
 							modifiers.IgnoreChangeAttributePlanModifierString("timeouts.create"),
 						},
+						Validators: []validator.String{
+							validators.PositiveDuration(),
+						},
 					},
 					"update": schema.StringAttribute{
-						MarkdownDescription: "Maximum time to wait for server update to complete. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`).",
+						MarkdownDescription: "Maximum time to wait for server update to complete. Only applies when the update changes network attachments or reboots the server; name and description changes return without waiting for `active`. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`); invalid, zero and negative durations are rejected at plan time.",
 						Optional:            true,
 						Computed:            true,
 						Default:             stringdefault.StaticString("10m"),
 						PlanModifiers: []planmodifier.String{
 							modifiers.IgnoreChangeAttributePlanModifierString("timeouts.update"),
 						},
+						Validators: []validator.String{
+							validators.PositiveDuration(),
+						},
 					},
 					"delete": schema.StringAttribute{
-						MarkdownDescription: "Maximum time to wait for server deletion to complete. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`).",
+						MarkdownDescription: "Maximum time to wait for server deletion to complete. Default is `10m` (10 minutes). Use Go duration syntax (e.g., `15m`, `1h`); invalid, zero and negative durations are rejected at plan time.",
 						Optional:            true,
 						Computed:            true,
 						Default:             stringdefault.StaticString("10m"),
 						PlanModifiers: []planmodifier.String{
 							modifiers.IgnoreChangeAttributePlanModifierString("timeouts.delete"),
 						},
+						Validators: []validator.String{
+							validators.PositiveDuration(),
+						},
 					},
 				},
 			},
@@ -158,8 +245,11 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the server instance. Must be unique within the project and between 1-255 characters.",
+				MarkdownDescription: "The name of the server instance. Must be unique within the project and between 1-255 characters. The name also identifies the server when a create request times out or fails at a gateway after the API may have applied it: the provider then looks for a new server with this name and manages it, with a warning, instead of failing and leaving it to be created again.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
 			},
 			"flavor_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the flavor (instance type) to use for this server. Defines the virtual CPU count, memory, and root disk size. **Changing this attribute is not supported and will be rejected at plan time.** Use the `zillaforge_flavors` data source to list available flavors.",
@@ -171,6 +261,18 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					validators.FlavorIDValidator(),
 				},
 			},
+			"root_disk_gb": schema.Int64Attribute{
+				MarkdownDescription: "Size of the server's root volume in GiB. Increasing this value expands the root volume in place and waits for the server to return to `active`; **decreasing it is not supported and will be rejected at plan time.** Only allowed with flavors that do not fix the root disk size (flavor `disk` of `0`). When set at creation, `wait_for_active` must be `true` so the volume can be expanded once the server is running. When omitted, the size reported by the API is stored.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					modifiers.GrowOnlyPlanModifier("root_disk_gb"),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"image_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the image to use for the server's operating system. **Changing this attribute is not supported and will be rejected at plan time.** Use the `zillaforge_images` data source to list available images.",
 				Required:            true,
@@ -184,9 +286,12 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"description": schema.StringAttribute{
 				MarkdownDescription: "A human-readable description of the server. Maximum 1000 characters.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(1000),
+				},
 			},
 			"keypair": schema.StringAttribute{
-				MarkdownDescription: "The name of the SSH keypair to inject into the server for authentication. **Changing this attribute is not supported and will be rejected at plan time.** Use the `zillaforge_keypairs` data source to list available keypairs or create a new one with the `zillaforge_keypair` resource.",
+				MarkdownDescription: "The name of the SSH keypair to inject into the server for authentication. **Changing this attribute is not supported and will be rejected at plan time.** Use the `zillaforge_keypairs` data source to list available keypairs or create a new one with the `zillaforge_keypair` resource. The platform injects a single keypair per server; to authorize more keys, list them under `ssh_authorized_keys` in `user_data`.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					modifiers.ImmutableAttributePlanModifier("keypair"),
@@ -201,36 +306,112 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"user_data": schema.StringAttribute{
-				MarkdownDescription: "Cloud-init user data for configuring the server on first boot. Must be base64-encoded (use Terraform's `base64encode()` function). Maximum size 64KB. **Changing this attribute is not supported and will be rejected at plan time.** The user data is not returned by the API for security reasons, so it will not appear in state after import.",
+				MarkdownDescription: "Cloud-init user data for configuring the server on first boot. Must be base64-encoded (use Terraform's `base64encode()` function). Maximum size 64KB after the provider base64-encodes it for the API, checked at plan time. **Changing this attribute is not supported and will be rejected at plan time.** The user data is not returned by the API for security reasons, so it will not appear in state after import.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					validators.UserDataSize(validators.MaxUserDataBytes),
+				},
 				PlanModifiers: []planmodifier.String{
 					modifiers.ImmutableAttributePlanModifier("user_data"),
 				},
 			},
+			"user_data_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a local file holding the cloud-init user data, as an alternative to inlining it in `user_data`. Conflicts with `user_data`. The file is read and base64-encoded by the provider; it must exist, be readable and be at most 64KB once encoded, all checked at plan time. **Changing this attribute, or the contents of the file, is not supported and will be rejected at plan time.** Only the path is stored in state; a SHA-256 of the contents feeds `revision`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("user_data")),
+					validators.UserDataFile(validators.MaxUserDataBytes),
+				},
+				PlanModifiers: []planmodifier.String{
+					modifiers.ImmutableAttributePlanModifier("user_data_file"),
+				},
+			},
 			"wait_for_active": schema.BoolAttribute{
-				MarkdownDescription: "Whether to wait for the server to reach `active` status after creation. **This value is used only during create/apply and is not stored in state; changing it does not trigger resource updates.** When set to `true` (default), Terraform will poll the server status until it reaches `active` state or the timeout is exceeded. When set to `false`, Terraform will return immediately after the API responds, without waiting for the server to become active. Default is `true`.",
+				MarkdownDescription: "Whether to wait for the server to reach `active` status after creation. **This value is used only during create/apply and is not stored in state; changing it does not trigger resource updates.** When set to `true` (default), Terraform will poll the server status until it reaches `active` state or the timeout is exceeded. When set to `false`, Terraform will return immediately after the API responds, without waiting for the server to become active. Ignored when `wait_until_status` is set. Default is `true`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 				PlanModifiers: []planmodifier.Bool{
 					modifiers.IgnoreChangeAttributePlanModifierBool("wait_for_active"),
 				},
-			}, "wait_for_deleted": schema.BoolAttribute{
-				MarkdownDescription: "Whether to wait for the server to be fully deleted. **This value is used only during delete/apply and is not stored in state; changing it does not trigger resource updates.** When set to `true` (default), Terraform will poll the server status until it is fully deleted or the timeout is exceeded. When set to `false`, Terraform will return immediately after the delete API call, without waiting for the server deletion to complete. Default is `true`.",
+			},
+			"wait_until_status": schema.StringAttribute{
+				MarkdownDescription: "Status to wait for after creation, overriding `wait_for_active` when set. Terraform polls the server until it reaches this status or `active`, whichever comes first, so workflows can return as soon as the server is usable, e.g. `shutoff` for an image that powers the server off once provisioned. " +
+					"Valid values: `building`, `active`, `reboot`, `shutoff`, `suspended`. The wait fails if the server enters `error` or the `create` timeout expires first. " +
+					"Floating IP association and `root_disk_gb` need a running server, so they are applied only when the wait ends at `active`; `root_disk_gb` cannot be set at creation with a status other than `active`. " +
+					"**This value is used only during create; changing it later only updates the stored value.**",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(helper.ServerWaitStatuses...),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, destroying the server, including a destroy-and-recreate triggered by a replacing change, fails with an error and no API call is made. " +
+					"Set it to `false` and apply before destroying the server. The protection is enforced by the provider only; the server can still be deleted outside Terraform. Default is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"windows_password_private_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key of `keypair`, e.g. `file(\"~/.ssh/id_rsa\")`, used to retrieve `windows_password`. Requires `keypair`. " +
+					"The key is sent to the API only to decrypt the password, is stored in state and is never logged. Changing this value needs no API call.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("keypair")),
+				},
+			},
+			"windows_password": schema.StringAttribute{
+				MarkdownDescription: "Administrator password generated by a Windows image on first boot, decrypted with `windows_password_private_key`. " +
+					"Null for other images, without a private key, and until the password is available, which can take several minutes after the server becomes `active`; a later refresh picks it up. " +
+					"Once retrieved, the password is kept in state.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"floating_ip_association": schema.StringAttribute{
+				MarkdownDescription: "How failures to associate a `floating_ip_id` are handled. **This value is used only during create/apply and is not stored in state; changing it does not trigger resource updates.** " +
+					"With `strict` (default), any failed association fails the apply. With `best_effort`, each failure is a warning followed by a summary, the other floating IPs stay associated, and the failed ones show as changes on the next plan so that applying again retries only those.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(helper.FloatingIPAssociationStrict),
+				Validators: []validator.String{
+					stringvalidator.OneOf(helper.FloatingIPAssociationStrict, helper.FloatingIPAssociationBestEffort),
+				},
+				PlanModifiers: []planmodifier.String{
+					modifiers.IgnoreChangeAttributePlanModifierString("floating_ip_association"),
+				},
+			},
+			"wait_for_deleted": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the server to be fully deleted. **This value is used only during delete/apply and is not stored in state; changing it does not trigger resource updates.** When set to `true` (default), Terraform will poll the server status until it is fully deleted (no longer found, or reporting a `DELETED` or `SOFT_DELETED` status on platforms that keep deleted servers in a recycle bin) or the timeout is exceeded. When set to `false`, Terraform will return immediately after the delete API call, without waiting for the server deletion to complete. Default is `true`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 				PlanModifiers: []planmodifier.Bool{
 					modifiers.IgnoreChangeAttributePlanModifierBool("wait_for_deleted"),
 				},
+			},
+			"reboot_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value whose change reboots the server in place. When the value changes to a new non-null value, Terraform issues a soft reboot (falling back to a hard reboot if the soft one is rejected) and waits for the server to return to `active`. Changing it never forces replacement, and removing it does not reboot. Use a hash of the configuration that requires the reboot, e.g. `sha1(local.app_config)`, or `timestamp()` to reboot on every apply.",
+				Optional:            true,
 			}, "status": schema.StringAttribute{
-				MarkdownDescription: "The current status of the server. Possible values: `building` (instance is being created), `active` (instance is running and ready), `error` (instance entered an error state), `deleted` (instance has been deleted).",
+				MarkdownDescription: "The current status of the server, always in lowercase regardless of the casing used by the API. Possible values: `building` (instance is being created), `active` (instance is running and ready), `reboot` (instance is rebooting), `shutoff` (instance is stopped), `suspended` (instance is suspended), `error` (instance entered an error state), `deleted` (instance has been deleted).",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"nic_ids": schema.MapAttribute{
+				MarkdownDescription: "ID of the server's network interface (NIC) on each attached network, keyed by `network_id`. Use it to reference a NIC from other resources, e.g. `zillaforge_server.x.nic_ids[\"<network_id>\"]`. Known after apply when network interfaces are added, removed or reattached with a new `ip_address`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					modifiers.NICIDsUnknownOnNetworkChange(),
+				},
+			},
 			"ip_addresses": schema.ListAttribute{
 				MarkdownDescription: "List of IP addresses assigned to the server. The order corresponds to the order of `network_attachment` blocks. Includes both DHCP-assigned and fixed IP addresses.",
 				Computed:            true,
@@ -240,6 +421,22 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					modifiers.IPAddressesUnknownOnNetworkChange(),
 				},
 			},
+			"ipv4_addresses": schema.ListAttribute{
+				MarkdownDescription: "IPv4 addresses from `ip_addresses`, sorted numerically. Use `ipv4_addresses[0]` to pick an IPv4 address deterministically.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					modifiers.IPAddressesUnknownOnNetworkChange(),
+				},
+			},
+			"ipv6_addresses": schema.ListAttribute{
+				MarkdownDescription: "IPv6 addresses from `ip_addresses`, sorted numerically. Empty when the server has no IPv6 address.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					modifiers.IPAddressesUnknownOnNetworkChange(),
+				},
+			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when the server was created, in RFC3339 format (e.g., `2023-10-15T14:30:00Z`).",
 				Computed:            true,
@@ -247,6 +444,10 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "Hash of the inputs that cannot change without recreating the server: `image_id`, `flavor_id`, `keypair` and `user_data` or the contents of `user_data_file` (of which only a SHA-256 is used). It is known at plan time when those inputs are, stays the same across refreshes, and only changes when one of them does, so other resources can list it in `lifecycle { replace_triggered_by = [...] }` to be replaced together with the server's configuration. After import it is computed without `user_data`, which the API does not return, so the first plan may update it in place.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -256,19 +457,484 @@ func (r *ServerResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	providerData, ok := req.ProviderData.(*helper.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			"Expected *cloudsdk.ProjectClient, got something else",
+			fmt.Sprintf("Expected *helper.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.api = providerData.API
+	r.precheckQuota = providerData.PrecheckQuota
+	r.precheckNameUnique = providerData.PrecheckNameUnique
+	r.emitOperationEvents = providerData.EmitOperationEvents
+}
+
+// ModifyPlan runs the checks that need the API and therefore cannot be
+// expressed as schema validators.
+func (r *ServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config resourcemodels.ServerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, err := helper.ServerUserData(config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data_file"), "User Data File Not Readable", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision"), helper.ServerRevision(config.ImageID, config.FlavorID, config.Keypair, userData))...)
+	if !req.State.Raw.IsNull() {
+		r.checkUserDataFileUnchanged(ctx, config, userData, req.State, resp)
+	}
+
+	r.validateRootDiskGB(ctx, config, req.State.Raw.IsNull(), resp)
+	r.validateFixedIPs(ctx, config, resp)
+
+	var serverID types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &serverID)...)
+	}
+	r.validateFloatingIPsAvailable(ctx, config, serverID.ValueString(), resp)
+	if req.State.Raw.IsNull() {
+		r.checkConfigDrive(ctx, config, resp)
+		r.checkLockout(ctx, config, resp)
+	}
+
+	if r.precheckNameUnique {
+		var state resourcemodels.ServerResourceModel
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		r.validateNameUnique(ctx, config, state, resp)
+	}
+}
+
+// checkUserDataFileUnchanged rejects a plan in which the file named by
+// user_data_file now holds different contents than when the server was
+// created; like user_data, they cannot change in place. The stored revision
+// is the only record of the original contents.
+func (r *ServerResource) checkUserDataFileUnchanged(ctx context.Context, config resourcemodels.ServerResourceModel, userData types.String, state tfsdk.State, resp *resource.ModifyPlanResponse) {
+	if config.UserDataFile.IsNull() || userData.IsUnknown() {
+		return
+	}
+
+	var prior resourcemodels.ServerResourceModel
+	resp.Diagnostics.Append(state.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() || !prior.UserDataFile.Equal(config.UserDataFile) || prior.Revision.IsNull() {
+		return
+	}
+
+	if !helper.ServerRevision(prior.ImageID, prior.FlavorID, prior.Keypair, userData).Equal(prior.Revision) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_data_file"),
+			"Unsupported Change: user_data_file",
+			fmt.Sprintf("The contents of %s changed since the server was created. Changing user data is not supported in-place and is rejected by the provider. To change it, you must recreate the resource manually or use the ZillaForge platform directly.", config.UserDataFile.ValueString()),
+		)
+	}
+}
+
+// checkConfigDrive warns when user_data is set for an image that only reads
+// cloud-init data from a config drive, which the server API cannot attach.
+func (r *ServerResource) checkConfigDrive(ctx context.Context, config resourcemodels.ServerResourceModel, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || (config.UserData.IsNull() && config.UserDataFile.IsNull()) || config.ImageID.IsUnknown() {
+		return
+	}
+
+	imageID := config.ImageID.ValueString()
+	tag, err := r.client.VRM().Tags().Get(ctx, imageID)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to look up image to check config drive requirement", map[string]interface{}{
+			"image_id": imageID,
+			"error":    err.Error(),
+		})
+		return
+	}
+
+	if helper.ImageRequiresConfigDrive(tag) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("image_id"),
+			"Image Requires Config Drive",
+			fmt.Sprintf("Image %s declares img_config_drive = mandatory, but the server API cannot attach a config drive yet. cloud-init on this image may never read user_data from the metadata service; choose another image if the server depends on user_data.", imageID),
+		)
+	}
+}
+
+// checkLockout warns at create time when the server would have neither a
+// security group nor credentials; see helper.LockoutWarning.
+func (r *ServerResource) checkLockout(ctx context.Context, config resourcemodels.ServerResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.NetworkAttachment.IsUnknown() {
+		return
+	}
+
+	var attachments []resourcemodels.NetworkAttachmentModel
+	if d := config.NetworkAttachment.ElementsAs(ctx, &attachments, false); d.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(helper.LockoutWarning(attachments, config.Keypair, config.Password)...)
+}
+
+// adoptCreatedServer looks for the server made by a create request that
+// failed with createErr but may have been applied, and returns it, or nil
+// when the API shows no new server named name. Adopting it keeps a retried
+// apply from creating a duplicate.
+func (r *ServerResource) adoptCreatedServer(ctx context.Context, name string, existingIDs map[string]bool, createErr error, diags *diag.Diagnostics) *serversdk.ServerResource {
+	tflog.Warn(ctx, "Server create response lost, checking whether the server was created", map[string]interface{}{
+		"name":  name,
+		"error": createErr.Error(),
+	})
+
+	serverRes, err := helper.FindCreatedServer(ctx, r.client.VPS().Servers(), name, existingIDs)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to check whether the server was created", map[string]interface{}{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if serverRes == nil {
+		return nil
+	}
+
+	diags.AddWarning(
+		"Server Create Response Lost",
+		fmt.Sprintf("The create request for server %q failed (%s), but the API shows it was created as %s. This resource now manages that server instead of creating another one.", name, createErr, serverRes.Server.ID),
+	)
+	return serverRes
+}
+
+// validateNameUnique rejects a name that another server in the project
+// already uses. It only runs when the server is created or renamed.
+func (r *ServerResource) validateNameUnique(ctx context.Context, config, state resourcemodels.ServerResourceModel, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || config.Name.IsUnknown() || config.Name.Equal(state.Name) {
+		return
+	}
+
+	name := config.Name.ValueString()
+	conflictID, err := helper.FindServerByName(ctx, r.client.VPS().Servers(), name, state.ID.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list servers to check name uniqueness", map[string]interface{}{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if conflictID != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Server Name Already In Use",
+			fmt.Sprintf("Server %s in this project is already named %q. Server names must be unique within a project; choose another name, or import the existing server with: terraform import <address> %s", conflictID, name, conflictID),
+		)
+	}
+}
+
+// validateRootDiskGB rejects root_disk_gb for flavors that fix the root disk
+// size, and at create time when the server would not be waited on until
+// active to expand its root volume.
+func (r *ServerResource) validateRootDiskGB(ctx context.Context, config resourcemodels.ServerResourceModel, creating bool, resp *resource.ModifyPlanResponse) {
+	if config.RootDiskGB.IsNull() || config.RootDiskGB.IsUnknown() || config.FlavorID.IsUnknown() {
+		return
+	}
+
+	if creating && config.WaitUntilStatus.IsNull() && !config.WaitForActive.IsNull() && !config.WaitForActive.IsUnknown() && !config.WaitForActive.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root_disk_gb"),
+			"Root Disk Requires Wait For Active",
+			"root_disk_gb is applied by expanding the root volume once the server is active, so it cannot be set at creation when wait_for_active is false.",
+		)
+		return
+	}
+	if creating && !config.WaitUntilStatus.IsNull() && !config.WaitUntilStatus.IsUnknown() && config.WaitUntilStatus.ValueString() != "active" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root_disk_gb"),
+			"Root Disk Requires Wait For Active",
+			fmt.Sprintf("root_disk_gb is applied by expanding the root volume once the server is active, so it cannot be set at creation when wait_until_status is %q.", config.WaitUntilStatus.ValueString()),
+		)
+		return
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	flavor, err := helper.GetFlavorByID(ctx, r.client, config.FlavorID.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "Unable to look up flavor to validate root_disk_gb", map[string]interface{}{
+			"flavor_id": config.FlavorID.ValueString(),
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	if flavor.Disk > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root_disk_gb"),
+			"Root Disk Size Fixed By Flavor",
+			fmt.Sprintf("Flavor %q (%s) fixes the root disk at %d GB, so root_disk_gb cannot be set. Remove root_disk_gb or choose a flavor with a disk size of 0.",
+				flavor.Name, flavor.ID, flavor.Disk),
+		)
+	}
+}
+
+// validateFixedIPs checks each fixed network_attachment.ip_address against
+// its network's subnet, so an out-of-range address fails at plan time rather
+// than with a network service error during apply. Attachments whose values
+// are not yet known, or whose network cannot be read, are skipped.
+func (r *ServerResource) validateFixedIPs(ctx context.Context, config resourcemodels.ServerResourceModel, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || config.NetworkAttachment.IsNull() || config.NetworkAttachment.IsUnknown() {
+		return
+	}
+
+	var attachments []resourcemodels.NetworkAttachmentModel
+	if d := config.NetworkAttachment.ElementsAs(ctx, &attachments, false); d.HasError() {
+		return
+	}
+
+	vpsClient := r.client.VPS()
+	for i, attachment := range attachments {
+		if attachment.IPAddress.IsNull() || attachment.IPAddress.IsUnknown() || attachment.IPAddress.ValueString() == "" {
+			continue
+		}
+		if attachment.NetworkID.IsNull() || attachment.NetworkID.IsUnknown() {
+			continue
+		}
+
+		networkID := attachment.NetworkID.ValueString()
+		netRes, err := vpsClient.Networks().Get(ctx, networkID)
+		if err != nil || netRes == nil || netRes.Network.CIDR == "" {
+			tflog.Warn(ctx, "Unable to read network to validate fixed IP address", map[string]interface{}{
+				"network_id": networkID,
+				"error":      fmt.Sprint(err),
+			})
+			continue
+		}
+
+		if err := helper.CheckFixedIPInSubnet(attachment.IPAddress.ValueString(), netRes.Network.CIDR, netRes.Network.Gateway); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("network_attachment").AtListIndex(i).AtName("ip_address"),
+				"Invalid Fixed IP Address",
+				fmt.Sprintf("ip_address cannot be used on network %s: %s. Use the zillaforge_subnets data source to find the allocation pools of the network.", networkID, err),
+			)
+		}
+	}
+}
+
+// validateFloatingIPsAvailable rejects a floating_ip_id that is associated
+// with another server, which would otherwise fail mid-apply, possibly after
+// the server was created. Unknown IDs and unreadable floating IPs are skipped.
+func (r *ServerResource) validateFloatingIPsAvailable(ctx context.Context, config resourcemodels.ServerResourceModel, serverID string, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || config.NetworkAttachment.IsNull() || config.NetworkAttachment.IsUnknown() {
+		return
+	}
+
+	var attachments []resourcemodels.NetworkAttachmentModel
+	if d := config.NetworkAttachment.ElementsAs(ctx, &attachments, false); d.HasError() {
+		return
+	}
+
+	floatingIPs := r.client.VPS().FloatingIPs()
+	for i, attachment := range attachments {
+		if attachment.FloatingIPID.IsNull() || attachment.FloatingIPID.IsUnknown() {
+			continue
+		}
+
+		floatingIPID := attachment.FloatingIPID.ValueString()
+		fip, err := floatingIPs.Get(ctx, floatingIPID)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to read floating IP to check its association", map[string]interface{}{
+				"floating_ip_id": floatingIPID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+
+		if err := helper.CheckFloatingIPAvailable(fip, serverID); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("network_attachment").AtListIndex(i).AtName("floating_ip_id"),
+				"Floating IP Already Associated",
+				fmt.Sprintf("%s.", err),
+			)
+		}
+	}
+}
+
+// checkQuota fails early when the project quota cannot fit a server of the
+// given flavor. It is best-effort: when the quota or the flavor cannot be
+// read, creation proceeds and the API has the final say.
+func (r *ServerResource) checkQuota(ctx context.Context, flavorID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	quota, err := r.api.Quota(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Skipping quota pre-check: quota unavailable", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	flavor, err := helper.GetFlavorByID(ctx, r.client, flavorID)
+	if err != nil {
+		tflog.Warn(ctx, "Skipping quota pre-check: flavor unavailable", map[string]interface{}{
+			"flavor_id": flavorID,
+			"error":     err.Error(),
+		})
+		return diags
+	}
+
+	if shortfalls := helper.ServerQuotaShortfalls(quota, flavor); len(shortfalls) > 0 {
+		diags.AddAttributeError(
+			path.Root("flavor_id"),
+			"Insufficient Project Quota",
+			fmt.Sprintf("Creating a server with flavor %s would exceed the project quota:\n- %s\n\nFree up resources, choose a smaller flavor or request a quota increase.",
+				flavor.Name, strings.Join(shortfalls, "\n- ")),
+		)
+	}
+	return diags
+}
+
+// resolveSecurityGroupNames resolves the security_group_names used by the
+// network_attachment list strict, failing on any name that does not resolve
+// to exactly one group. Names used only in lenient, such as those in prior
+// state, are resolved when possible: their group may have been deleted or
+// renamed since, so a name that no longer resolves is left out and its group
+// counts as already detached. It returns an empty map without calling the API
+// when no attachment uses names.
+func (r *ServerResource) resolveSecurityGroupNames(ctx context.Context, strict types.List, lenient ...types.List) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attachmentNames := func(list types.List) []string {
+		if list.IsNull() || list.IsUnknown() {
+			return nil
+		}
+		var attachments []resourcemodels.NetworkAttachmentModel
+		diags.Append(list.ElementsAs(ctx, &attachments, false)...)
+		names, d := helper.SecurityGroupNamesFromAttachments(ctx, attachments)
+		diags.Append(d...)
+		return names
+	}
+
+	names := attachmentNames(strict)
+	strictNames := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		strictNames[name] = struct{}{}
+	}
+	for _, list := range lenient {
+		for _, name := range attachmentNames(list) {
+			if _, ok := strictNames[name]; !ok {
+				names = append(names, name)
+			}
+		}
+	}
+	if diags.HasError() || len(names) == 0 {
+		return map[string]string{}, diags
+	}
+
+	nameIDs, err := helper.ResolveSecurityGroupNames(ctx, r.client, names)
+	var nameErr *helper.SecurityGroupNameError
+	if errors.As(err, &nameErr) {
+		for name, problem := range nameErr.Problems {
+			if _, ok := strictNames[name]; !ok {
+				tflog.Debug(ctx, "Treating unresolvable security group name from prior state as detached", map[string]interface{}{
+					"name":    name,
+					"problem": problem,
+				})
+				delete(nameErr.Problems, name)
+			}
+		}
+		if len(nameErr.Problems) == 0 {
+			err = nil
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Invalid Security Group Names",
+			fmt.Sprintf("Unable to resolve security_group_names to security group IDs: %s", err.Error()),
 		)
+	}
+	return nameIDs, diags
+}
+
+// readWindowsPassword sets windows_password when windows_password_private_key
+// is set and the administrator password of a Windows server can be retrieved.
+// A password already in state is kept, since it is only generated once. The
+// password is never logged and a failed lookup only leaves it null.
+func (r *ServerResource) readWindowsPassword(ctx context.Context, state *resourcemodels.ServerResourceModel) {
+	if !state.WindowsPassword.IsNull() && !state.WindowsPassword.IsUnknown() {
+		return
+	}
+	state.WindowsPassword = types.StringNull()
+	if r.api == nil || state.WindowsPasswordPrivateKey.ValueString() == "" || state.Keypair.ValueString() == "" {
+		return
+	}
+	if state.Status.ValueString() != "active" {
 		return
 	}
 
-	r.client = client
+	tag, err := r.client.VRM().Tags().Get(ctx, state.ImageID.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Unable to look up image for windows_password", map[string]interface{}{
+			"image_id": state.ImageID.ValueString(),
+			"error":    err.Error(),
+		})
+		return
+	}
+	if !helper.ImageIsWindows(tag) {
+		return
+	}
+
+	password, err := r.api.ServerPassword(ctx, state.ID.ValueString(), state.WindowsPasswordPrivateKey.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Windows password not retrieved", map[string]interface{}{
+			"id":    state.ID.ValueString(),
+			"error": err.Error(),
+		})
+		return
+	}
+	if password != "" {
+		state.WindowsPassword = types.StringValue(password)
+	}
+}
+
+// saveCreatedServer writes the ID of a just-created server to state, together
+// with the configuration Delete relies on. If a later step of Create fails,
+// Terraform keeps this partial state and marks the server tainted, so the
+// next apply replaces it rather than creating a second server next to an
+// untracked one.
+func saveCreatedServer(ctx context.Context, state *tfsdk.State, plan resourcemodels.ServerResourceModel, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, a := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"id", types.StringValue(id)},
+		{"name", plan.Name},
+		{"flavor_id", plan.FlavorID},
+		{"image_id", plan.ImageID},
+		{"wait_for_deleted", plan.WaitForDeleted},
+		{"deletion_protection", plan.DeletionProtection},
+		{"timeouts", plan.Timeouts},
+	} {
+		diags.Append(state.SetAttribute(ctx, path.Root(a.name), a.value)...)
+	}
+	return diags
 }
 
 func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_server", "create", time.Now(), &resp.State, &resp.Diagnostics)
+
 	var plan resourcemodels.ServerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -279,8 +945,36 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 		"name": plan.Name.ValueString(),
 	})
 
+	// Resolve the timeout before anything is created, so an invalid value
+	// cannot fail the apply after the server exists.
+	timeout, diags := helper.ResolveTimeout(ctx, plan.Timeouts, "create", helper.DefaultServerWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Send the groups named in security_group_names along with the configured IDs
+	nameIDs, diags := r.resolveSecurityGroupNames(ctx, plan.NetworkAttachment)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createPlan := plan
+	createPlan.NetworkAttachment, diags = helper.WithNamedSecurityGroups(ctx, plan.NetworkAttachment, nameIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// user_data_file is sent to the API the same way as user_data
+	userData, err := helper.ServerUserData(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data_file"), "User Data File Not Readable", err.Error())
+		return
+	}
+	createPlan.UserData = userData
+
 	// Build create request
-	createReq, diags := helper.BuildServerCreateRequest(ctx, plan)
+	createReq, diags := helper.BuildServerCreateRequest(ctx, createPlan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -292,14 +986,54 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 		"has_keypair":  createReq.KeypairID != "",
 	})
 
-	// Call API
+	if r.precheckQuota && r.api != nil {
+		resp.Diagnostics.Append(r.checkQuota(ctx, plan.FlavorID.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	vpsClient := r.client.VPS()
-	serverRes, err := vpsClient.Servers().Create(ctx, createReq)
+
+	// first_free addresses are picked just before the create, so the ports
+	// they are checked against are current
+	var createNICs []resourcemodels.NetworkAttachmentModel
+	resp.Diagnostics.Append(createPlan.NetworkAttachment.ElementsAs(ctx, &createNICs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, att := range createNICs {
+		if att.FixedIPStrategy.ValueString() != helper.FixedIPStrategyFirstFree {
+			continue
+		}
+		ip, err := helper.FirstFreeNetworkIP(ctx, vpsClient.Networks(), att.NetworkID.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(helper.APIErrorDiagnostic("Create Error", fmt.Sprintf("pick the first free IP on network %s", att.NetworkID.ValueString()), err))
+			return
+		}
+		createReq.NICs[i].FixedIP = ip
+	}
+
+	// List the servers that already carry this name, so that a server made
+	// by a create request whose response is lost can be told apart from them
+	existingIDs, err := helper.ServerIDsNamed(ctx, vpsClient.Servers(), createReq.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Create Error",
-			fmt.Sprintf("Unable to create server: %s", err),
-		)
+		tflog.Warn(ctx, "Unable to list servers before create; a lost create response cannot be recovered", map[string]interface{}{
+			"name":  createReq.Name,
+			"error": err.Error(),
+		})
+	}
+
+	// Call API
+	serverRes, err := vpsClient.Servers().Create(ctx, createReq)
+	if err != nil && existingIDs != nil && helper.IsOutcomeUnknown(err) {
+		serverRes = r.adoptCreatedServer(ctx, createReq.Name, existingIDs, err, &resp.Diagnostics)
+		if serverRes != nil {
+			err = nil
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Create Error", "create server", err))
 		return
 	}
 
@@ -308,43 +1042,68 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 		"status": serverRes.Server.Status,
 	})
 
-	// Wait for active status if requested
-	waitForActive := true
-	if !plan.WaitForActive.IsNull() {
-		waitForActive = plan.WaitForActive.ValueBool()
-	}
-
-	if waitForActive {
-		// Get timeout from config (default 10m)
-		timeout := 10 * time.Minute
-		var timeoutsModel resourcemodels.TimeoutsModel
-		if !plan.Timeouts.IsNull() {
-			resp.Diagnostics.Append(plan.Timeouts.As(ctx, &timeoutsModel, basetypes.ObjectAsOptions{})...)
-			if !resp.Diagnostics.HasError() && !timeoutsModel.Create.IsNull() {
-				if d, err := time.ParseDuration(timeoutsModel.Create.ValueString()); err == nil {
-					timeout = d
-				}
-			}
-		}
+	// Record the server before waiting on it, so a later failure leaves it
+	// in state, tainted, instead of orphaning it
+	resp.Diagnostics.Append(saveCreatedServer(ctx, &resp.State, plan, serverRes.Server.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		tflog.Debug(ctx, "Waiting for server to become active", map[string]interface{}{
+	// Wait for the requested status; wait_until_status overrides wait_for_active
+	targets := helper.CreateWaitTargets(plan.WaitUntilStatus, plan.WaitForActive)
+	deadline := time.Now().Add(timeout)
+	reachedActive := false
+	if len(targets) > 0 {
+		tflog.Debug(ctx, "Waiting for server status", map[string]interface{}{
+			"targets": targets,
 			"timeout": timeout.String(),
 		})
 
-		serverRes, err = helper.WaitForServerActive(ctx, vpsClient.Servers(), serverRes.Server.ID, timeout)
+		serverID := serverRes.Server.ID
+		if len(targets) == 1 {
+			serverRes, err = helper.WaitForServerActive(ctx, vpsClient.Servers(), serverID, timeout)
+		} else {
+			serverRes, err = helper.WaitForServerStatuses(ctx, vpsClient.Servers(), serverID, targets, timeout)
+		}
 		if err != nil {
+			target := "active"
+			if !plan.WaitUntilStatus.IsNull() {
+				target = plan.WaitUntilStatus.ValueString()
+			}
 			resp.Diagnostics.AddError(
 				"Create Error",
-				fmt.Sprintf("Server created but failed to reach active state: %s", err),
+				fmt.Sprintf("Server created but failed to reach %s state: %s", target, err),
 			)
 			return
 		}
+		reachedActive = strings.EqualFold(string(serverRes.Server.Status), string(servermodels.ServerStatusActive))
+	}
+
+	// The root disk and floating IPs need a running server
+	if reachedActive {
+		// Expand the root volume now that the server is running
+		if !plan.RootDiskGB.IsNull() && !plan.RootDiskGB.IsUnknown() && int(plan.RootDiskGB.ValueInt64()) > serverRes.Server.RootDiskSize {
+			tflog.Debug(ctx, "Expanding root disk", map[string]interface{}{
+				"from_gb": serverRes.Server.RootDiskSize,
+				"to_gb":   plan.RootDiskGB.ValueInt64(),
+			})
+			serverRes, err = helper.ExtendRootDisk(ctx, vpsClient.Servers(), serverRes.Server.ID, int(plan.RootDiskGB.ValueInt64()), timeout)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Create Error",
+					fmt.Sprintf("Server created but failed to expand root disk: %s", err),
+				)
+				return
+			}
+		}
 
 		// Associate floating IPs after server is ACTIVE (NICs ready)
 		var planNetworkAttachments []resourcemodels.NetworkAttachmentModel
 		resp.Diagnostics.Append(plan.NetworkAttachment.ElementsAs(ctx, &planNetworkAttachments, false)...)
 		if !resp.Diagnostics.HasError() {
-			resp.Diagnostics.Append(helper.AssociateFloatingIPsForServer(ctx, serverRes, planNetworkAttachments)...)
+			// Wait for each association to show on the floating IP, so the
+			// server read below reports floating_ip for every attachment
+			resp.Diagnostics.Append(helper.AssociateFloatingIPsForServer(ctx, serverRes, vpsClient.FloatingIPs(), planNetworkAttachments, plan.FloatingIPAssociation.ValueString(), time.Until(deadline))...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -371,133 +1130,18 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Reorder network_attachment to match user-provided plan order (preserve which block was marked primary)
 	// We prefer plan's order/primary selection since the API does not expose a stable primary flag.
-	var planNetworkAttachments []resourcemodels.NetworkAttachmentModel
+	var planNetworkAttachments, apiNetworkAttachments []resourcemodels.NetworkAttachmentModel
 	planDiags := plan.NetworkAttachment.ElementsAs(ctx, &planNetworkAttachments, false)
-	// Only attempt reorder if we can successfully read the plan attachments
-	if len(planDiags) == 0 {
-		// Build a map from network_id -> ServerNIC for quick lookup
-		nics, err := serverRes.NICs().List(ctx)
-		if err == nil {
-			nicMap := make(map[string]*servermodels.ServerNIC, len(nics))
-			for _, nic := range nics {
-				nicMap[nic.NetworkID] = nic
-			}
-
-			networkAttachmentAttrTypes := map[string]attr.Type{
-				"network_id":         types.StringType,
-				"ip_address":         types.StringType,
-				"primary":            types.BoolType,
-				"security_group_ids": types.ListType{ElemType: types.StringType},
-				"floating_ip_id":     types.StringType,
-				"floating_ip":        types.StringType,
-			}
-
-			ordered := make([]attr.Value, 0, len(nics))
-			// Add attachments in plan order
-			for _, p := range planNetworkAttachments {
-				nid := p.NetworkID.ValueString()
-				nic := nicMap[nid]
-				// Map SecurityGroupIDs: prefer plan-specified order if present, otherwise use API NIC SGIDs (sorted deterministically)
-				sgVals := make([]attr.Value, 0)
-				var planSGs []types.String
-				if d := p.SecurityGroupIDs.ElementsAs(ctx, &planSGs, false); len(d) == 0 && len(planSGs) > 0 {
-					for _, sg := range planSGs {
-						sgVals = append(sgVals, types.StringValue(sg.ValueString()))
-					}
-				} else if nic != nil {
-					sgIDs := make([]string, len(nic.SGIDs))
-					copy(sgIDs, nic.SGIDs)
-					sort.Strings(sgIDs)
-					for _, sg := range sgIDs {
-						sgVals = append(sgVals, types.StringValue(sg))
-					}
-				}
-				sgList, d := types.ListValue(types.StringType, sgVals)
-				diags.Append(d...)
-
-				// IP address from NIC if available
-				ipAddress := types.StringNull()
-				if nic != nil && len(nic.Addresses) > 0 {
-					ipAddress = types.StringValue(nic.Addresses[0])
-				}
-
-				// Extract floating IP information if associated
-				floatingIPID := p.FloatingIPID
-				floatingIPAddress := types.StringNull()
-				if nic != nil && nic.FloatingIP != nil {
-					floatingIPAddress = types.StringValue(nic.FloatingIP.Address)
-				}
-
-				attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
-					"network_id":         types.StringValue(nid),
-					"ip_address":         ipAddress,
-					"primary":            types.BoolValue(p.Primary.ValueBool()),
-					"security_group_ids": sgList,
-					"floating_ip_id":     floatingIPID,
-					"floating_ip":        floatingIPAddress,
-				})
-				diags.Append(d...)
-				ordered = append(ordered, attObj)
-				// Remove from nicMap so remaining NICs can be appended later
-				delete(nicMap, nid)
-			}
-
-			// Append any NICs not present in the plan (sorted by NetworkID)
-			remaining := make([]*servermodels.ServerNIC, 0, len(nicMap))
-			for _, nic := range nicMap {
-				remaining = append(remaining, nic)
-			}
-			// Deterministic sort
-			if len(remaining) > 1 {
-				sort.SliceStable(remaining, func(i, j int) bool {
-					return remaining[i].NetworkID < remaining[j].NetworkID
-				})
-			}
-			for _, nic := range remaining {
-				sgIDs := make([]string, len(nic.SGIDs))
-				copy(sgIDs, nic.SGIDs)
-				sort.Strings(sgIDs)
-				sgVals := make([]attr.Value, 0, len(sgIDs))
-				for _, sg := range sgIDs {
-					sgVals = append(sgVals, types.StringValue(sg))
-				}
-				sgList, d := types.ListValue(types.StringType, sgVals)
-				diags.Append(d...)
-
-				ipAddress := types.StringNull()
-				if len(nic.Addresses) > 0 {
-					ipAddress = types.StringValue(nic.Addresses[0])
-				}
-
-				// Extract floating IP information if associated
-				floatingIPID := types.StringNull()
-				floatingIPAddress := types.StringNull()
-				if nic.FloatingIP != nil {
-					floatingIPID = types.StringValue(nic.FloatingIP.ID)
-					floatingIPAddress = types.StringValue(nic.FloatingIP.Address)
-				}
-
-				attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
-					"network_id":         types.StringValue(nic.NetworkID),
-					"ip_address":         ipAddress,
-					"primary":            types.BoolValue(false),
-					"security_group_ids": sgList,
-					"floating_ip_id":     floatingIPID,
-					"floating_ip":        floatingIPAddress,
-				})
-				diags.Append(d...)
-				ordered = append(ordered, attObj)
-			}
-
-			// Build final list and set on state
-			networkAttachmentList, d := types.ListValue(
-				types.ObjectType{AttrTypes: networkAttachmentAttrTypes},
-				ordered,
-			)
-			diags.Append(d...)
-			if !d.HasError() {
-				state.NetworkAttachment = networkAttachmentList
-			}
+	apiDiags := state.NetworkAttachment.ElementsAs(ctx, &apiNetworkAttachments, false)
+	// Only attempt reorder if we can successfully read the attachments
+	if !planDiags.HasError() && !apiDiags.HasError() {
+		resp.Diagnostics.Append(helper.NICSettingsNotAppliedWarning(planNetworkAttachments, nil)...)
+		planOrder, apiNics, d := helper.WithoutNamedSecurityGroups(ctx, planNetworkAttachments, apiNetworkAttachments, nameIDs, helper.OrderFromPlan)
+		resp.Diagnostics.Append(d...)
+		networkAttachmentList, d := helper.OrderNetworkAttachments(ctx, planOrder, apiNics, helper.OrderFromPlan)
+		resp.Diagnostics.Append(d...)
+		if !d.HasError() {
+			state.NetworkAttachment = networkAttachmentList
 		}
 	}
 
@@ -505,18 +1149,33 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 	// NOTE: wait_for_active, wait_for_deleted and timeouts are runtime-only
 	// Preserve user-provided values that aren't returned by API
 	state.UserData = plan.UserData // API doesn't return user_data for security
+	state.UserDataFile = plan.UserDataFile
 	state.Password = plan.Password // API doesn't return password for security
 	state.Keypair = plan.Keypair
+	state.RebootTrigger = plan.RebootTrigger
+	state.Description = helper.PreserveEmptyDescription(state.Description, plan.Description)
+	if state.RootDiskGB.IsNull() && !plan.RootDiskGB.IsUnknown() {
+		// Not every API version reports the root disk size
+		state.RootDiskGB = plan.RootDiskGB
+	}
 
 	// Store runtime-only config in state during Create (they will be ignored during updates)
 	state.WaitForActive = plan.WaitForActive
+	state.WaitUntilStatus = plan.WaitUntilStatus
 	state.WaitForDeleted = plan.WaitForDeleted
+	state.FloatingIPAssociation = plan.FloatingIPAssociation
 	state.Timeouts = plan.Timeouts
+	state.DeletionProtection = plan.DeletionProtection
+	state.WindowsPasswordPrivateKey = plan.WindowsPasswordPrivateKey
+	state.Revision = helper.ServerRevision(plan.ImageID, plan.FlavorID, plan.Keypair, userData)
+	r.readWindowsPassword(ctx, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_server", "read", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state resourcemodels.ServerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -531,10 +1190,7 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 	vpsClient := r.client.VPS()
 	server, err := vpsClient.Servers().Get(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Read Error",
-			fmt.Sprintf("Unable to read server: %s", err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Read Error", "read server", err))
 		return
 	}
 
@@ -544,181 +1200,86 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(helper.RefreshNICFloatingIPs(ctx, vpsClient.FloatingIPs(), server.Server.ID, &newState)...)
+	resp.Diagnostics.Append(helper.FillNICFloatingIPs(ctx, vpsClient.FloatingIPs(), server.Server.ID, server.Server.PublicIPs, &newState)...)
 
 	// Reorder network_attachment to prefer existing state order (stable across reads)
-	var prevNetworkAttachments []resourcemodels.NetworkAttachmentModel
-	if d := state.NetworkAttachment.ElementsAs(ctx, &prevNetworkAttachments, false); len(d) == 0 && len(prevNetworkAttachments) > 0 {
-		var apiNetworkAttachments []resourcemodels.NetworkAttachmentModel
-		if d2 := newState.NetworkAttachment.ElementsAs(ctx, &apiNetworkAttachments, false); len(d2) == 0 {
-			// Build map from network_id -> attachment
-			nicMap := make(map[string]resourcemodels.NetworkAttachmentModel, len(apiNetworkAttachments))
-			for _, a := range apiNetworkAttachments {
-				nicMap[a.NetworkID.ValueString()] = a
-			}
-
-			networkAttachmentAttrTypes := map[string]attr.Type{
-				"network_id":         types.StringType,
-				"ip_address":         types.StringType,
-				"primary":            types.BoolType,
-				"security_group_ids": types.ListType{ElemType: types.StringType},
-				"floating_ip_id":     types.StringType,
-				"floating_ip":        types.StringType,
-			}
-
-			ordered := make([]attr.Value, 0, len(apiNetworkAttachments))
-			// Add attachments in previous state order when possible
-			for _, p := range prevNetworkAttachments {
-				nid := p.NetworkID.ValueString()
-				if nic, ok := nicMap[nid]; ok {
-					// Prefer previous state's security group ordering when possible to avoid spurious diffs.
-					// Use previous state's ordering filtered by what's actually present in the API; append any
-					// remaining API SGs deterministically (sorted).
-					sgVals := make([]attr.Value, 0)
-					var prevSGs []types.String
-					if d := p.SecurityGroupIDs.ElementsAs(ctx, &prevSGs, false); len(d) == 0 && len(prevSGs) > 0 {
-						// Build set of SG IDs returned by API for this NIC
-						var apiSGs []types.String
-						resp.Diagnostics.Append(nic.SecurityGroupIDs.ElementsAs(ctx, &apiSGs, false)...) // append errors if any
-						apiSet := make(map[string]struct{}, len(apiSGs))
-						for _, s := range apiSGs {
-							apiSet[s.ValueString()] = struct{}{}
-						}
-						// Add in previous state's order when present in the API
-						for _, s := range prevSGs {
-							if _, ok := apiSet[s.ValueString()]; ok {
-								sgVals = append(sgVals, types.StringValue(s.ValueString()))
-								delete(apiSet, s.ValueString())
-							}
-						}
-						// Append any remaining API SGs deterministically (sorted)
-						if len(apiSet) > 0 {
-							remaining := make([]string, 0, len(apiSet))
-							for s := range apiSet {
-								remaining = append(remaining, s)
-							}
-							sort.Strings(remaining)
-							for _, s := range remaining {
-								sgVals = append(sgVals, types.StringValue(s))
-							}
-						}
-					} else {
-						// Fallback: use API SGs sorted deterministically
-						var apiSGs []types.String
-						resp.Diagnostics.Append(nic.SecurityGroupIDs.ElementsAs(ctx, &apiSGs, false)...) // append errors if any
-						sgStrings := make([]string, len(apiSGs))
-						for i, sg := range apiSGs {
-							sgStrings[i] = sg.ValueString()
-						}
-						sort.Strings(sgStrings)
-						for _, sg := range sgStrings {
-							sgVals = append(sgVals, types.StringValue(sg))
-						}
-					}
-					sgList, d := types.ListValue(types.StringType, sgVals)
-					resp.Diagnostics.Append(d...)
-
-					ipAddress := types.StringNull()
-					if !nic.IPAddress.IsNull() {
-						ipAddress = nic.IPAddress
-					}
-
-					attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
-						"network_id":         types.StringValue(nid),
-						"ip_address":         ipAddress,
-						"primary":            types.BoolValue(nic.Primary.ValueBool()),
-						"security_group_ids": sgList,
-						"floating_ip_id":     nic.FloatingIPID,
-						"floating_ip":        nic.FloatingIP,
-					})
-					resp.Diagnostics.Append(d...)
-					ordered = append(ordered, attObj)
-					delete(nicMap, nid)
-				}
-			}
-
-			// Append any NICs not present in the previous state (sorted by NetworkID)
-			if len(nicMap) > 0 {
-				remaining := make([]resourcemodels.NetworkAttachmentModel, 0, len(nicMap))
-				for _, nic := range nicMap {
-					remaining = append(remaining, nic)
-				}
-				if len(remaining) > 1 {
-					sort.SliceStable(remaining, func(i, j int) bool {
-						return remaining[i].NetworkID.ValueString() < remaining[j].NetworkID.ValueString()
-					})
-				}
-				for _, nic := range remaining {
-					var sgListVals []types.String
-					resp.Diagnostics.Append(nic.SecurityGroupIDs.ElementsAs(ctx, &sgListVals, false)...) // append errors if any
-					// Sort SG IDs to ensure deterministic ordering
-					sgStrings := make([]string, len(sgListVals))
-					for i, sg := range sgListVals {
-						sgStrings[i] = sg.ValueString()
-					}
-					sort.Strings(sgStrings)
-					sgVals := make([]attr.Value, 0, len(sgStrings))
-					for _, sg := range sgStrings {
-						sgVals = append(sgVals, types.StringValue(sg))
-					}
-					sgList, d := types.ListValue(types.StringType, sgVals)
-					resp.Diagnostics.Append(d...)
-
-					ipAddress := types.StringNull()
-					if !nic.IPAddress.IsNull() {
-						ipAddress = nic.IPAddress
-					}
-
-					attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
-						"network_id":         types.StringValue(nic.NetworkID.ValueString()),
-						"ip_address":         ipAddress,
-						"primary":            types.BoolValue(nic.Primary.ValueBool()),
-						"security_group_ids": sgList,
-						"floating_ip_id":     nic.FloatingIPID,
-						"floating_ip":        nic.FloatingIP,
-					})
-					resp.Diagnostics.Append(d...)
-					ordered = append(ordered, attObj)
-				}
+	var prevNetworkAttachments, apiNetworkAttachments []resourcemodels.NetworkAttachmentModel
+	if d := state.NetworkAttachment.ElementsAs(ctx, &prevNetworkAttachments, false); !d.HasError() && len(prevNetworkAttachments) > 0 {
+		if d := newState.NetworkAttachment.ElementsAs(ctx, &apiNetworkAttachments, false); !d.HasError() {
+			// A name that no longer resolves is not fatal on refresh: its group
+			// then shows up in security_group_ids and the next apply reports it.
+			nameIDs, d := r.resolveSecurityGroupNames(ctx, state.NetworkAttachment)
+			for _, diagnostic := range d {
+				resp.Diagnostics.AddWarning(diagnostic.Summary(), diagnostic.Detail())
 			}
-
-			// Build final list and set on newState
-			if len(ordered) > 0 {
-				networkAttachmentList, d := types.ListValue(
-					types.ObjectType{AttrTypes: networkAttachmentAttrTypes},
-					ordered,
-				)
-				resp.Diagnostics.Append(d...)
-				if !d.HasError() {
-					newState.NetworkAttachment = networkAttachmentList
-				}
+			prevNetworkAttachments, apiNetworkAttachments, d = helper.WithoutNamedSecurityGroups(ctx, prevNetworkAttachments, apiNetworkAttachments, nameIDs, helper.OrderFromState)
+			resp.Diagnostics.Append(d...)
+			networkAttachmentList, d := helper.OrderNetworkAttachments(ctx, prevNetworkAttachments, apiNetworkAttachments, helper.OrderFromState)
+			resp.Diagnostics.Append(d...)
+			if !d.HasError() {
+				newState.NetworkAttachment = networkAttachmentList
 			}
 		}
 	}
 
 	// Preserve user-provided values that aren't returned by API
 	newState.UserData = state.UserData
+	newState.UserDataFile = state.UserDataFile
 	newState.Password = state.Password
 	newState.Keypair = state.Keypair
+	newState.RebootTrigger = state.RebootTrigger
+	newState.Description = helper.PreserveEmptyDescription(newState.Description, state.Description)
+	if newState.RootDiskGB.IsNull() {
+		newState.RootDiskGB = state.RootDiskGB
+	}
 
 	// Preserve runtime-only config from existing state
 	newState.WaitForActive = state.WaitForActive
+	newState.WaitUntilStatus = state.WaitUntilStatus
 	newState.WaitForDeleted = state.WaitForDeleted
+	newState.FloatingIPAssociation = state.FloatingIPAssociation
 	newState.Timeouts = state.Timeouts
+	newState.DeletionProtection = state.DeletionProtection
+	newState.WindowsPasswordPrivateKey = state.WindowsPasswordPrivateKey
+	newState.WindowsPassword = state.WindowsPassword
+	newState.Revision = state.Revision
+	if newState.Revision.IsNull() {
+		// Servers created before revision existed
+		newState.Revision = helper.ServerRevision(newState.ImageID, newState.FlavorID, newState.Keypair, newState.UserData)
+	}
+	r.readWindowsPassword(ctx, &newState)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
 func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_server", "delete", time.Now(), &req.State, &resp.Diagnostics)
+
 	var state resourcemodels.ServerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Server Deletion Protected",
+			fmt.Sprintf("Server %s has deletion_protection set to true, so it was not deleted. To delete it, set deletion_protection = false, apply, and then destroy it.", state.ID.ValueString()),
+		)
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting server", map[string]interface{}{
 		"id": state.ID.ValueString(),
 	})
 
+	timeout, diags := helper.ResolveTimeout(ctx, state.Timeouts, "delete", helper.DefaultServerWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get VPS client for operations
 	vpsClient := r.client.VPS()
 
@@ -743,10 +1304,15 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	// Call API
 	err := vpsClient.Servers().Delete(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Delete Error",
-			fmt.Sprintf("Unable to delete server: %s", err),
-		)
+		// Check for 404 (already deleted)
+		if helper.IsNotFound(err) {
+			tflog.Warn(ctx, "Server already deleted", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Delete Error", "delete server", err))
 		return
 	}
 
@@ -768,18 +1334,6 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Get timeout from config (default 10m)
-	timeout := 10 * time.Minute
-	var timeoutsModel resourcemodels.TimeoutsModel
-	if !state.Timeouts.IsNull() {
-		resp.Diagnostics.Append(state.Timeouts.As(ctx, &timeoutsModel, basetypes.ObjectAsOptions{})...)
-		if !resp.Diagnostics.HasError() && !timeoutsModel.Delete.IsNull() {
-			if d, err := time.ParseDuration(timeoutsModel.Delete.ValueString()); err == nil {
-				timeout = d
-			}
-		}
-	}
-
 	tflog.Debug(ctx, "Waiting for server to be deleted", map[string]interface{}{
 		"timeout": timeout.String(),
 	})
@@ -799,6 +1353,8 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logOperation(ctx, r.emitOperationEvents, "zillaforge_server", "update", time.Now(), &req.State, &resp.Diagnostics)
+
 	var plan resourcemodels.ServerResourceModel
 	var state resourcemodels.ServerResourceModel
 
@@ -812,53 +1368,99 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		"id": state.ID.ValueString(),
 	})
 
+	timeout, diags := helper.ResolveTimeout(ctx, plan.Timeouts, "update", helper.DefaultServerWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	userData, err := helper.ServerUserData(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data_file"), "User Data File Not Readable", err.Error())
+		return
+	}
+
+	// Compare and send security groups with security_group_names resolved, so
+	// renaming a reference or adding a name updates the NIC.
+	nameIDs, diags := r.resolveSecurityGroupNames(ctx, plan.NetworkAttachment, state.NetworkAttachment)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resolvedPlan, resolvedState := plan, state
+	resolvedPlan.NetworkAttachment, diags = helper.WithNamedSecurityGroups(ctx, plan.NetworkAttachment, nameIDs)
+	resp.Diagnostics.Append(diags...)
+	resolvedState.NetworkAttachment, diags = helper.WithNamedSecurityGroups(ctx, state.NetworkAttachment, nameIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build update request with only changed fields
-	updateCtx, diags := helper.BuildServerUpdateRequest(ctx, plan, state)
+	updateCtx, diags := helper.BuildServerUpdateRequest(ctx, resolvedPlan, resolvedState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var plannedNICs, priorNICs []resourcemodels.NetworkAttachmentModel
+	resp.Diagnostics.Append(plan.NetworkAttachment.ElementsAs(ctx, &plannedNICs, false)...)
+	resp.Diagnostics.Append(state.NetworkAttachment.ElementsAs(ctx, &priorNICs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(helper.NICSettingsNotAppliedWarning(plannedNICs, priorNICs)...)
+
 	// Only call Update APIs if there are changes to apply
 	if updateCtx.HasChanges {
 		vpsClient := r.client.VPS()
 
+		// NIC retries and floating IP waits stop at the deadline so they
+		// cannot outlast the update.
+		deadline := time.Now().Add(timeout)
+
 		// Update server attributes if needed
-		if updateCtx.ServerUpdate.Name != "" || updateCtx.ServerUpdate.Description != "" {
-			_, err := vpsClient.Servers().Update(ctx, state.ID.ValueString(), updateCtx.ServerUpdate)
+		if updateCtx.ServerUpdate.Name != "" || updateCtx.ServerUpdate.Description != "" || updateCtx.ClearDescription {
+			updateReqCtx := ctx
+			if updateCtx.ClearDescription {
+				updateReqCtx = helper.WithClearedFields(ctx, "description")
+			}
+			_, err := vpsClient.Servers().Update(updateReqCtx, state.ID.ValueString(), updateCtx.ServerUpdate)
 			if err != nil {
-				resp.Diagnostics.AddError(
-					"Update Error",
-					fmt.Sprintf("Unable to update server: %s", err),
-				)
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "update server", err))
 				return
 			}
 
 			tflog.Info(ctx, "Server update API called", map[string]interface{}{
 				"id":                  state.ID.ValueString(),
 				"name_changed":        updateCtx.ServerUpdate.Name != "",
-				"description_changed": updateCtx.ServerUpdate.Description != "",
+				"description_changed": updateCtx.ServerUpdate.Description != "" || updateCtx.ClearDescription,
 			})
 		}
 
+		// Expand the root volume before touching NICs, which need an active server
+		if updateCtx.RootDiskGB > 0 {
+			tflog.Info(ctx, "Expanding server root disk", map[string]interface{}{
+				"id":    state.ID.ValueString(),
+				"to_gb": updateCtx.RootDiskGB,
+			})
+			if _, err := helper.ExtendRootDisk(ctx, vpsClient.Servers(), state.ID.ValueString(), updateCtx.RootDiskGB, timeout); err != nil {
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "expand server root disk", err))
+				return
+			}
+		}
+
 		// Handle network attachment changes (delete, create, update)
-		if len(updateCtx.NetworksToDelete) > 0 || len(updateCtx.NetworksToCreate) > 0 || len(updateCtx.NetworkChanges) > 0 {
+		if len(updateCtx.NetworksToDelete) > 0 || len(updateCtx.NetworksToCreate) > 0 || len(updateCtx.NetworksToReattach) > 0 || len(updateCtx.NetworkChanges) > 0 {
 			serverRes, err := vpsClient.Servers().Get(ctx, state.ID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
-					"Update Error",
-					fmt.Sprintf("Unable to get server for NIC updates: %s", err),
-				)
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "get server for NIC updates", err))
 				return
 			}
 
 			// Get all NICs to find the NIC ID for each network
 			nics, err := serverRes.NICs().List(ctx)
 			if err != nil {
-				resp.Diagnostics.AddError(
-					"Update Error",
-					fmt.Sprintf("Unable to list server NICs: %s", err),
-				)
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "list server NICs", err))
 				return
 			}
 
@@ -870,74 +1472,56 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 			nicsClient := serverRes.NICs()
 
+			strategies := make(map[string]string, len(plannedNICs))
+			fixedIPs := make(map[string]string, len(plannedNICs))
+			for _, att := range plannedNICs {
+				strategies[att.NetworkID.ValueString()] = att.FixedIPStrategy.ValueString()
+				if !att.IPAddress.IsUnknown() && att.IPAddress.ValueString() != "" {
+					fixedIPs[att.NetworkID.ValueString()] = att.IPAddress.ValueString()
+				}
+			}
+
 			// Step 1: Create new NICs first (before deleting old ones to ensure server always has at least one NIC)
 			for _, nicCreate := range updateCtx.NetworksToCreate {
-				// Retry Add operation a few times for transient failures (e.g., neutron IP allocation edge cases)
-				var addErr error
-				for attempt := 1; attempt <= 3; attempt++ {
-					_, addErr = nicsClient.Add(ctx, &nicCreate)
-					if addErr == nil {
-						break
-					}
-					// If this looks like an IP allocation error from Neutron, retry a couple times
-					if strings.Contains(addErr.Error(), "is not a valid IP for the specified subnet") || strings.Contains(addErr.Error(), "(neutron)IP address") {
-						if attempt < 3 {
-							tflog.Warn(ctx, "Transient IP allocation error when adding NIC — retrying", map[string]interface{}{"attempt": attempt, "err": addErr.Error(), "network_id": nicCreate.NetworkID})
-							// small backoff
-							time.Sleep(2 * time.Second)
-							continue
-						}
+				strategy := strategies[nicCreate.NetworkID]
+				if strategy == helper.FixedIPStrategyFirstFree && nicCreate.FixedIP == "" {
+					ip, err := helper.FirstFreeNetworkIP(ctx, vpsClient.Networks(), nicCreate.NetworkID)
+					if err != nil {
+						resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("pick the first free IP on network %s", nicCreate.NetworkID), err))
+						return
 					}
-					// Non-transient or final attempt: break and surface error
-					break
+					nicCreate.FixedIP = ip
 				}
 
+				// Retry transient failures (e.g., neutron IP allocation edge cases)
+				addErr := helper.AddNICWithRetry(ctx, nicsClient, &nicCreate, time.Until(deadline))
+
 				if addErr != nil {
-					// If the Add failed due to an IP allocation error, try to pick a candidate IP in the network CIDR and retry
-					if strings.Contains(addErr.Error(), "is not a valid IP for the specified subnet") || strings.Contains(addErr.Error(), "(neutron)IP address") {
-						// Attempt to get network CIDR
+					// If the Add failed due to an IP allocation error, try to pick a candidate IP in the network CIDR and retry.
+					// Only auto falls back; first_free and specific request exactly the address they chose.
+					if helper.IsIPAllocationError(addErr) && (strategy == "" || strategy == helper.FixedIPStrategyAuto) {
+						// Attempt to get network CIDR and pick candidates from its allocation pool
 						netRes, err := vpsClient.Networks().Get(ctx, nicCreate.NetworkID)
 						if err == nil && netRes != nil && netRes.Network.CIDR != "" {
-							cidr := netRes.Network.CIDR
-							if ip, ipnet, err := net.ParseCIDR(cidr); err == nil {
-								// Only handle IPv4 for now
-								if ip4 := ip.To4(); ip4 != nil {
-									// Try a few candidate host offsets
-									for _, off := range []int{10, 20, 30, 40, 50} {
-										cand := make(net.IP, len(ip4))
-										copy(cand, ip4)
-										// add offset to last octet(s)
-										c := uint32(cand[0])<<24 | uint32(cand[1])<<16 | uint32(cand[2])<<8 | uint32(cand[3])
-										c += uint32(off)
-										cand[0] = byte((c >> 24) & 0xff)
-										cand[1] = byte((c >> 16) & 0xff)
-										cand[2] = byte((c >> 8) & 0xff)
-										cand[3] = byte(c & 0xff)
-										if ipnet.Contains(cand) {
-											// Try add with FixedIP candidate
-											tryReq := servermodels.ServerNICCreateRequest{
-												NetworkID: nicCreate.NetworkID,
-												SGIDs:     nicCreate.SGIDs,
-												FixedIP:   cand.String(),
-											}
-											_, err := nicsClient.Add(ctx, &tryReq)
-											if err == nil {
-												addErr = nil
-												break
-											}
-											// If candidate fails, continue to next
-										}
-									}
+							for _, cand := range helper.FixedIPCandidates(netRes.Network.CIDR, netRes.Network.Gateway, []int{10, 20, 30, 40, 50}) {
+								// Try add with FixedIP candidate
+								tryReq := servermodels.ServerNICCreateRequest{
+									NetworkID: nicCreate.NetworkID,
+									SGIDs:     nicCreate.SGIDs,
+									FixedIP:   cand,
 								}
+								_, err := nicsClient.Add(ctx, &tryReq)
+								if err == nil {
+									addErr = nil
+									break
+								}
+								// If candidate fails, continue to next
 							}
 						}
 					}
 
 					if addErr != nil {
-						resp.Diagnostics.AddError(
-							"Update Error",
-							fmt.Sprintf("Unable to create NIC for network %s: %s", nicCreate.NetworkID, addErr),
-						)
+						resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("create NIC for network %s", nicCreate.NetworkID), addErr))
 						return
 					}
 				}
@@ -961,10 +1545,7 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 				err := nicsClient.Delete(ctx, nicID)
 				if err != nil {
-					resp.Diagnostics.AddError(
-						"Update Error",
-						fmt.Sprintf("Unable to delete NIC for network %s: %s", networkID, err),
-					)
+					resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("delete NIC for network %s", networkID), err))
 					return
 				}
 
@@ -975,7 +1556,33 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 				})
 			}
 
-			// Step 3: Update security groups for existing networks
+			// Step 3: Reattach NICs whose fixed IP changed. The NIC must be
+			// removed first since it keeps its address until deleted.
+			for _, nicCreate := range updateCtx.NetworksToReattach {
+				nicID, exists := nicByNetwork[nicCreate.NetworkID]
+				if !exists {
+					resp.Diagnostics.AddError(
+						"Update Error",
+						fmt.Sprintf("NIC not found for network %s", nicCreate.NetworkID),
+					)
+					return
+				}
+
+				restore := updateCtx.NICRestores[nicCreate.NetworkID]
+				if err := helper.ReattachNIC(ctx, nicsClient, nicID, &nicCreate, &restore, time.Until(deadline)); err != nil {
+					resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("reattach NIC for network %s", nicCreate.NetworkID), err))
+					return
+				}
+
+				tflog.Info(ctx, "NIC reattached with new fixed IP", map[string]interface{}{
+					"id":         state.ID.ValueString(),
+					"network_id": nicCreate.NetworkID,
+					"old_nic_id": nicID,
+					"fixed_ip":   nicCreate.FixedIP,
+				})
+			}
+
+			// Step 4: Update security groups for existing networks
 			for networkID, nicUpdate := range updateCtx.NetworkChanges {
 				nicID, exists := nicByNetwork[networkID]
 				if !exists {
@@ -988,13 +1595,23 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 				_, err := nicsClient.Update(ctx, nicID, &nicUpdate)
 				if err != nil {
-					resp.Diagnostics.AddError(
-						"Update Error",
-						fmt.Sprintf("Unable to update security groups for network %s: %s", networkID, err),
-					)
+					resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", fmt.Sprintf("update security groups for network %s", networkID), err))
 					return
 				}
 
+				// The update request carries no address, so make sure the
+				// NIC kept its fixed IP rather than recording it unverified
+				if fixedIP, ok := fixedIPs[networkID]; ok {
+					if err := helper.WaitForNICAddress(ctx, nicsClient, networkID, fixedIP, time.Until(deadline)); err != nil {
+						resp.Diagnostics.AddError(
+							"Update Error",
+							fmt.Sprintf("The security groups of the NIC on network %s were updated, but its fixed IP could not be confirmed: %s. "+
+								"Check the NIC's address, then apply again to reattach it with ip_address %s.", networkID, err, fixedIP),
+						)
+						return
+					}
+				}
+
 				tflog.Info(ctx, "NIC security groups updated", map[string]interface{}{
 					"id":         state.ID.ValueString(),
 					"network_id": networkID,
@@ -1004,30 +1621,43 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 			}
 		}
 
-		// Get timeout from config (default 10m)
-		timeout := 10 * time.Minute
-		var timeoutsModel resourcemodels.TimeoutsModel
-		if !plan.Timeouts.IsNull() {
-			resp.Diagnostics.Append(plan.Timeouts.As(ctx, &timeoutsModel, basetypes.ObjectAsOptions{})...)
-			if !resp.Diagnostics.HasError() && !timeoutsModel.Update.IsNull() {
-				if d, err := time.ParseDuration(timeoutsModel.Update.ValueString()); err == nil {
-					timeout = d
-				}
+		// Reboot last so it also picks up any NIC changes made above
+		if updateCtx.Reboot {
+			tflog.Info(ctx, "Rebooting server (reboot_trigger changed)", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			if err := helper.RebootServer(ctx, vpsClient.Servers(), state.ID.ValueString()); err != nil {
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "reboot server", err))
+				return
 			}
 		}
 
-		// Wait for server to return to active status after update
-		tflog.Debug(ctx, "Waiting for server to become active after update", map[string]interface{}{
-			"timeout": timeout.String(),
-		})
+		var serverRes *serversdk.ServerResource
+		var err error
+		if helper.UpdateRequiresActiveWait(updateCtx) {
+			// Wait for server to return to active status after update
+			tflog.Debug(ctx, "Waiting for server to become active after update", map[string]interface{}{
+				"timeout": timeout.String(),
+			})
 
-		serverRes, err := helper.WaitForServerActive(ctx, vpsClient.Servers(), state.ID.ValueString(), timeout)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Update Error",
-				fmt.Sprintf("Server updated but failed to return to active state: %s", err),
-			)
-			return
+			serverRes, err = helper.WaitForServerActive(ctx, vpsClient.Servers(), state.ID.ValueString(), timeout)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Update Error",
+					fmt.Sprintf("Server updated but failed to return to active state: %s", err),
+				)
+				return
+			}
+		} else {
+			// Metadata-only change (name/description/floating IP): the server
+			// does not cycle, so skip the active waiter.
+			tflog.Debug(ctx, "Skipping active wait for metadata-only update")
+
+			serverRes, err = vpsClient.Servers().Get(ctx, state.ID.ValueString())
+			if err != nil {
+				resp.Diagnostics.Append(helper.APIErrorDiagnostic("Update Error", "read server after update", err))
+				return
+			}
 		}
 
 		// Handle floating IP changes (T024: associate/disassociate based on plan vs state)
@@ -1037,36 +1667,27 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		resp.Diagnostics.Append(state.NetworkAttachment.ElementsAs(ctx, &stateNetworkAttachments, false)...)
 
 		if !resp.Diagnostics.HasError() {
-			// Build maps for easy comparison
-			planFIPsByNetwork := make(map[string]string)
-			stateFIPsByNetwork := make(map[string]string)
-
-			for _, att := range planNetworkAttachments {
-				if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
-					planFIPsByNetwork[att.NetworkID.ValueString()] = att.FloatingIPID.ValueString()
-				}
+			reattached := make(map[string]bool)
+			for _, nicCreate := range updateCtx.NetworksToReattach {
+				reattached[nicCreate.NetworkID] = true
 			}
-
-			for _, att := range stateNetworkAttachments {
-				if !att.FloatingIPID.IsNull() && !att.FloatingIPID.IsUnknown() {
-					stateFIPsByNetwork[att.NetworkID.ValueString()] = att.FloatingIPID.ValueString()
-				}
-			}
-
-			// Find floating IPs to disassociate (in state but not in plan, or changed)
-			floatingIPsToDisassociate := make([]string, 0)
-			for networkID, stateFIPID := range stateFIPsByNetwork {
-				planFIPID, existsInPlan := planFIPsByNetwork[networkID]
-				if !existsInPlan {
-					// Floating IP removed from this network
-					floatingIPsToDisassociate = append(floatingIPsToDisassociate, stateFIPID)
-				} else if planFIPID != stateFIPID {
-					// Floating IP changed for this network - disassociate old one
-					floatingIPsToDisassociate = append(floatingIPsToDisassociate, stateFIPID)
-				}
+			floatingIPsToDisassociate, floatingIPsToAssociate := helper.FloatingIPChanges(planNetworkAttachments, stateNetworkAttachments, reattached)
+
+			// A moved floating IP is disassociated like a removed one and then
+			// associated with the NIC of its new network.
+			moves := make(map[string]resourcemodels.FloatingIPMove, len(updateCtx.FloatingIPMoves))
+			for _, move := range updateCtx.FloatingIPMoves {
+				moves[move.FloatingIPID] = move
+				tflog.Info(ctx, "Moving floating IP to another NIC of the server", map[string]interface{}{
+					"floating_ip_id": move.FloatingIPID,
+					"server_id":      serverRes.Server.ID,
+					"from":           move.FromNetworkID,
+					"to":             move.ToNetworkID,
+				})
 			}
 
-			// Disassociate removed/changed floating IPs
+			// Disassociate removed/changed floating IPs first, so a swapped
+			// address is released before its replacement is bound to the NIC
 			if len(floatingIPsToDisassociate) > 0 {
 				tflog.Debug(ctx, "Disassociating floating IPs during update", map[string]interface{}{
 					"count": len(floatingIPsToDisassociate),
@@ -1076,8 +1697,17 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 					return
 				}
 
-				// Wait briefly for disassociation to propagate through the cloud API
-				time.Sleep(3 * time.Second)
+				for _, floatingIPID := range floatingIPsToDisassociate {
+					if err := helper.WaitForFloatingIPDisassociated(ctx, vpsClient.FloatingIPs(), floatingIPID, time.Until(deadline)); err != nil {
+						detail := fmt.Sprintf("Floating IP %s was not released from server %s: %s", floatingIPID, serverRes.Server.ID, err.Error())
+						if move, ok := moves[floatingIPID]; ok {
+							detail = fmt.Sprintf("Floating IP %s was not released from network %s of server %s, so it was not moved to network %s: %s",
+								floatingIPID, move.FromNetworkID, serverRes.Server.ID, move.ToNetworkID, err.Error())
+						}
+						resp.Diagnostics.AddError("Failed to disassociate floating IP", detail)
+						return
+					}
+				}
 
 				// Refresh server state after disassociation to get updated NIC information
 				serverRes, err = vpsClient.Servers().Get(ctx, serverRes.Server.ID)
@@ -1090,29 +1720,12 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 				}
 			}
 
-			// Find floating IPs to associate (in plan but not in state, or changed)
-			floatingIPsToAssociate := make([]resourcemodels.NetworkAttachmentModel, 0)
-			for _, planAtt := range planNetworkAttachments {
-				if planAtt.FloatingIPID.IsNull() || planAtt.FloatingIPID.IsUnknown() {
-					continue
-				}
-
-				networkID := planAtt.NetworkID.ValueString()
-				planFIPID := planAtt.FloatingIPID.ValueString()
-				stateFIPID, existsInState := stateFIPsByNetwork[networkID]
-
-				if !existsInState || stateFIPID != planFIPID {
-					// New floating IP or changed floating IP for this network
-					floatingIPsToAssociate = append(floatingIPsToAssociate, planAtt)
-				}
-			}
-
 			// Associate new/changed floating IPs
 			if len(floatingIPsToAssociate) > 0 {
 				tflog.Debug(ctx, "Associating floating IPs during update", map[string]interface{}{
 					"count": len(floatingIPsToAssociate),
 				})
-				resp.Diagnostics.Append(helper.AssociateFloatingIPsForServer(ctx, serverRes, floatingIPsToAssociate)...)
+				resp.Diagnostics.Append(helper.AssociateFloatingIPsForServer(ctx, serverRes, vpsClient.FloatingIPs(), floatingIPsToAssociate, plan.FloatingIPAssociation.ValueString(), time.Until(deadline))...)
 				if resp.Diagnostics.HasError() {
 					return
 				}
@@ -1139,102 +1752,39 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		// Reorder network_attachment to match plan order (to avoid spurious diffs)
 		// This is critical after adding/removing NICs to ensure computed fields (IP addresses) are correct
 		// Note: planNetworkAttachments already extracted earlier for floating IP change detection
-		if len(planNetworkAttachments) > 0 {
-			// Fetch fresh NICs
-			nics, err := serverRes.NICs().List(ctx)
-			if err == nil {
-				nicMap := make(map[string]*servermodels.ServerNIC, len(nics))
-				for _, nic := range nics {
-					nicMap[nic.NetworkID] = nic
-				}
-
-				networkAttachmentAttrTypes := map[string]attr.Type{
-					"network_id":         types.StringType,
-					"ip_address":         types.StringType,
-					"primary":            types.BoolType,
-					"security_group_ids": types.ListType{ElemType: types.StringType},
-					"floating_ip_id":     types.StringType,
-					"floating_ip":        types.StringType,
-				}
-
-				ordered := make([]attr.Value, 0, len(planNetworkAttachments))
-				// Add attachments in plan order
-				for _, p := range planNetworkAttachments {
-					nid := p.NetworkID.ValueString()
-					nic := nicMap[nid]
-					if nic == nil {
-						// NIC not found - this shouldn't happen after successful add, but handle gracefully
-						tflog.Warn(ctx, "NIC not found for planned network after update", map[string]interface{}{"network_id": nid})
-						continue
-					}
-
-					// Map SecurityGroupIDs: prefer plan-specified order if present
-					sgVals := make([]attr.Value, 0)
-					var planSGs []types.String
-					if d := p.SecurityGroupIDs.ElementsAs(ctx, &planSGs, false); len(d) == 0 && len(planSGs) > 0 {
-						for _, sg := range planSGs {
-							sgVals = append(sgVals, types.StringValue(sg.ValueString()))
-						}
-					} else {
-						sgIDs := make([]string, len(nic.SGIDs))
-						copy(sgIDs, nic.SGIDs)
-						sort.Strings(sgIDs)
-						for _, sg := range sgIDs {
-							sgVals = append(sgVals, types.StringValue(sg))
-						}
-					}
-					sgList, d := types.ListValue(types.StringType, sgVals)
-					diags.Append(d...)
-
-					// IP address from NIC (this is the critical part - use actual assigned IP)
-					ipAddress := types.StringNull()
-					if len(nic.Addresses) > 0 {
-						ipAddress = types.StringValue(nic.Addresses[0])
-					}
-
-					// Extract floating IP info from NIC (computed from actual server state)
-					floatingIPID := types.StringNull()
-					floatingIPAddress := types.StringNull()
-					if nic.FloatingIP != nil {
-						floatingIPID = types.StringValue(nic.FloatingIP.ID)
-						floatingIPAddress = types.StringValue(nic.FloatingIP.Address)
-					}
-
-					attObj, d := types.ObjectValue(networkAttachmentAttrTypes, map[string]attr.Value{
-						"network_id":         types.StringValue(nid),
-						"ip_address":         ipAddress,
-						"primary":            types.BoolValue(p.Primary.ValueBool()),
-						"security_group_ids": sgList,
-						"floating_ip_id":     floatingIPID,
-						"floating_ip":        floatingIPAddress,
-					})
-					diags.Append(d...)
-					ordered = append(ordered, attObj)
-					// Remove from nicMap so remaining NICs can be appended later (if any)
-					delete(nicMap, nid)
-				}
-
-				// Build final list and set on newState
-				networkAttachmentList, d := types.ListValue(
-					types.ObjectType{AttrTypes: networkAttachmentAttrTypes},
-					ordered,
-				)
-				diags.Append(d...)
-				if !d.HasError() {
-					newState.NetworkAttachment = networkAttachmentList
-				}
+		var apiNetworkAttachments []resourcemodels.NetworkAttachmentModel
+		if d := newState.NetworkAttachment.ElementsAs(ctx, &apiNetworkAttachments, false); len(planNetworkAttachments) > 0 && !d.HasError() {
+			planOrder, apiNics, d := helper.WithoutNamedSecurityGroups(ctx, planNetworkAttachments, apiNetworkAttachments, nameIDs, helper.OrderFromPlan)
+			resp.Diagnostics.Append(d...)
+			networkAttachmentList, d := helper.OrderNetworkAttachments(ctx, planOrder, apiNics, helper.OrderFromPlan)
+			resp.Diagnostics.Append(d...)
+			if !d.HasError() {
+				newState.NetworkAttachment = networkAttachmentList
 			}
 		}
 
 		// Preserve user-provided values that aren't returned by API
 		newState.UserData = plan.UserData
+		newState.UserDataFile = plan.UserDataFile
 		newState.Password = plan.Password
 		newState.Keypair = plan.Keypair
+		newState.RebootTrigger = plan.RebootTrigger
+		newState.Description = helper.PreserveEmptyDescription(newState.Description, plan.Description)
+		if newState.RootDiskGB.IsNull() && !plan.RootDiskGB.IsUnknown() {
+			newState.RootDiskGB = plan.RootDiskGB
+		}
 
 		// Preserve runtime-only config from plan (these can be changed without triggering server updates)
 		newState.WaitForActive = plan.WaitForActive
+		newState.WaitUntilStatus = plan.WaitUntilStatus
 		newState.WaitForDeleted = plan.WaitForDeleted
+		newState.FloatingIPAssociation = plan.FloatingIPAssociation
 		newState.Timeouts = plan.Timeouts
+		newState.DeletionProtection = plan.DeletionProtection
+		newState.WindowsPasswordPrivateKey = plan.WindowsPasswordPrivateKey
+		newState.WindowsPassword = plan.WindowsPassword
+		newState.Revision = helper.ServerRevision(plan.ImageID, plan.FlavorID, plan.Keypair, userData)
+		r.readWindowsPassword(ctx, &newState)
 
 		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 	} else {
@@ -1244,8 +1794,24 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		// Even though no API calls are needed, we still need to update runtime-only attributes
 		// in state to match the plan (these don't trigger actual server updates)
 		state.WaitForActive = plan.WaitForActive
+		state.WaitUntilStatus = plan.WaitUntilStatus
 		state.WaitForDeleted = plan.WaitForDeleted
+		state.FloatingIPAssociation = plan.FloatingIPAssociation
 		state.Timeouts = plan.Timeouts
+		state.RebootTrigger = plan.RebootTrigger
+		state.DeletionProtection = plan.DeletionProtection
+		state.WindowsPasswordPrivateKey = plan.WindowsPasswordPrivateKey
+		state.WindowsPassword = plan.WindowsPassword
+		state.Revision = helper.ServerRevision(plan.ImageID, plan.FlavorID, plan.Keypair, userData)
+		r.readWindowsPassword(ctx, &state)
+
+		// dns_nameservers and host_routes changes alone need no API call either
+		networkAttachmentList, d := helper.WithNICSettings(ctx, state.NetworkAttachment, plannedNICs)
+		resp.Diagnostics.Append(d...)
+		if d.HasError() {
+			return
+		}
+		state.NetworkAttachment = networkAttachmentList
 
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	}
@@ -1263,10 +1829,7 @@ func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportSta
 	vpsClient := r.client.VPS()
 	serverRes, err := vpsClient.Servers().Get(ctx, serverID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Import Error",
-			fmt.Sprintf("Unable to read server %s: %s", serverID, err),
-		)
+		resp.Diagnostics.Append(helper.APIErrorDiagnostic("Import Error", fmt.Sprintf("read server %s", serverID), err))
 		return
 	}
 
@@ -1276,6 +1839,8 @@ func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportSta
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Every NIC must carry its floating IP, not only the ones the NIC listing reports.
+	resp.Diagnostics.Append(helper.FillNICFloatingIPs(ctx, vpsClient.FloatingIPs(), serverRes.Server.ID, serverRes.Server.PublicIPs, &state)...)
 
 	// T055: user_data and password are never available after creation (security), set to null
 	// These are already set to null in mapServerToState helper
@@ -1285,6 +1850,11 @@ func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportSta
 	// Set default values for client-side flags (not stored in API)
 	state.WaitForActive = types.BoolValue(true)  // Default behavior
 	state.WaitForDeleted = types.BoolValue(true) // Default behavior
+	state.WaitUntilStatus = types.StringNull()
+	state.FloatingIPAssociation = types.StringValue(helper.FloatingIPAssociationStrict)
+	state.DeletionProtection = types.BoolValue(false)
+	state.WindowsPasswordPrivateKey = types.StringNull()
+	state.WindowsPassword = types.StringNull()
 
 	// Set timeouts to null (not stored in API, user can configure in Terraform)
 	timeoutsAttrTypes := map[string]attr.Type{
@@ -1294,6 +1864,7 @@ func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportSta
 	}
 	timeoutsNull := types.ObjectNull(timeoutsAttrTypes)
 	state.Timeouts = timeoutsNull
+	state.Revision = helper.ServerRevision(state.ImageID, state.FlavorID, state.Keypair, state.UserData)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	tflog.Info(ctx, "Imported server", map[string]interface{}{
@@ -4,13 +4,16 @@
 package resource_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -33,6 +36,7 @@ func TestAccServerResource_Basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "image_id"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "status"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "created_at"),
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "revision"),
 					// Verify network_attachment
 					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.#", "1"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.0.network_id"),
@@ -185,6 +189,9 @@ func TestAccServerResource_MultiNetwork(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.1.network_id"),
 					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.primary", "true"),
 					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.1.primary", "false"),
+					// One NIC ID per attached network
+					resource.TestCheckResourceAttr("zillaforge_server.test", "nic_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "ipv4_addresses.0"),
 				)},
 		},
 	})
@@ -281,6 +288,64 @@ resource "zillaforge_server" "test" {
 }
 `
 
+func TestAccServerResource_SecurityGroupNames(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-sg-names-%d", time.Now().UnixNano()%100000)
+	config := fmt.Sprintf(testAccServerResourceConfig_securityGroupNames, name, name, name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The group attached by name is not copied into security_group_ids
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.security_group_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.0.security_group_ids.0", "zillaforge_security_group.sg1", "id"),
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.security_group_names.#", "1"),
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.security_group_names.0", name+"-sg2"),
+				),
+			},
+			{
+				// Refresh must not report the named group as drift
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_securityGroupNames = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_security_group" "sg1" {
+  name = "%s-sg1"
+}
+
+resource "zillaforge_security_group" "sg2" {
+  name = "%s-sg2"
+}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id           = data.zillaforge_networks.test.networks[0].id
+    security_group_ids   = [zillaforge_security_group.sg1.id]
+    security_group_names = [zillaforge_security_group.sg2.name]
+  }
+}
+`
+
 // T018: Acceptance test - Server destruction.
 func TestAccServerResource_Destroy(t *testing.T) {
 	t.Parallel()
@@ -494,7 +559,7 @@ func TestAccServerResource_WaitForActiveTrue(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "flavor_id"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "image_id"),
 					// Status should be "active" (lowercase) since we waited
-					resource.TestCheckResourceAttr("zillaforge_server.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("zillaforge_server.test", "status", "active"),
 				),
 			},
 		},
@@ -549,7 +614,7 @@ func TestAccServerResource_WaitForActiveDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("zillaforge_server.test", "wait_for_active", "true"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "id"),
 					// Status should be "active" (lowercase) since default is to wait
-					resource.TestCheckResourceAttr("zillaforge_server.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("zillaforge_server.test", "status", "active"),
 				),
 			},
 		},
@@ -833,6 +898,71 @@ resource "zillaforge_server" "test" {
 }
 `
 
+// Acceptance test: Removing the description clears it in the API without drift.
+func TestAccServerResource_ClearDescription(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-desc-clear-%d", time.Now().UnixNano()%100000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_updateDescription, name, name, "To be cleared"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "description", "To be cleared"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_noDescription, name, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zillaforge_server.test", "description"),
+				),
+			},
+			{
+				// Refresh must not bring the old description back
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zillaforge_server.test", "description"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_noDescription, name, name),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_noDescription = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_security_group" "sg" {
+  name = "%s-sg"
+}
+
+resource "zillaforge_server" "test" {
+  name        = "%s"
+  flavor_id   = data.zillaforge_flavors.test.flavors[0].id
+  image_id    = data.zillaforge_images.test.images[0].id
+  password    = "TestPassword123!"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+    security_group_ids = [zillaforge_security_group.sg.id]
+  }
+}
+`
+
 // Acceptance test: Plan-time rejection when attempting to modify flavor_id.
 func TestAccServerResource_ModifyFlavorPlanTimeReject(t *testing.T) {
 	t.Parallel()
@@ -1390,10 +1520,19 @@ func TestAccServerResource_FloatingIP_AssociateCreate(t *testing.T) {
 					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.#", "1"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.0.floating_ip_id"),
 					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.0.floating_ip"),
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.0.public_ip", "zillaforge_server.test", "network_attachment.0.floating_ip"),
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.0.ip_address"),
 					// Verify floating IP is actually associated
 					testAccCheckFloatingIPAssociated("zillaforge_floating_ip.test"),
 				),
 			},
+			// The refreshed floating IP names the server it points at
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_floating_ip.test", "associated_server_name", name),
+				),
+			},
 		},
 	})
 }
@@ -1663,6 +1802,48 @@ resource "zillaforge_server" "test" {
 }
 `
 
+// Acceptance test - A floating IP disassociated outside Terraform shows as
+// drift and the next apply associates it again.
+func TestAccServerResource_FloatingIP_DisassociatedOutOfBand(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-fip-oob-%d", time.Now().UnixNano()%100000)
+	config := fmt.Sprintf(testAccServerResourceConfig_floatingIPDisassociate_with, name, name, name)
+
+	var floatingIPID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("zillaforge_server.test", "network_attachment.0.floating_ip_id", func(value string) error {
+						floatingIPID = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := provider.TestAccProjectClient(t).VPS().FloatingIPs().Disassociate(context.Background(), floatingIPID); err != nil {
+						t.Fatalf("failed to disassociate floating IP: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.0.floating_ip_id", "zillaforge_floating_ip.test", "id"),
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "network_attachment.0.floating_ip"),
+				),
+			},
+		},
+	})
+}
+
 // T022: Acceptance test - Verify repeated disassociation is idempotent.
 func TestAccServerResource_FloatingIP_DisassociateIdempotent(t *testing.T) {
 	t.Parallel()
@@ -2021,3 +2202,368 @@ resource "zillaforge_server" "test" {
   }
 }
 `
+
+// Acceptance test - Import a two-NIC server where only the second NIC has a
+// floating IP. The attachments are declared in network ID order, which is the
+// order import produces, so the imported state must match without drift.
+func TestAccServerResource_ImportMultiNICMixedFloatingIP(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-fip-multi-import-%d", time.Now().UnixNano()%100000)
+	config := fmt.Sprintf(testAccServerResourceConfig_importMultiNICMixedFloatingIP, name, name, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.#", "2"),
+					resource.TestCheckNoResourceAttr("zillaforge_server.test", "network_attachment.0.floating_ip_id"),
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.1.floating_ip_id", "zillaforge_floating_ip.test", "id"),
+					resource.TestCheckResourceAttrPair("zillaforge_server.test", "network_attachment.1.floating_ip", "zillaforge_floating_ip.test", "ip_address"),
+				),
+			},
+			{
+				ResourceName:            "zillaforge_server.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "user_data", "wait_for_active", "wait_for_deleted", "timeouts"},
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_importMultiNICMixedFloatingIP = `
+data "zillaforge_flavors" "test" {}
+data "zillaforge_images" "test" {}
+data "zillaforge_networks" "test" {}
+
+locals {
+  network_ids = sort(slice(data.zillaforge_networks.test.networks[*].id, 0, 2))
+}
+
+resource "zillaforge_security_group" "sg" {
+  name = "%s-sg"
+}
+
+resource "zillaforge_floating_ip" "test" {
+  name = "%s-fip"
+}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = local.network_ids[0]
+    primary    = true
+    security_group_ids = [zillaforge_security_group.sg.id]
+  }
+
+  network_attachment {
+    network_id = local.network_ids[1]
+    security_group_ids = [zillaforge_security_group.sg.id]
+    floating_ip_id = zillaforge_floating_ip.test.id
+  }
+}
+`
+
+// Acceptance test - Over-long name and description are rejected at plan time.
+func TestAccServerResource_NameDescriptionLengthPlanTimeReject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccServerResourceConfig_lengthCheck, strings.Repeat("n", 256), "ok"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)Attribute name string length must be between 1 and 255`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccServerResourceConfig_lengthCheck, "test-server-length", strings.Repeat("d", 1001)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)Attribute description string length must be at most 1000`),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_lengthCheck = `
+resource "zillaforge_server" "test" {
+  name        = "%s"
+  description = "%s"
+  flavor_id   = "flavor-id"
+  image_id    = "image-id"
+
+  network_attachment {
+    network_id = "network-id"
+  }
+}
+`
+
+// Acceptance test - Changing reboot_trigger reboots in place without replacement.
+func TestAccServerResource_RebootTrigger(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-reboot-%d", time.Now().UnixNano()%100000)
+	var serverID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_rebootTrigger, name, "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "reboot_trigger", "v1"),
+					resource.TestCheckResourceAttrWith("zillaforge_server.test", "id", func(value string) error {
+						serverID = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_rebootTrigger, name, "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "reboot_trigger", "v2"),
+					resource.TestCheckResourceAttr("zillaforge_server.test", "status", "active"),
+					resource.TestCheckResourceAttrWith("zillaforge_server.test", "id", func(value string) error {
+						if value != serverID {
+							return fmt.Errorf("expected in-place reboot, but server was replaced (%s -> %s)", serverID, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_rebootTrigger = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name           = "%s"
+  flavor_id      = data.zillaforge_flavors.test.flavors[0].id
+  image_id       = data.zillaforge_images.test.images[0].id
+  password       = "TestPassword123!"
+  reboot_trigger = "%s"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+  }
+}
+`
+
+// Acceptance test - root_disk_gb grows in place and rejects shrinking at plan time.
+func TestAccServerResource_RootDiskGrowOnly(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-rootdisk-%d", time.Now().UnixNano()%100000)
+	var serverID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_rootDisk, name, 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "root_disk_gb", "20"),
+					resource.TestCheckResourceAttrWith("zillaforge_server.test", "id", func(value string) error {
+						serverID = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_rootDisk, name, 30),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("zillaforge_server.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "root_disk_gb", "30"),
+					resource.TestCheckResourceAttrWith("zillaforge_server.test", "id", func(value string) error {
+						if value != serverID {
+							return fmt.Errorf("expected in-place expansion, but server was replaced (%s -> %s)", serverID, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccServerResourceConfig_rootDisk, name, 20),
+				ExpectError: regexp.MustCompile(`(?i)Unsupported Change: root_disk_gb`),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_rootDisk = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name         = "%s"
+  flavor_id    = [for f in data.zillaforge_flavors.test.flavors : f.id if f.disk == 0][0]
+  image_id     = data.zillaforge_images.test.images[0].id
+  password     = "TestPassword123!"
+  root_disk_gb = %d
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+  }
+}
+`
+
+// Acceptance test - a fixed ip_address outside the network CIDR fails at plan time.
+func TestAccServerResource_FixedIPOutsideCIDRPlanTimeReject(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-badip-%d", time.Now().UnixNano()%100000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccServerResourceConfig_fixedIPOutsideCIDR, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Fixed IP Address`),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_fixedIPOutsideCIDR = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+    # TEST-NET-3 documentation address, never inside a project network
+    ip_address = "203.0.113.250"
+  }
+}
+`
+
+// Acceptance test - NIC without security_group_ids produces no post-create diff.
+func TestAccServerResource_NoSecurityGroups(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-nosg-%d", time.Now().UnixNano()%100000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_noSecurityGroups, name),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("zillaforge_server.test", "id"),
+					resource.TestCheckNoResourceAttr("zillaforge_server.test", "network_attachment.0.security_group_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_noSecurityGroups = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id = data.zillaforge_networks.test.networks[0].id
+  }
+}
+`
+
+// Acceptance test - dns_nameservers round-trips through state and can be
+// changed on its own without drift.
+func TestAccServerResource_NICSettings(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("test-server-nicopts-%d", time.Now().UnixNano()%100000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_nicSettings, name, "1.1.1.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.dns_nameservers.0", "1.1.1.1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccServerResourceConfig_nicSettings, name, "8.8.8.8"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("zillaforge_server.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zillaforge_server.test", "network_attachment.0.dns_nameservers.0", "8.8.8.8"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerResourceConfig_nicSettings = `
+data "zillaforge_flavors" "test" {}
+
+data "zillaforge_images" "test" {}
+
+data "zillaforge_networks" "test" {}
+
+resource "zillaforge_server" "test" {
+  name      = "%s"
+  flavor_id = data.zillaforge_flavors.test.flavors[0].id
+  image_id  = data.zillaforge_images.test.images[0].id
+  password  = "TestPassword123!"
+  wait_for_deleted = false
+
+  network_attachment {
+    network_id      = data.zillaforge_networks.test.networks[0].id
+    dns_nameservers = ["%s"]
+  }
+}
+`
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The helper package builds network_attachment values for create, read,
+// update and import; they must have exactly the schema's object type.
+func TestServerSchema_NetworkAttachmentAttrTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewServerResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attrType, diags := schemaResp.Schema.TypeAtPath(ctx, path.Root("network_attachment"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	listType, ok := attrType.(types.ListType)
+	if !ok {
+		t.Fatalf("expected network_attachment to be a list, got %s", attrType)
+	}
+	if expected := (types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}); !listType.ElemType.Equal(expected) {
+		t.Errorf("schema element type %s does not match helper.NetworkAttachmentAttrTypes %s", listType.ElemType, expected)
+	}
+}
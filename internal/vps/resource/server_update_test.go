@@ -0,0 +1,449 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	resourcemodels "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestServerUpdate_FloatingIPSwap(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		serverID  = "11111111-1111-1111-1111-111111111111"
+		networkID = "22222222-2222-2222-2222-222222222222"
+		oldFIPID  = "33333333-3333-3333-3333-333333333333"
+		newFIPID  = "44444444-4444-4444-4444-444444444444"
+	)
+
+	// The fake NIC holds a single floating IP and rejects a second one, as
+	// the platform does, and records the swap steps in order.
+	var (
+		mu     sync.Mutex
+		nicFIP = oldFIPID
+		events []string
+	)
+	record := func(event string) {
+		events = append(events, event)
+	}
+	fipJSON := func(id string) string {
+		if nicFIP == id {
+			return `{"id":"` + id + `","device_id":"` + serverID + `"}`
+		}
+		return `{"id":"` + id + `"}`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+oldFIPID+"/disassociate"):
+			record("disassociate " + oldFIPID)
+			nicFIP = ""
+			_, _ = w.Write([]byte(fipJSON(oldFIPID)))
+		case strings.HasSuffix(r.URL.Path, "/nics/nic-1/floatingip"):
+			if nicFIP != "" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"errorCode":409,"message":"nic already has a floating ip"}`))
+				return
+			}
+			record("associate " + newFIPID)
+			nicFIP = newFIPID
+			_, _ = w.Write([]byte(fipJSON(newFIPID)))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+oldFIPID):
+			if nicFIP != oldFIPID {
+				record("released " + oldFIPID)
+			}
+			_, _ = w.Write([]byte(fipJSON(oldFIPID)))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+newFIPID):
+			_, _ = w.Write([]byte(fipJSON(newFIPID)))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[{"id":"nic-1","network_id":"` + networkID + `","addresses":["10.0.0.5"]}]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(`{"id":"` + serverID + `","name":"web","status":"ACTIVE"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attachment := func(floatingIPID string, floatingIP types.String) types.List {
+		return types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{
+			types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+				"network_id":           types.StringValue(networkID),
+				"ip_address":           types.StringValue("10.0.0.5"),
+				"primary":              types.BoolValue(true),
+				"security_group_ids":   types.ListNull(types.StringType),
+				"floating_ip_id":       types.StringValue(floatingIPID),
+				"floating_ip":          floatingIP,
+				"public_ip":            floatingIP,
+				"security_group_names": types.ListNull(types.StringType),
+				"dns_nameservers":      types.ListNull(types.StringType),
+				"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+				"fixed_ip_strategy":    types.StringNull(),
+			}),
+		})
+	}
+
+	// build returns the raw server state or plan for the given attachments.
+	build := func(networkAttachment types.List) tftypes.Value {
+		values := map[string]interface{}{
+			"id":                      serverID,
+			"name":                    "web",
+			"flavor_id":               "55555555-5555-5555-5555-555555555555",
+			"image_id":                "66666666-6666-6666-6666-666666666666",
+			"network_attachment":      networkAttachment,
+			"wait_for_active":         true,
+			"wait_for_deleted":        true,
+			"floating_ip_association": helper.FloatingIPAssociationStrict,
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for attr, value := range values {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: build(attachment(oldFIPID, types.StringValue("203.0.113.10")))}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: build(attachment(newFIPID, types.StringUnknown()))}
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"disassociate " + oldFIPID, "released " + oldFIPID, "associate " + newFIPID}
+	if strings.Join(events, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expected the old floating IP to be freed before the new one is bound:\nexpected %v\ngot      %v", expected, events)
+	}
+}
+
+func TestServerUpdate_FloatingIPMovedBetweenNICs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		serverID   = "11111111-1111-1111-1111-111111111111"
+		networkAID = "22222222-2222-2222-2222-222222222222"
+		networkBID = "33333333-3333-3333-3333-333333333333"
+		fipID      = "44444444-4444-4444-4444-444444444444"
+	)
+
+	// The floating IP starts on nic-a. The fake rejects binding it to nic-b
+	// while it is still bound, as the platform does, and records each step.
+	var (
+		mu      sync.Mutex
+		boundTo = "nic-a"
+		events  []string
+	)
+	fipJSON := func() string {
+		if boundTo != "" {
+			return `{"id":"` + fipID + `","address":"203.0.113.10","device_id":"` + serverID + `"}`
+		}
+		return `{"id":"` + fipID + `","address":"203.0.113.10"}`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+fipID+"/disassociate"):
+			events = append(events, "disassociate from "+boundTo)
+			boundTo = ""
+			_, _ = w.Write([]byte(fipJSON()))
+		case strings.HasSuffix(r.URL.Path, "/nics/nic-b/floatingip"):
+			if boundTo != "" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"errorCode":409,"message":"floating ip already associated"}`))
+				return
+			}
+			events = append(events, "associate to nic-b")
+			boundTo = "nic-b"
+			_, _ = w.Write([]byte(fipJSON()))
+		case strings.HasSuffix(r.URL.Path, "/floatingips/"+fipID):
+			_, _ = w.Write([]byte(fipJSON()))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[{"id":"nic-a","network_id":"` + networkAID + `","addresses":["10.0.0.5"]},{"id":"nic-b","network_id":"` + networkBID + `","addresses":["10.1.0.5"]}]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(`{"id":"` + serverID + `","name":"web","status":"ACTIVE"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	nic := func(networkID, ip string, primary bool, floatingIPID, floatingIP types.String) attr.Value {
+		return types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+			"network_id":           types.StringValue(networkID),
+			"ip_address":           types.StringValue(ip),
+			"primary":              types.BoolValue(primary),
+			"security_group_ids":   types.ListNull(types.StringType),
+			"floating_ip_id":       floatingIPID,
+			"floating_ip":          floatingIP,
+			"public_ip":            floatingIP,
+			"security_group_names": types.ListNull(types.StringType),
+			"dns_nameservers":      types.ListNull(types.StringType),
+			"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+			"fixed_ip_strategy":    types.StringNull(),
+		})
+	}
+	attachments := func(nics ...attr.Value) types.List {
+		return types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, nics)
+	}
+
+	// build returns the raw server state or plan for the given attachments.
+	build := func(networkAttachment types.List) tftypes.Value {
+		values := map[string]interface{}{
+			"id":                      serverID,
+			"name":                    "web",
+			"flavor_id":               "55555555-5555-5555-5555-555555555555",
+			"image_id":                "66666666-6666-6666-6666-666666666666",
+			"network_attachment":      networkAttachment,
+			"wait_for_active":         true,
+			"wait_for_deleted":        true,
+			"floating_ip_association": helper.FloatingIPAssociationStrict,
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for attr, value := range values {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: build(attachments(
+		nic(networkAID, "10.0.0.5", true, types.StringValue(fipID), types.StringValue("203.0.113.10")),
+		nic(networkBID, "10.1.0.5", false, types.StringNull(), types.StringNull()),
+	))}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: build(attachments(
+		nic(networkAID, "10.0.0.5", true, types.StringNull(), types.StringUnknown()),
+		nic(networkBID, "10.1.0.5", false, types.StringValue(fipID), types.StringUnknown()),
+	))}
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var updated []resourcemodels.NetworkAttachmentModel
+	var networkAttachment types.List
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("network_attachment"), &networkAttachment)...)
+	resp.Diagnostics.Append(networkAttachment.ElementsAs(ctx, &updated, false)...)
+	if resp.Diagnostics.HasError() || len(updated) != 2 {
+		t.Fatalf("unexpected network_attachment in state: %v %v", networkAttachment, resp.Diagnostics.Errors())
+	}
+	if !updated[0].FloatingIPID.IsNull() || updated[1].FloatingIPID.ValueString() != fipID {
+		t.Errorf("expected the floating IP on the second NIC in state, got %s and %s", updated[0].FloatingIPID, updated[1].FloatingIPID)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"disassociate from nic-a", "associate to nic-b"}
+	if strings.Join(events, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expected the floating IP to move between NICs in place:\nexpected %v\ngot      %v", expected, events)
+	}
+}
+
+func TestServerUpdate_SecurityGroupRenamedWithReference(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		serverID  = "11111111-1111-1111-1111-111111111111"
+		networkID = "22222222-2222-2222-2222-222222222222"
+		sgID      = "33333333-3333-3333-3333-333333333333"
+	)
+
+	// The group referenced as "web" was renamed in place to "web-v2", and the
+	// configuration is updated to the new name in the same apply.
+	var (
+		mu       sync.Mutex
+		nicSGIDs []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/iam/"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/security_groups"):
+			_, _ = w.Write([]byte(`{"security_groups":[{"id":"` + sgID + `","name":"web-v2"}]}`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/nics/nic-1"):
+			var req struct {
+				SGIDs []string `json:"sg_ids"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			nicSGIDs = req.SGIDs
+			_, _ = w.Write([]byte(`{"id":"nic-1","network_id":"` + networkID + `","addresses":["10.0.0.5"],"sg_ids":["` + sgID + `"]}`))
+		case strings.HasSuffix(r.URL.Path, "/nics"):
+			_, _ = w.Write([]byte(`{"nics":[{"id":"nic-1","network_id":"` + networkID + `","addresses":["10.0.0.5"],"sg_ids":["` + sgID + `"]}]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers/"+serverID):
+			_, _ = w.Write([]byte(`{"id":"` + serverID + `","name":"web","status":"ACTIVE"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":404,"message":"resource not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cloudsdk.New(srv.URL, "header.payload.signature")
+	if err != nil {
+		t.Fatalf("failed to create SDK client: %v", err)
+	}
+	projectClient, err := client.Project(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("failed to create project client: %v", err)
+	}
+
+	r := NewServerResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &helper.ProviderData{Client: projectClient},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	attachment := func(sgName string) types.List {
+		return types.ListValueMust(types.ObjectType{AttrTypes: helper.NetworkAttachmentAttrTypes}, []attr.Value{
+			types.ObjectValueMust(helper.NetworkAttachmentAttrTypes, map[string]attr.Value{
+				"network_id":           types.StringValue(networkID),
+				"ip_address":           types.StringValue("10.0.0.5"),
+				"primary":              types.BoolValue(true),
+				"security_group_ids":   types.ListNull(types.StringType),
+				"floating_ip_id":       types.StringNull(),
+				"floating_ip":          types.StringNull(),
+				"public_ip":            types.StringNull(),
+				"security_group_names": types.ListValueMust(types.StringType, []attr.Value{types.StringValue(sgName)}),
+				"dns_nameservers":      types.ListNull(types.StringType),
+				"host_routes":          types.ListNull(types.ObjectType{AttrTypes: helper.HostRouteAttrTypes}),
+				"fixed_ip_strategy":    types.StringNull(),
+			}),
+		})
+	}
+
+	build := func(networkAttachment types.List) tftypes.Value {
+		values := map[string]interface{}{
+			"id":                      serverID,
+			"name":                    "web",
+			"flavor_id":               "55555555-5555-5555-5555-555555555555",
+			"image_id":                "66666666-6666-6666-6666-666666666666",
+			"network_attachment":      networkAttachment,
+			"wait_for_active":         true,
+			"wait_for_deleted":        true,
+			"floating_ip_association": helper.FloatingIPAssociationStrict,
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for attr, value := range values {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags.Errors())
+			}
+		}
+		return state.Raw
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: build(attachment("web"))}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: build(attachment("web-v2"))}
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var names []string
+	if diags := resp.State.GetAttribute(ctx, path.Root("network_attachment").AtListIndex(0).AtName("security_group_names"), &names); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags.Errors())
+	}
+	if len(names) != 1 || names[0] != "web-v2" {
+		t.Errorf("expected security_group_names [web-v2], got %v", names)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(nicSGIDs) != 1 || nicSGIDs[0] != sgID {
+		t.Errorf("expected the NIC to keep group %s, got %v", sgID, nicSGIDs)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/models/vrm/common"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/validators"
+	vps_helper "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/helper"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImageDataSource{}
+
+// NewImageDataSource creates a new instance of the image data source.
+func NewImageDataSource() datasource.DataSource {
+	return &ImageDataSource{}
+}
+
+// ImageDataSource defines the single-image lookup data source implementation.
+type ImageDataSource struct {
+	client *cloudsdk.ProjectClient
+}
+
+func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+func (d *ImageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single VM image (repository:tag pair) in the ZillaForge VRM service by its exact ID. " +
+			"Use this when an image ID is already known, for example from another configuration's state. " +
+			"Unlike `zillaforge_images`, a missing image is reported as an error rather than an empty result. " +
+			"API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the image tag to look up, as returned in `zillaforge_images.images[*].id`.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.ImageIDValidator(),
+				},
+			},
+
+			"repository_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the repository containing this image tag.",
+				Computed:            true,
+			},
+
+			"tag_name": schema.StringAttribute{
+				MarkdownDescription: "Tag name or label for this image version.",
+				Computed:            true,
+			},
+
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Image size in bytes.",
+				Computed:            true,
+			},
+
+			"operating_system": schema.StringAttribute{
+				MarkdownDescription: "Operating system type for this image. " +
+					"Valid values: `linux`, `windows`.",
+				Computed: true,
+			},
+
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Human-readable description of the image repository. " +
+					"Null if no description is provided.",
+				Computed: true,
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Tag type classification. " +
+					"Valid values: `common` (standard image), `increase` (incremental image).",
+				Computed: true,
+			},
+
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Current status of the image tag, e.g. `active`, `queued`, `saving`, `creating`, `error`, `deleted`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudsdk.ProjectClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudsdk.ProjectClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data model.ImageModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	tflog.Debug(ctx, "Reading image from VRM API", map[string]interface{}{
+		"id": id,
+	})
+
+	tag, err := vps_helper.WithRetry(ctx, func(ctx context.Context) (*common.Tag, error) {
+		return helper.GetImageByID(ctx, d.client.VRM(), id)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			resp.Diagnostics.AddError(
+				"Image Not Found",
+				fmt.Sprintf("No image with ID %q exists in this project. Use the `zillaforge_images` data source to list available images.", id),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to retrieve image",
+			fmt.Sprintf("Unable to get VRM tag %s: %s", id, err.Error()),
+		)
+		return
+	}
+
+	data = helper.TagToImageModel(tag)
+
+	tflog.Debug(ctx, "Successfully retrieved image", map[string]interface{}{
+		"id":    data.ID.ValueString(),
+		"image": data.RepositoryName.ValueString() + ":" + data.TagName.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
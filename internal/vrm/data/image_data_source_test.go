@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance test - Look up an image by the ID returned from zillaforge_images.
+// Expected: All attributes match the corresponding list entry.
+func TestAccImageDataSource_ByID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			provider.TestAccPreCheck(t)
+			skipIfNoMatchingImages(t, "", "", "")
+		},
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zillaforge_images" "all" {}
+
+data "zillaforge_image" "test" {
+  id = data.zillaforge_images.all.images[0].id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "id", "data.zillaforge_images.all", "images.0.id"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "repository_name", "data.zillaforge_images.all", "images.0.repository_name"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "tag_name", "data.zillaforge_images.all", "images.0.tag_name"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "size", "data.zillaforge_images.all", "images.0.size"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "operating_system", "data.zillaforge_images.all", "images.0.operating_system"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "type", "data.zillaforge_images.all", "images.0.type"),
+					resource.TestCheckResourceAttrPair("data.zillaforge_image.test", "status", "data.zillaforge_images.all", "images.0.status"),
+				),
+			},
+		},
+	})
+}
+
+// Acceptance test - Unknown image ID returns an error instead of an empty result.
+func TestAccImageDataSource_NotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { provider.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zillaforge_image" "test" {
+  id = "00000000-0000-0000-0000-000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile(`Image Not Found|Failed to retrieve image`),
+			},
+		},
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	vps_helper "github.com/Zillaforge/terraform-provider-zillaforge/internal/vps/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/helper"
 	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/model"
 
@@ -43,7 +44,8 @@ func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Queries VM images (represented as repository:tag pairs) from the ZillaForge VRM service. " +
 			"Images can be filtered by repository name, exact tag name, or tag name pattern. " +
-			"Each image includes metadata such as size, operating system, status, and a unique ID used for VM creation.",
+			"Each image includes metadata such as size, operating system, status, and a unique ID used for VM creation. " +
+			"API calls that cannot connect are retried up to 3 times, 2s, 4s and 8s apart; with the 30s request timeout, each call can take up to about 2m15s before the read fails.",
 
 		Attributes: map[string]schema.Attribute{
 			"repository": schema.StringAttribute{
@@ -71,6 +73,15 @@ func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Optional: true,
 			},
 
+			"fail_on_empty": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail, rather than return an empty `images` list, when the VRM image service cannot be reached: " +
+					"the request times out, loses its connection, is rate limited, or gets a 502, 503 or 504 after retries. " +
+					"Defaults to `true`. Set to `false` so that a VRM outage only produces a warning and does not block plans of unrelated resources; " +
+					"anything referencing `images[0]` still fails. Other errors, such as a denied permission, always fail. " +
+					"A successful query that matches no images returns an empty list either way.",
+				Optional: true,
+			},
+
 			"images": schema.ListNestedAttribute{
 				MarkdownDescription: "List of images matching the filter criteria. " +
 					"Returns an empty list if no images match. " +
@@ -187,13 +198,32 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	// FR-022: Use repository-scoped API when repository filter is provided for efficiency
 	if !data.Repository.IsNull() && data.Repository.ValueString() != "" {
-		tags, err = helper.ListTagsForRepository(ctx, d.client.VRM(), data.Repository.ValueString())
+		tags, err = vps_helper.WithRetry(ctx, func(ctx context.Context) ([]*common.Tag, error) {
+			return helper.ListTagsForRepository(ctx, d.client.VRM(), data.Repository.ValueString())
+		})
 	} else {
 		// Project-wide tag listing
-		tags, err = helper.ListAllTags(ctx, d.client.VRM())
+		tags, err = vps_helper.WithRetry(ctx, func(ctx context.Context) ([]*common.Tag, error) {
+			return helper.ListAllTags(ctx, d.client.VRM())
+		})
 	}
 
-	if err != nil {
+	switch {
+	case err != nil && (vps_helper.IsRetryable(err) || vps_helper.IsOutcomeUnknown(err)):
+		if data.FailOnEmpty.IsNull() || data.FailOnEmpty.ValueBool() {
+			resp.Diagnostics.AddError(
+				"VRM Unreachable",
+				fmt.Sprintf("The VRM image service could not be reached, so no images were listed: %s\n\n"+
+					"This is usually temporary; retry later. To let plans continue with an empty images list during an outage, set fail_on_empty = false.", err),
+			)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"VRM Unreachable",
+			fmt.Sprintf("The VRM image service could not be reached, so images is empty: %s", err),
+		)
+		tags = nil
+	case err != nil:
 		resp.Diagnostics.AddError(
 			"Failed to retrieve images",
 			fmt.Sprintf("Unable to query VRM tags: %s", err.Error()),
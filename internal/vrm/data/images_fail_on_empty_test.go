@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/terraform-provider-zillaforge/internal/vrm/data"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImagesDataSource_FailOnEmpty(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		tags          http.HandlerFunc
+		failOnEmpty   types.Bool
+		expectedError string
+		expectWarning bool
+	}{
+		{
+			name:          "unreachable",
+			tags:          slowTags,
+			failOnEmpty:   types.BoolNull(),
+			expectedError: "VRM Unreachable",
+		},
+		{
+			name:          "unreachable without fail_on_empty",
+			tags:          slowTags,
+			failOnEmpty:   types.BoolValue(false),
+			expectWarning: true,
+		},
+		{
+			name: "permission denied without fail_on_empty",
+			tags: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errorCode":403,"message":"forbidden"}`))
+			},
+			failOnEmpty:   types.BoolValue(false),
+			expectedError: "Failed to retrieve images",
+		},
+		{
+			name: "no matching images",
+			tags: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"tags":[]}`))
+			},
+			failOnEmpty: types.BoolNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/tags") {
+					tt.tags(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			client, err := cloudsdk.New(srv.URL, "header.payload.signature", cloudsdk.WithTimeout(100*time.Millisecond))
+			if err != nil {
+				t.Fatalf("failed to create SDK client: %v", err)
+			}
+			projectClient, err := client.Project(ctx, "test-project")
+			if err != nil {
+				t.Fatalf("failed to create project client: %v", err)
+			}
+
+			d := data.NewImagesDataSource()
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: projectClient}, &datasource.ConfigureResponse{})
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.SetAttribute(ctx, path.Root("fail_on_empty"), tt.failOnEmpty); diags.HasError() {
+				t.Fatalf("failed to build config: %v", diags.Errors())
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			if tt.expectedError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectedError {
+					t.Fatalf("expected error %q, got %v", tt.expectedError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.expectWarning {
+				t.Errorf("expected warning %t, got %v", tt.expectWarning, resp.Diagnostics)
+			}
+
+			var images types.List
+			resp.State.GetAttribute(ctx, path.Root("images"), &images)
+			if images.IsNull() || len(images.Elements()) != 0 {
+				t.Errorf("expected an empty images list, got %s", images)
+			}
+		})
+	}
+}
+
+// slowTags answers after the client's timeout, as an unreachable VRM would.
+func slowTags(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(300 * time.Millisecond)
+	_, _ = w.Write([]byte(`{"tags":[]}`))
+}
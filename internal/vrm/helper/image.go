@@ -97,6 +97,16 @@ func ListTagsForRepository(ctx context.Context, vrmClient *vrm.Client, repoName
 	return tags, nil
 }
 
+// GetImageByID retrieves a single image tag directly by its ID.
+func GetImageByID(ctx context.Context, vrmClient *vrm.Client, id string) (*common.Tag, error) {
+	tag, err := vrmClient.Tags().Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag %s: %w", id, err)
+	}
+
+	return tag, nil
+}
+
 // filterTags applies client-side filtering based on tag name or pattern.
 //
 // Notes:
@@ -7,13 +7,16 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 
 // ImagesDataSourceModel describes the data source config and filters.
 type ImagesDataSourceModel struct {
-	Repository types.String `tfsdk:"repository"`  // Optional filter
-	Tag        types.String `tfsdk:"tag"`         // Optional filter (mutually exclusive with tag_pattern)
-	TagPattern types.String `tfsdk:"tag_pattern"` // Optional filter (mutually exclusive with tag)
-	Images     []ImageModel `tfsdk:"images"`      // Computed results
+	Repository  types.String `tfsdk:"repository"`    // Optional filter
+	Tag         types.String `tfsdk:"tag"`           // Optional filter (mutually exclusive with tag_pattern)
+	TagPattern  types.String `tfsdk:"tag_pattern"`   // Optional filter (mutually exclusive with tag)
+	FailOnEmpty types.Bool   `tfsdk:"fail_on_empty"` // Optional, default true: fail rather than return no images when VRM is unreachable
+	Images      []ImageModel `tfsdk:"images"`        // Computed results
 }
 
-// ImageModel represents a single image (tag) in the results list.
+// ImageModel represents a single image (tag) in the results list. It is also
+// the model of the singular zillaforge_image data source, which takes `id` as
+// input and computes the remaining attributes.
 type ImageModel struct {
 	ID              types.String `tfsdk:"id"`
 	RepositoryName  types.String `tfsdk:"repository_name"`